@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// newInvokeCommand returns the command invoking a tool of the tools files.
+func newInvokeCommand(cmd *Command) *cobra.Command {
+	var params string
+	invokeCmd := &cobra.Command{
+		Use:   "invoke <tool>",
+		Short: "Invokes a tool without starting the server and prints its result",
+		Long: `Invokes a tool without starting the server and prints its result as JSON.
+
+Only the source and the embedding models used by the tool are initialized.
+Tools with authenticated parameters or that require client authorization
+cannot be invoked.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInvoke(c.Context(), cmd, args[0], params)
+		},
+	}
+	invokeCmd.Flags().StringVar(&params, "params", "{}", "Parameters of the tool as a JSON object, e.g. '{\"id\": 1}'.")
+	return invokeCmd
+}
+
+func runInvoke(ctx context.Context, cmd *Command, toolName, rawParams string) error {
+	ctx, err := cmd.setupSubcommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	toolsFile, _, err := cmd.loadToolsFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := invokeTool(ctx, cmd.cfg, toolsFile, toolName, rawParams)
+	if err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	out, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		errMsg := fmt.Errorf("unable to marshal result: %w", err)
+		cmd.logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	fmt.Fprintln(cmd.outStream, string(out))
+	return nil
+}
+
+// invokeTool initializes the tool of the tools file with only the resources
+// it uses, and invokes it with the parameters given as a JSON object.
+func invokeTool(ctx context.Context, cfg server.ServerConfig, toolsFile ToolsFile, toolName, rawParams string) (any, error) {
+	tc, ok := toolsFile.Tools[toolName]
+	if !ok {
+		return nil, fmt.Errorf("tool %q does not exist", toolName)
+	}
+
+	// restrict the config to the resources used by the tool
+	cfg.SourceConfigs = server.SourceConfigs{}
+	if sourceName := tools.ConfigSourceName(tc); sourceName != "" {
+		if sc, ok := toolsFile.Sources[sourceName]; ok {
+			cfg.SourceConfigs[sourceName] = sc
+		}
+	}
+	cfg.EmbeddingModelConfigs = server.EmbeddingModelConfigs{}
+	for _, p := range tools.ConfigParameters(tc) {
+		if ec, ok := toolsFile.EmbeddingModels[p.GetEmbeddedBy()]; ok {
+			cfg.EmbeddingModelConfigs[p.GetEmbeddedBy()] = ec
+		}
+	}
+	cfg.AuthServiceConfigs = toolsFile.AuthServices
+	cfg.ToolConfigs = server.ToolConfigs{toolName: tc}
+	if err := server.ValidateReferences(cfg); err != nil {
+		return nil, err
+	}
+
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := server.InitializeConfigs(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("toolbox failed to initialize: %w", err)
+	}
+	resourceMgr := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
+	tool := toolsMap[toolName]
+
+	clientAuth, err := tool.RequiresClientAuthorization(resourceMgr)
+	if err != nil {
+		return nil, fmt.Errorf("error during invocation: %w", err)
+	}
+	if clientAuth {
+		return nil, fmt.Errorf("tool %q requires client authorization, which is not supported by invoke", toolName)
+	}
+
+	var data map[string]any
+	if err := util.DecodeJSON(strings.NewReader(rawParams), &data); err != nil {
+		return nil, fmt.Errorf("parameters were invalid JSON: %w", err)
+	}
+	params, err := tool.ParseParams(data, map[string]map[string]any{})
+	if err != nil {
+		return nil, fmt.Errorf("provided parameters were invalid: %w", err)
+	}
+	params, err = tool.EmbedParams(ctx, params, embeddingModelsMap)
+	if err != nil {
+		return nil, fmt.Errorf("error embedding parameters: %w", err)
+	}
+
+	res, err := tool.Invoke(ctx, resourceMgr, params, "")
+	if err != nil {
+		return nil, fmt.Errorf("error while invoking tool: %w", err)
+	}
+	return res, nil
+}
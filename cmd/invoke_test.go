@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInvokeCommand(t *testing.T) {
+	toolsFile := writeSQLiteToolsFile(t, filepath.Join(t.TempDir(), "test.db"), "add")
+	tcs := []struct {
+		desc      string
+		args      []string
+		want      string
+		errString string
+	}{
+		{
+			desc: "invoke tool",
+			args: []string{"invoke", "add", "--tools-file", toolsFile, "--params", `{"a": 1, "b": 2}`},
+			want: "[\n  {\n    \"sum\": 3\n  }\n]\n",
+		},
+		{
+			desc:      "missing tool",
+			args:      []string{"invoke", "missing", "--tools-file", toolsFile},
+			errString: `tool "missing" does not exist`,
+		},
+		{
+			desc:      "missing parameter",
+			args:      []string{"invoke", "add", "--tools-file", toolsFile, "--params", `{"a": 1}`},
+			errString: "provided parameters were invalid",
+		},
+		{
+			desc:      "invalid JSON parameters",
+			args:      []string{"invoke", "add", "--tools-file", toolsFile, "--params", `{"a": 1`},
+			errString: "parameters were invalid JSON",
+		},
+		{
+			desc:      "missing tool name",
+			args:      []string{"invoke", "--tools-file", toolsFile},
+			errString: "accepts 1 arg(s), received 0",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+			c := NewCommand(WithStreams(out, errOut))
+			c.SetArgs(tc.args)
+			c.SetContext(context.Background())
+			err := c.Execute()
+			if tc.errString != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errString) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.errString)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s, logs: %s", err, errOut)
+			}
+			// logs are written to the error stream, the output only has the result
+			if got := out.String(); got != tc.want {
+				t.Fatalf("unexpected output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
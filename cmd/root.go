@@ -36,8 +36,10 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
@@ -47,6 +49,11 @@ import (
 	// Import prompt packages for side effect of registration
 	_ "github.com/googleapis/genai-toolbox/internal/prompts/custom"
 
+	// Import resource packages for side effect of registration
+	_ "github.com/googleapis/genai-toolbox/internal/mcpresources/firestore/firestorerules"
+	_ "github.com/googleapis/genai-toolbox/internal/mcpresources/looker/lookerexplore"
+	_ "github.com/googleapis/genai-toolbox/internal/mcpresources/postgres/postgrestableschema"
+
 	// Import tool packages for side effect of registration
 	_ "github.com/googleapis/genai-toolbox/internal/tools/alloydb/alloydbcreatecluster"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/alloydb/alloydbcreateinstance"
@@ -322,6 +329,7 @@ type Command struct {
 	tools_files     []string
 	tools_folder    string
 	prebuiltConfigs []string
+	secretProviders []string
 	inStream        io.Reader
 	outStream       io.Writer
 	errStream       io.Writer
@@ -364,30 +372,47 @@ func NewCommand(opts ...Option) *Command {
 	flags.StringVarP(&cmd.cfg.Address, "address", "a", "127.0.0.1", "Address of the interface the server will listen on.")
 	flags.IntVarP(&cmd.cfg.Port, "port", "p", 5000, "Port the server will listen on.")
 
-	flags.StringVar(&cmd.tools_file, "tools_file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
+	// flags shared with the subcommands
+	persistentFlags := cmd.PersistentFlags()
+	persistentFlags.StringVar(&cmd.tools_file, "tools_file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
 	// deprecate tools_file
-	_ = flags.MarkDeprecated("tools_file", "please use --tools-file instead")
-	flags.StringVar(&cmd.tools_file, "tools-file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
-	flags.StringSliceVar(&cmd.tools_files, "tools-files", []string{}, "Multiple file paths specifying tool configurations. Files will be merged. Cannot be used with --tools-file, or --tools-folder.")
-	flags.StringVar(&cmd.tools_folder, "tools-folder", "", "Directory path containing YAML tool configuration files. All .yaml and .yml files in the directory will be loaded and merged. Cannot be used with --tools-file, or --tools-files.")
-	flags.Var(&cmd.cfg.LogLevel, "log-level", "Specify the minimum level logged. Allowed: 'DEBUG', 'INFO', 'WARN', 'ERROR'.")
-	flags.Var(&cmd.cfg.LoggingFormat, "logging-format", "Specify logging format to use. Allowed: 'standard' or 'JSON'.")
+	_ = persistentFlags.MarkDeprecated("tools_file", "please use --tools-file instead")
+	persistentFlags.StringVar(&cmd.tools_file, "tools-file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
+	persistentFlags.StringSliceVar(&cmd.tools_files, "tools-files", []string{}, "Multiple file paths specifying tool configurations. Files will be merged. Cannot be used with --tools-file, or --tools-folder.")
+	persistentFlags.StringVar(&cmd.tools_folder, "tools-folder", "", "Directory path containing YAML tool configuration files. All .yaml and .yml files in the directory will be loaded and merged. Cannot be used with --tools-file, or --tools-files.")
+	persistentFlags.Var(&cmd.cfg.LogLevel, "log-level", "Specify the minimum level logged. Allowed: 'DEBUG', 'INFO', 'WARN', 'ERROR'.")
+	persistentFlags.Var(&cmd.cfg.LoggingFormat, "logging-format", "Specify logging format to use. Allowed: 'standard' or 'JSON'.")
 	flags.BoolVar(&cmd.cfg.TelemetryGCP, "telemetry-gcp", false, "Enable exporting directly to Google Cloud Monitoring.")
 	flags.StringVar(&cmd.cfg.TelemetryOTLP, "telemetry-otlp", "", "Enable exporting using OpenTelemetry Protocol (OTLP) to the specified endpoint (e.g. 'http://127.0.0.1:4318')")
 	flags.StringVar(&cmd.cfg.TelemetryServiceName, "telemetry-service-name", "toolbox", "Sets the value of the service.name resource attribute for telemetry data.")
+	flags.BoolVar(&cmd.cfg.TelemetryPrometheus, "telemetry-prometheus", false, "Enable exposing metrics in the Prometheus format on the /metrics endpoint.")
 	// Fetch prebuilt tools sources to customize the help description
 	prebuiltHelp := fmt.Sprintf(
 		"Use a prebuilt tool configuration by source type. Allowed: '%s'. Can be specified multiple times.",
 		strings.Join(prebuiltconfigs.GetPrebuiltSources(), "', '"),
 	)
-	flags.StringSliceVar(&cmd.prebuiltConfigs, "prebuilt", []string{}, prebuiltHelp)
+	persistentFlags.StringSliceVar(&cmd.prebuiltConfigs, "prebuilt", []string{}, prebuiltHelp)
 	flags.BoolVar(&cmd.cfg.Stdio, "stdio", false, "Listens via MCP STDIO instead of acting as a remote HTTP server.")
+	flags.StringVar(&cmd.cfg.StdioToolset, "toolset", "", "Name of the toolset served via MCP STDIO. Defaults to all tools. Can only be used with --stdio.")
+	flags.StringVar(&cmd.cfg.StdioPromptset, "promptset", "", "Name of the promptset served via MCP STDIO. Defaults to all prompts. Can only be used with --stdio.")
 	flags.BoolVar(&cmd.cfg.DisableReload, "disable-reload", false, "Disables dynamic reloading of tools file.")
 	flags.BoolVar(&cmd.cfg.UI, "ui", false, "Launches the Toolbox UI web server.")
 	// TODO: Insecure by default. Might consider updating this for v1.0.0
 	flags.StringSliceVar(&cmd.cfg.AllowedOrigins, "allowed-origins", []string{"*"}, "Specifies a list of origins permitted to access this server. Defaults to '*'.")
 	flags.StringSliceVar(&cmd.cfg.AllowedHosts, "allowed-hosts", []string{"*"}, "Specifies a list of hosts permitted to access this server. Defaults to '*'.")
 	flags.StringSliceVar(&cmd.cfg.UserAgentMetadata, "user-agent-metadata", []string{}, "Appends additional metadata to the User-Agent.")
+	flags.IntVar(&cmd.cfg.McpBatchConcurrency, "mcp-batch-concurrency", 1, "Maximum number of messages of a MCP JSON-RPC batch request that are processed concurrently.")
+	flags.StringSliceVar(&cmd.cfg.Audit.Sinks, "audit-log", []string{}, "Enables the audit log of tool invocations. Allowed: 'stdout', 'otel' or the path of a JSONL file. Can be specified multiple times.")
+	flags.IntVar(&cmd.cfg.Audit.MaxFileSizeMB, "audit-log-max-size", 100, "Size in megabytes at which the audit log file is rotated. 0 disables rotation.")
+	flags.IntVar(&cmd.cfg.Audit.MaxFileBackups, "audit-log-max-backups", 5, "Number of rotated audit log files that are kept.")
+	flags.StringSliceVar(&cmd.cfg.Audit.Claims, "audit-claims", []string{"sub", "email"}, "Claims of the verified auth services that are recorded in the audit log.")
+	flags.StringSliceVar(&cmd.cfg.Audit.RedactParams, "audit-redact", []string{}, "Names of parameters whose values are redacted in the audit log, in addition to parameters whose names suggest secrets such as passwords or tokens.")
+	persistentFlags.StringArrayVar(&cmd.secretProviders, "secret-provider", []string{}, "Registers a secret provider as 'scheme=command'. References ${scheme:ref} in tools files are resolved to the output of the command run with ref as its last argument. Can be specified multiple times.")
+	flags.StringSliceVar(&cmd.cfg.OptionalSources, "readiness-optional-sources", []string{}, "Names of the sources whose failing health checks do not make the server unready on /readyz.")
+	flags.DurationVar(&cmd.cfg.ReadinessTimeout, "readiness-timeout", 5*time.Second, "Timeout of the health check of each source on /readyz.")
+
+	cmd.AddCommand(newValidateCommand(cmd))
+	cmd.AddCommand(newInvokeCommand(cmd))
 
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd) }
@@ -402,29 +427,82 @@ type ToolsFile struct {
 	Tools           server.ToolConfigs           `yaml:"tools"`
 	Toolsets        server.ToolsetConfigs        `yaml:"toolsets"`
 	Prompts         server.PromptConfigs         `yaml:"prompts"`
+	Resources       server.ResourceConfigs       `yaml:"resources"`
 }
 
 // parseEnv replaces environment variables ${ENV_NAME} with their values.
-// also support ${ENV_NAME:default_value}.
-func parseEnv(input string) (string, error) {
+// also support ${ENV_NAME:default_value}. References to secrets such as
+// ${file:/run/secrets/password} are resolved with the secret provider of the
+// scheme instead.
+func parseEnv(ctx context.Context, input string) (string, error) {
 	re := regexp.MustCompile(`\$\{(\w+)(:([^}]*))?\}`)
 
 	var err error
-	output := re.ReplaceAllStringFunc(input, func(match string) string {
-		parts := re.FindStringSubmatch(match)
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		hasSecret := false
+		lines[i] = re.ReplaceAllStringFunc(line, func(match string) string {
+			parts := re.FindStringSubmatch(match)
+
+			// resolve references to secrets
+			if parts[2] != "" && secrets.IsScheme(parts[1]) {
+				hasSecret = true
+				value, resolveErr := secrets.Resolve(ctx, parts[1], parts[3])
+				if resolveErr != nil && err == nil {
+					err = resolveErr
+				}
+				return value
+			}
+
+			// extract the variable name
+			variableName := parts[1]
+			if value, found := os.LookupEnv(variableName); found {
+				return value
+			}
+			if len(parts) >= 4 && parts[2] != "" {
+				return parts[3]
+			}
+			err = fmt.Errorf("environment variable not found: %q", variableName)
+			return ""
+		})
+		if hasSecret {
+			// redact the whole value of fields such as `Bearer ${file:token}`
+			secrets.Record(scalarValue(lines[i]))
+		}
+	}
+	return strings.Join(lines, "\n"), err
+}
+
+// scalarValue returns the unquoted value of a YAML line such as `key: value`
+// or `- value`.
+func scalarValue(line string) string {
+	v := strings.TrimSpace(line)
+	v = strings.TrimPrefix(v, "- ")
+	if _, after, ok := strings.Cut(v, ": "); ok {
+		v = strings.TrimSpace(after)
+	}
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		v = v[1 : len(v)-1]
+	}
+	return v
+}
 
-		// extract the variable name
-		variableName := parts[1]
-		if value, found := os.LookupEnv(variableName); found {
-			return value
+// registerSecretProviders registers the secret providers given as
+// scheme=command, which run the command to resolve the references of the
+// scheme.
+func registerSecretProviders(specs []string) error {
+	for _, spec := range specs {
+		scheme, commandLine, ok := strings.Cut(spec, "=")
+		if !ok || scheme == "" {
+			return fmt.Errorf("invalid secret provider %q: expected the format scheme=command", spec)
 		}
-		if len(parts) >= 4 && parts[2] != "" {
-			return parts[3]
+		p, err := secrets.NewExecProvider(commandLine)
+		if err != nil {
+			return fmt.Errorf("invalid secret provider %q: %w", spec, err)
 		}
-		err = fmt.Errorf("environment variable not found: %q", variableName)
-		return ""
-	})
-	return output, err
+		secrets.Register(scheme, p)
+	}
+	return nil
 }
 
 func convertToolsFile(raw []byte) ([]byte, error) {
@@ -435,7 +513,7 @@ func convertToolsFile(raw []byte) ([]byte, error) {
 	var buf bytes.Buffer
 	encoder := yaml.NewEncoder(&buf)
 
-	v1keys := []string{"sources", "authSources", "authServices", "embeddingModels", "tools", "toolsets", "prompts"}
+	v1keys := []string{"sources", "authSources", "authServices", "embeddingModels", "tools", "toolsets", "prompts", "resources"}
 	for {
 		if err := decoder.Decode(&input); err != nil {
 			if err == io.EOF {
@@ -554,7 +632,7 @@ func ProcessValue(v any, isToolset bool) any {
 func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
 	var toolsFile ToolsFile
 	// Replace environment variables if found
-	output, err := parseEnv(string(raw))
+	output, err := parseEnv(ctx, string(raw))
 	if err != nil {
 		return toolsFile, fmt.Errorf("error parsing environment variables: %s", err)
 	}
@@ -566,7 +644,7 @@ func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
 	}
 
 	// Parse contents
-	toolsFile.Sources, toolsFile.AuthServices, toolsFile.EmbeddingModels, toolsFile.Tools, toolsFile.Toolsets, toolsFile.Prompts, err = server.UnmarshalResourceConfig(ctx, raw)
+	toolsFile.Sources, toolsFile.AuthServices, toolsFile.EmbeddingModels, toolsFile.Tools, toolsFile.Toolsets, toolsFile.Prompts, toolsFile.Resources, err = server.UnmarshalResourceConfig(ctx, raw)
 	if err != nil {
 		return toolsFile, err
 	}
@@ -584,6 +662,7 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 		Tools:           make(server.ToolConfigs),
 		Toolsets:        make(server.ToolsetConfigs),
 		Prompts:         make(server.PromptConfigs),
+		Resources:       make(server.ResourceConfigs),
 	}
 
 	var conflicts []string
@@ -642,11 +721,20 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 				merged.Prompts[name] = prompt
 			}
 		}
+
+		// Check for conflicts and merge resources
+		for name, resource := range file.Resources {
+			if _, exists := merged.Resources[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("resource '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				merged.Resources[name] = resource
+			}
+		}
 	}
 
 	// If conflicts were detected, return an error
 	if len(conflicts) > 0 {
-		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset and prompt has a unique name across all files", strings.Join(conflicts, "\n  - "))
+		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset, prompt and resource has a unique name across all files", strings.Join(conflicts, "\n  - "))
 	}
 
 	return merged, nil
@@ -720,14 +808,14 @@ func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, s *server.Ser
 		panic(err)
 	}
 
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := validateReloadEdits(ctx, toolsFile)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := validateReloadEdits(ctx, toolsFile)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to validate reloaded edits: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
 		return err
 	}
 
-	s.ResourceMgr.SetResources(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	s.SetResources(ctx, sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
 
 	return nil
 }
@@ -735,7 +823,7 @@ func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, s *server.Ser
 // validateReloadEdits checks that the reloaded tools file configs can initialized without failing
 func validateReloadEdits(
 	ctx context.Context, toolsFile ToolsFile,
-) (map[string]sources.Source, map[string]auth.AuthService, map[string]embeddingmodels.EmbeddingModel, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset, error,
+) (map[string]sources.Source, map[string]auth.AuthService, map[string]embeddingmodels.EmbeddingModel, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset, map[string]mcpresources.Resource, error,
 ) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -760,16 +848,17 @@ func validateReloadEdits(
 		ToolConfigs:           toolsFile.Tools,
 		ToolsetConfigs:        toolsFile.Toolsets,
 		PromptConfigs:         toolsFile.Prompts,
+		ResourceConfigs:       toolsFile.Resources,
 	}
 
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.InitializeConfigs(ctx, reloadedConfig)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := server.InitializeConfigs(ctx, reloadedConfig)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to initialize reloaded configs: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
-	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, nil
 }
 
 // watchChanges checks for changes in the provided yaml tools file(s) or folder.
@@ -915,46 +1004,18 @@ func resolveWatcherInputs(toolsFile string, toolsFiles []string, toolsFolder str
 	return watchDirs, watchedFiles
 }
 
-func run(cmd *Command) error {
-	ctx, cancel := context.WithCancel(cmd.Context())
-	defer cancel()
-
-	// watch for sigterm / sigint signals
-	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
-	go func(sCtx context.Context) {
-		var s os.Signal
-		select {
-		case <-sCtx.Done():
-			// this should only happen when the context supplied when testing is canceled
-			return
-		case s = <-signals:
-		}
-		switch s {
-		case syscall.SIGINT:
-			cmd.logger.DebugContext(sCtx, "Received SIGINT signal to shutdown.")
-		case syscall.SIGTERM:
-			cmd.logger.DebugContext(sCtx, "Sending SIGTERM signal to shutdown.")
-		}
-		cancel()
-	}(ctx)
-
-	// If stdio, set logger's out stream (usually DEBUG and INFO logs) to errStream
-	loggerOut := cmd.outStream
-	if cmd.cfg.Stdio {
-		loggerOut = cmd.errStream
-	}
-
+// setupLogger initializes the logger of the command from the logging flags.
+func (cmd *Command) setupLogger(out io.Writer) error {
 	// Handle logger separately from config
 	switch strings.ToLower(cmd.cfg.LoggingFormat.String()) {
 	case "json":
-		logger, err := log.NewStructuredLogger(loggerOut, cmd.errStream, cmd.cfg.LogLevel.String())
+		logger, err := log.NewStructuredLogger(out, cmd.errStream, cmd.cfg.LogLevel.String())
 		if err != nil {
 			return fmt.Errorf("unable to initialize logger: %w", err)
 		}
 		cmd.logger = logger
 	case "standard":
-		logger, err := log.NewStdLogger(loggerOut, cmd.errStream, cmd.cfg.LogLevel.String())
+		logger, err := log.NewStdLogger(out, cmd.errStream, cmd.cfg.LogLevel.String())
 		if err != nil {
 			return fmt.Errorf("unable to initialize logger: %w", err)
 		}
@@ -963,23 +1024,13 @@ func run(cmd *Command) error {
 		return fmt.Errorf("logging format invalid")
 	}
 
-	ctx = util.WithLogger(ctx, cmd.logger)
-
-	// Set up OpenTelemetry
-	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryServiceName)
-	if err != nil {
-		errMsg := fmt.Errorf("error setting up OpenTelemetry: %w", err)
-		cmd.logger.ErrorContext(ctx, errMsg.Error())
-		return errMsg
-	}
-	defer func() {
-		err := otelShutdown(ctx)
-		if err != nil {
-			errMsg := fmt.Errorf("error shutting down OpenTelemetry: %w", err)
-			cmd.logger.ErrorContext(ctx, errMsg.Error())
-		}
-	}()
+	return nil
+}
 
+// loadToolsFiles loads and merges the prebuilt configurations and the tools
+// files given by the flags, or tools.yaml if none is given. It also reports
+// whether custom tools files are loaded, which are watched for changes.
+func (cmd *Command) loadToolsFiles(ctx context.Context) (ToolsFile, bool, error) {
 	var allToolsFiles []ToolsFile
 
 	// Load Prebuilt Configuration
@@ -994,7 +1045,7 @@ func run(cmd *Command) error {
 			buf, err := prebuiltconfigs.Get(configName)
 			if err != nil {
 				cmd.logger.ErrorContext(ctx, err.Error())
-				return err
+				return ToolsFile{}, false, err
 			}
 
 			// Update version string
@@ -1005,7 +1056,7 @@ func run(cmd *Command) error {
 			if err != nil {
 				errMsg := fmt.Errorf("unable to parse prebuilt tool configuration for '%s': %w", configName, err)
 				cmd.logger.ErrorContext(ctx, errMsg.Error())
-				return errMsg
+				return ToolsFile{}, false, errMsg
 			}
 			allToolsFiles = append(allToolsFiles, parsed)
 		}
@@ -1031,7 +1082,7 @@ func run(cmd *Command) error {
 			(len(cmd.tools_files) > 0 && cmd.tools_folder != "") {
 			errMsg := fmt.Errorf("--tools-file, --tools-files, and --tools-folder flags cannot be used simultaneously")
 			cmd.logger.ErrorContext(ctx, errMsg.Error())
-			return errMsg
+			return ToolsFile{}, false, errMsg
 		}
 
 		var customTools ToolsFile
@@ -1051,7 +1102,7 @@ func run(cmd *Command) error {
 			if readFileErr != nil {
 				errMsg := fmt.Errorf("unable to read tool file at %q: %w", cmd.tools_file, readFileErr)
 				cmd.logger.ErrorContext(ctx, errMsg.Error())
-				return errMsg
+				return ToolsFile{}, false, errMsg
 			}
 			customTools, err = parseToolsFile(ctx, buf)
 			if err != nil {
@@ -1061,7 +1112,7 @@ func run(cmd *Command) error {
 
 		if err != nil {
 			cmd.logger.ErrorContext(ctx, err.Error())
-			return err
+			return ToolsFile{}, false, err
 		}
 		allToolsFiles = append(allToolsFiles, customTools)
 	}
@@ -1071,15 +1122,85 @@ func run(cmd *Command) error {
 	finalToolsFile, err := mergeToolsFiles(allToolsFiles...)
 	if err != nil {
 		cmd.logger.ErrorContext(ctx, err.Error())
+		return ToolsFile{}, false, err
+	}
+	return finalToolsFile, isCustomConfigured, nil
+}
+
+// setResourceConfigs sets the configs of the resources of the tools file on
+// the server config.
+func (cmd *Command) setResourceConfigs(toolsFile ToolsFile) {
+	cmd.cfg.SourceConfigs = toolsFile.Sources
+	cmd.cfg.AuthServiceConfigs = toolsFile.AuthServices
+	cmd.cfg.EmbeddingModelConfigs = toolsFile.EmbeddingModels
+	cmd.cfg.ToolConfigs = toolsFile.Tools
+	cmd.cfg.ToolsetConfigs = toolsFile.Toolsets
+	cmd.cfg.PromptConfigs = toolsFile.Prompts
+	cmd.cfg.ResourceConfigs = toolsFile.Resources
+}
+
+func run(cmd *Command) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	// watch for sigterm / sigint signals
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func(sCtx context.Context) {
+		var s os.Signal
+		select {
+		case <-sCtx.Done():
+			// this should only happen when the context supplied when testing is canceled
+			return
+		case s = <-signals:
+		}
+		switch s {
+		case syscall.SIGINT:
+			cmd.logger.DebugContext(sCtx, "Received SIGINT signal to shutdown.")
+		case syscall.SIGTERM:
+			cmd.logger.DebugContext(sCtx, "Sending SIGTERM signal to shutdown.")
+		}
+		cancel()
+	}(ctx)
+
+	// If stdio, set logger's out stream (usually DEBUG and INFO logs) to errStream
+	loggerOut := cmd.outStream
+	if cmd.cfg.Stdio {
+		loggerOut = cmd.errStream
+	}
+
+	if err := cmd.setupLogger(loggerOut); err != nil {
+		return err
+	}
+
+	ctx = util.WithLogger(ctx, cmd.logger)
+
+	if err := registerSecretProviders(cmd.secretProviders); err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	// Set up OpenTelemetry
+	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryServiceName, cmd.cfg.TelemetryPrometheus)
+	if err != nil {
+		errMsg := fmt.Errorf("error setting up OpenTelemetry: %w", err)
+		cmd.logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	defer func() {
+		err := otelShutdown(ctx)
+		if err != nil {
+			errMsg := fmt.Errorf("error shutting down OpenTelemetry: %w", err)
+			cmd.logger.ErrorContext(ctx, errMsg.Error())
+		}
+	}()
+
+	finalToolsFile, isCustomConfigured, err := cmd.loadToolsFiles(ctx)
+	if err != nil {
 		return err
 	}
 
-	cmd.cfg.SourceConfigs = finalToolsFile.Sources
-	cmd.cfg.AuthServiceConfigs = finalToolsFile.AuthServices
-	cmd.cfg.EmbeddingModelConfigs = finalToolsFile.EmbeddingModels
-	cmd.cfg.ToolConfigs = finalToolsFile.Tools
-	cmd.cfg.ToolsetConfigs = finalToolsFile.Toolsets
-	cmd.cfg.PromptConfigs = finalToolsFile.Prompts
+	cmd.setResourceConfigs(finalToolsFile)
 
 	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
 	if err != nil {
@@ -31,22 +31,30 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/gemini"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources/postgres/postgrestableschema"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	cloudsqlpgsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	postgressrc "github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/http"
 	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	"github.com/googleapis/genai-toolbox/internal/tools/utility/wait"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"github.com/spf13/cobra"
 )
 
@@ -73,6 +81,30 @@ func withDefaults(c server.ServerConfig) server.ServerConfig {
 	if c.UserAgentMetadata == nil {
 		c.UserAgentMetadata = []string{}
 	}
+	if c.McpBatchConcurrency == 0 {
+		c.McpBatchConcurrency = 1
+	}
+	if c.Audit.Sinks == nil {
+		c.Audit.Sinks = []string{}
+	}
+	if c.Audit.MaxFileSizeMB == 0 {
+		c.Audit.MaxFileSizeMB = 100
+	}
+	if c.Audit.MaxFileBackups == 0 {
+		c.Audit.MaxFileBackups = 5
+	}
+	if c.Audit.Claims == nil {
+		c.Audit.Claims = []string{"sub", "email"}
+	}
+	if c.Audit.RedactParams == nil {
+		c.Audit.RedactParams = []string{}
+	}
+	if c.OptionalSources == nil {
+		c.OptionalSources = []string{}
+	}
+	if c.ReadinessTimeout == 0 {
+		c.ReadinessTimeout = 5 * time.Second
+	}
 	return c
 }
 
@@ -205,6 +237,13 @@ func TestServerConfigFlags(t *testing.T) {
 				TelemetryServiceName: "toolbox-custom",
 			}),
 		},
+		{
+			desc: "telemetry prometheus",
+			args: []string{"--telemetry-prometheus"},
+			want: withDefaults(server.ServerConfig{
+				TelemetryPrometheus: true,
+			}),
+		},
 		{
 			desc: "stdio",
 			args: []string{"--stdio"},
@@ -212,6 +251,15 @@ func TestServerConfigFlags(t *testing.T) {
 				Stdio: true,
 			}),
 		},
+		{
+			desc: "stdio with toolset and promptset",
+			args: []string{"--stdio", "--toolset", "my-toolset", "--promptset", "my-promptset"},
+			want: withDefaults(server.ServerConfig{
+				Stdio:          true,
+				StdioToolset:   "my-toolset",
+				StdioPromptset: "my-promptset",
+			}),
+		},
 		{
 			desc: "disable reload",
 			args: []string{"--disable-reload"},
@@ -240,6 +288,34 @@ func TestServerConfigFlags(t *testing.T) {
 				UserAgentMetadata: []string{"foo", "bar"},
 			}),
 		},
+		{
+			desc: "mcp batch concurrency",
+			args: []string{"--mcp-batch-concurrency", "4"},
+			want: withDefaults(server.ServerConfig{
+				McpBatchConcurrency: 4,
+			}),
+		},
+		{
+			desc: "audit log",
+			args: []string{"--audit-log", "stdout,/var/log/toolbox/audit.jsonl", "--audit-log-max-size", "10", "--audit-log-max-backups", "2", "--audit-claims", "sub,hd", "--audit-redact", "ssn"},
+			want: withDefaults(server.ServerConfig{
+				Audit: audit.Config{
+					Sinks:          []string{"stdout", "/var/log/toolbox/audit.jsonl"},
+					MaxFileSizeMB:  10,
+					MaxFileBackups: 2,
+					Claims:         []string{"sub", "hd"},
+					RedactParams:   []string{"ssn"},
+				},
+			}),
+		},
+		{
+			desc: "readiness",
+			args: []string{"--readiness-optional-sources", "my-cache,my-search", "--readiness-timeout", "2s"},
+			want: withDefaults(server.ServerConfig{
+				OptionalSources:  []string{"my-cache", "my-search"},
+				ReadinessTimeout: 2 * time.Second,
+			}),
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -305,7 +381,7 @@ func TestParseEnv(t *testing.T) {
 					t.Setenv(k, v)
 				}
 			}
-			got, err := parseEnv(tc.in)
+			got, err := parseEnv(context.Background(), tc.in)
 			if tc.err {
 				if err == nil {
 					t.Fatalf("expected error not found")
@@ -321,6 +397,79 @@ func TestParseEnv(t *testing.T) {
 	}
 }
 
+func TestParseEnvSecrets(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(secretFile, []byte("my-password\n"), 0600); err != nil {
+		t.Fatalf("unable to write secret file: %s", err)
+	}
+	if err := registerSecretProviders([]string{"echo=echo resolved"}); err != nil {
+		t.Fatalf("unable to register secret provider: %s", err)
+	}
+
+	tcs := []struct {
+		desc      string
+		in        string
+		want      string
+		redacted  string
+		errString string
+	}{
+		{
+			desc: "file secret",
+			in:   "password: ${file:" + secretFile + "}",
+			want: "password: my-password",
+		},
+		{
+			desc:      "missing file secret",
+			in:        "password: ${file:" + secretFile + ".missing}",
+			errString: "unable to resolve secret",
+		},
+		{
+			desc: "exec secret",
+			in:   "token: ${echo:my-token}",
+			want: "token: resolved my-token",
+		},
+		{
+			desc:     "secret within a value",
+			in:       "headers:\n  Authorization: \"Bearer ${echo:my-header}\"",
+			want:     "headers:\n  Authorization: \"Bearer resolved my-header\"",
+			redacted: "Bearer resolved my-header",
+		},
+		{
+			desc: "unregistered scheme is an env var with default",
+			in:   "${unknown:bar}",
+			want: "bar",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseEnv(context.Background(), tc.in)
+			if tc.errString != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errString) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.errString)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.want != got {
+				t.Fatalf("unexpected want: got %s, want %s", got, tc.want)
+			}
+			if tc.redacted != "" && secrets.RedactString(tc.redacted) != secrets.Redacted {
+				t.Fatalf("value %q containing a secret is not redacted", tc.redacted)
+			}
+		})
+	}
+}
+
+func TestRegisterSecretProvidersInvalid(t *testing.T) {
+	for _, spec := range []string{"no-command", "=cmd", "scheme="} {
+		if err := registerSecretProviders([]string{spec}); err == nil {
+			t.Fatalf("expected error registering secret provider %q", spec)
+		}
+	}
+}
+
 func TestToolFileFlag(t *testing.T) {
 	tcs := []struct {
 		desc string
@@ -1164,6 +1313,232 @@ func TestParseToolFile(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "example with result limits and timeout",
+			in: `
+			kind: sources
+			name: my-pg-instance
+			type: postgres
+			host: localhost
+			port: "5432"
+			database: my_db
+			user: my_user
+			password: my_pass
+			maxRows: 1000
+---
+			kind: tools
+			name: example_tool
+			type: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: |
+				SELECT * FROM SQL_STATEMENT;
+			maxRows: 100
+			maxResultBytes: 65536
+			invocationTimeout: 30s
+			`,
+			wantToolsFile: ToolsFile{
+				Sources: server.SourceConfigs{
+					"my-pg-instance": postgressrc.Config{
+						Name:         "my-pg-instance",
+						Type:         postgressrc.SourceType,
+						Host:         "localhost",
+						Port:         "5432",
+						Database:     "my_db",
+						User:         "my_user",
+						Password:     "my_pass",
+						ResultLimits: sqlutil.ResultLimits{MaxRows: 1000},
+					},
+				},
+				Tools: server.ToolConfigs{
+					"example_tool": tools.OptionsToolConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Type:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+							AuthRequired: []string{},
+						},
+						ToolOptions: tools.ToolOptions{
+							ResultLimits:      sqlutil.ResultLimits{MaxRows: 100, MaxResultBytes: 65536},
+							InvocationTimeout: "30s",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "example with invocation timeout and tool timeout",
+			in: `
+			kind: tools
+			name: example_tool
+			type: wait
+			description: some description
+			timeout: 10s
+			invocationTimeout: 1m
+			`,
+			wantToolsFile: ToolsFile{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.OptionsToolConfig{
+						ToolConfig: wait.Config{
+							Name:         "example_tool",
+							Type:         "wait",
+							Description:  "some description",
+							Timeout:      "10s",
+							AuthRequired: []string{},
+						},
+						ToolOptions: tools.ToolOptions{InvocationTimeout: "1m"},
+					},
+				},
+			},
+		},
+		{
+			description: "example with output schema",
+			in: `
+			kind: tools
+			name: example_tool
+			type: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: |
+				SELECT id, name FROM users;
+			outputSchema:
+				- name: id
+					type: integer
+					description: the id
+					required: true
+				- name: name
+					type: string
+					description: the name
+---
+			kind: tools
+			name: auto_tool
+			type: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: |
+				SELECT id, name FROM users;
+			outputSchema: auto
+			`,
+			wantToolsFile: ToolsFile{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.OptionsToolConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Type:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT id, name FROM users;\n",
+							AuthRequired: []string{},
+						},
+						ToolOptions: tools.ToolOptions{
+							OutputSchema: &tools.OutputSchema{
+								Columns: parameters.Parameters{
+									parameters.NewIntParameterWithRequired("id", "the id", true),
+									parameters.NewStringParameter("name", "the name"),
+								},
+							},
+						},
+					},
+					"auto_tool": tools.OptionsToolConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "auto_tool",
+							Type:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT id, name FROM users;\n",
+							AuthRequired: []string{},
+						},
+						ToolOptions: tools.ToolOptions{
+							OutputSchema: &tools.OutputSchema{Auto: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "example with toolset resources",
+			in: `
+			kind: resources
+			name: pg_tables
+			type: postgres-table-schema
+			source: my-pg-instance
+			authRequired:
+				- my-google-service
+			policies:
+				- claim: email
+					endsWith: "@corp.com"
+---
+			kind: toolsets
+			name: example_toolset
+			tools:
+				- example_tool
+			resources:
+				- pg_tables
+			`,
+			wantToolsFile: ToolsFile{
+				Toolsets: server.ToolsetConfigs{
+					"example_toolset": tools.ToolsetConfig{
+						Name:          "example_toolset",
+						ToolNames:     []string{"example_tool"},
+						ResourceNames: []string{"pg_tables"},
+					},
+				},
+				Resources: server.ResourceConfigs{
+					"pg_tables": postgrestableschema.Config{
+						Name:   "pg_tables",
+						Type:   "postgres-table-schema",
+						Source: "my-pg-instance",
+						Access: mcpresources.Access{
+							AuthRequired: []string{"my-google-service"},
+							Policies:     []tools.Policy{{Claim: "email", EndsWith: "@corp.com"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "example with lazy init and retry",
+			in: `
+			kind: sources
+			name: my-pg-instance
+			type: postgres
+			host: localhost
+			port: "5432"
+			database: my_db
+			user: my_user
+			password: my_pass
+			lazyInit: true
+			retry:
+				maxAttempts: 5
+				initialBackoff: 2s
+				maxBackoff: 1m
+			`,
+			wantToolsFile: ToolsFile{
+				Sources: server.SourceConfigs{
+					"my-pg-instance": sources.OptionsSourceConfig{
+						SourceConfig: postgressrc.Config{
+							Name:     "my-pg-instance",
+							Type:     postgressrc.SourceType,
+							Host:     "localhost",
+							Port:     "5432",
+							Database: "my_db",
+							User:     "my_user",
+							Password: "my_pass",
+						},
+						SourceOptions: sources.SourceOptions{
+							LazyInit: true,
+							Retry: sources.RetryOptions{
+								MaxAttempts:    5,
+								InitialBackoff: "2s",
+								MaxBackoff:     "1m",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -1186,6 +1561,9 @@ func TestParseToolFile(t *testing.T) {
 			if diff := cmp.Diff(tc.wantToolsFile.Prompts, toolsFile.Prompts); diff != "" {
 				t.Fatalf("incorrect prompts parse: diff %v", diff)
 			}
+			if diff := cmp.Diff(tc.wantToolsFile.Resources, toolsFile.Resources); diff != "" {
+				t.Fatalf("incorrect resources parse: diff %v", diff)
+			}
 		})
 	}
 
@@ -1511,6 +1889,97 @@ func TestParseToolFileWithAuth(t *testing.T) {
 				Prompts: nil,
 			},
 		},
+		{
+			description: "example with policies",
+			in: `
+			kind: sources
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			user: my_user
+			password: my_pass
+---
+			kind: authServices
+			name: my-google-service
+			type: google
+			clientId: my-client-id
+---
+			kind: tools
+			name: example_tool
+			type: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: |
+				SELECT * FROM SQL_STATEMENT;
+			authRequired:
+				- my-google-service
+			policies:
+				- claim: email
+					endsWith: "@corp.com"
+				- authService: my-google-service
+					claim: hd
+					equals: example.com
+---
+			kind: toolsets
+			name: example_toolset
+			tools:
+				- example_tool
+			policies:
+				- claim: groups
+					contains: dba
+			`,
+			wantToolsFile: ToolsFile{
+				Sources: server.SourceConfigs{
+					"my-pg-instance": cloudsqlpgsrc.Config{
+						Name:     "my-pg-instance",
+						Type:     cloudsqlpgsrc.SourceType,
+						Project:  "my-project",
+						Region:   "my-region",
+						Instance: "my-instance",
+						IPType:   "public",
+						Database: "my_db",
+						User:     "my_user",
+						Password: "my_pass",
+					},
+				},
+				AuthServices: server.AuthServiceConfigs{
+					"my-google-service": google.Config{
+						Name:     "my-google-service",
+						Type:     google.AuthServiceType,
+						ClientID: "my-client-id",
+					},
+				},
+				Tools: server.ToolConfigs{
+					"example_tool": tools.OptionsToolConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Type:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+							AuthRequired: []string{"my-google-service"},
+						},
+						ToolOptions: tools.ToolOptions{
+							Policies: []tools.Policy{
+								{Claim: "email", EndsWith: "@corp.com"},
+								{AuthService: "my-google-service", Claim: "hd", Equals: "example.com"},
+							},
+						},
+					},
+				},
+				Toolsets: server.ToolsetConfigs{
+					"example_toolset": tools.ToolsetConfig{
+						Name:      "example_toolset",
+						ToolNames: []string{"example_tool"},
+						Policies:  []tools.Policy{{Claim: "groups", Contains: "dba"}},
+					},
+				},
+				Prompts: nil,
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -2169,7 +2638,7 @@ func TestPrebuiltTools(t *testing.T) {
 	t.Setenv("LOOKER_PROJECT", "your_project_id")
 	t.Setenv("LOOKER_LOCATION", "us")
 
-	t.Setenv("SQLITE_DATABASE", "test.db")
+	t.Setenv("SQLITE_DATABASE", filepath.Join(t.TempDir(), "test.db"))
 
 	t.Setenv("NEO4J_URI", "bolt://localhost:7687")
 	t.Setenv("NEO4J_DATABASE", "neo4j")
@@ -2610,6 +3079,7 @@ func TestMergeToolsFiles(t *testing.T) {
 				Tools:           server.ToolConfigs{"tool1": http.Config{Name: "tool1"}, "tool2": http.Config{Name: "tool2"}},
 				Toolsets:        server.ToolsetConfigs{"set1": tools.ToolsetConfig{Name: "set1"}, "set2": tools.ToolsetConfig{Name: "set2"}},
 				Prompts:         server.PromptConfigs{},
+				Resources:       server.ResourceConfigs{},
 				EmbeddingModels: server.EmbeddingModelConfigs{"model1": gemini.Config{Name: "gemini-text"}},
 			},
 			wantErr: false,
@@ -2629,6 +3099,7 @@ func TestMergeToolsFiles(t *testing.T) {
 				Tools:           file1.Tools,
 				Toolsets:        file1.Toolsets,
 				Prompts:         server.PromptConfigs{},
+				Resources:       server.ResourceConfigs{},
 			},
 		},
 		{
@@ -2641,6 +3112,7 @@ func TestMergeToolsFiles(t *testing.T) {
 				Tools:           make(server.ToolConfigs),
 				Toolsets:        make(server.ToolsetConfigs),
 				Prompts:         server.PromptConfigs{},
+				Resources:       server.ResourceConfigs{},
 			},
 		},
 	}
@@ -2667,7 +3139,7 @@ func TestMergeToolsFiles(t *testing.T) {
 	}
 }
 func TestPrebuiltAndCustomTools(t *testing.T) {
-	t.Setenv("SQLITE_DATABASE", "test.db")
+	t.Setenv("SQLITE_DATABASE", filepath.Join(t.TempDir(), "test.db"))
 	// Setup custom tools file
 	customContent := `
 kind: tools
@@ -2858,7 +3330,7 @@ authSources:
 }
 
 func TestDefaultToolsFileBehavior(t *testing.T) {
-	t.Setenv("SQLITE_DATABASE", "test.db")
+	t.Setenv("SQLITE_DATABASE", filepath.Join(t.TempDir(), "test.db"))
 	testCases := []struct {
 		desc      string
 		args      []string
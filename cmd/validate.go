@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// newValidateCommand returns the command validating the tools files.
+func newValidateCommand(cmd *Command) *cobra.Command {
+	var skipConnect bool
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the tools files without starting the server",
+		Long: `Validates the tools files without starting the server.
+
+The tools files are parsed and merged, and the references between resources,
+such as the source of a tool and whether it is of a compatible type, or the
+tools of a toolset, are checked. Unless
+--skip-connect is set, the sources are connected to and all the resources are
+initialized as they are by the server.`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return runValidate(c.Context(), cmd, skipConnect)
+		},
+	}
+	validateCmd.Flags().BoolVar(&skipConnect, "skip-connect", false, "Only checks the tools files, the references between resources and the source types of tools, without connecting to the sources.")
+	return validateCmd
+}
+
+func runValidate(ctx context.Context, cmd *Command, skipConnect bool) error {
+	ctx, err := cmd.setupSubcommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	toolsFile, _, err := cmd.loadToolsFiles(ctx)
+	if err != nil {
+		return err
+	}
+	cmd.setResourceConfigs(toolsFile)
+
+	if err := server.ValidateReferences(cmd.cfg); err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	// the summary is computed before the initialization adds the default toolset
+	summary := fmt.Sprintf("Tools files are valid: %d sources, %d authServices, %d embeddingModels, %d tools, %d toolsets, %d prompts",
+		len(toolsFile.Sources), len(toolsFile.AuthServices), len(toolsFile.EmbeddingModels), len(toolsFile.Tools), len(toolsFile.Toolsets), len(toolsFile.Prompts))
+
+	if !skipConnect {
+		sourcesMap, _, _, _, _, _, _, _, err := server.InitializeConfigs(ctx, cmd.cfg)
+		if err != nil {
+			errMsg := fmt.Errorf("toolbox failed to initialize: %w", err)
+			cmd.logger.ErrorContext(ctx, errMsg.Error())
+			return errMsg
+		}
+		// sources with lazyInit do not fail the initialization
+		var unavailable []string
+		for name, s := range sourcesMap {
+			if us, ok := s.(*sources.UnavailableSource); ok {
+				unavailable = append(unavailable, fmt.Sprintf("%s: %s", name, us.Err()))
+			}
+		}
+		if len(unavailable) > 0 {
+			sort.Strings(unavailable)
+			errMsg := fmt.Errorf("unable to initialize sources:\n  - %s", strings.Join(unavailable, "\n  - "))
+			cmd.logger.ErrorContext(ctx, errMsg.Error())
+			return errMsg
+		}
+	}
+
+	fmt.Fprintln(cmd.outStream, summary)
+	return nil
+}
+
+// setupSubcommand sets up the logger, the secret providers and the telemetry
+// instrumentation of a subcommand. Logs are written to the error stream, so
+// that the output stream only contains the result of the subcommand.
+func (cmd *Command) setupSubcommand(ctx context.Context) (context.Context, error) {
+	if err := cmd.setupLogger(cmd.errStream); err != nil {
+		return ctx, err
+	}
+	ctx = util.WithLogger(ctx, cmd.logger)
+
+	if err := registerSecretProviders(cmd.secretProviders); err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return ctx, err
+	}
+
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		errMsg := fmt.Errorf("unable to create telemetry instrumentation: %w", err)
+		cmd.logger.ErrorContext(ctx, errMsg.Error())
+		return ctx, errMsg
+	}
+	return util.WithInstrumentation(ctx, instrumentation), nil
+}
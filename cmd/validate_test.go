@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSQLiteToolsFile writes a tools file with a SQLite source, a tool
+// adding two integers and a toolset with the given tools.
+func writeSQLiteToolsFile(t *testing.T, database string, toolsetTools ...string) string {
+	t.Helper()
+	content := fmt.Sprintf(`kind: sources
+name: my-sqlite
+type: sqlite
+database: %s
+---
+kind: tools
+name: add
+type: sqlite-sql
+source: my-sqlite
+description: Adds two integers.
+statement: SELECT ? + ? AS sum
+parameters:
+  - name: a
+    type: integer
+    description: first integer
+  - name: b
+    type: integer
+    description: second integer
+---
+kind: toolsets
+name: my-toolset
+tools:
+  - %s
+`, database, strings.Join(toolsetTools, "\n  - "))
+	return writeToolsFile(t, content)
+}
+
+// writeToolsFile writes a tools file with the given content.
+func writeToolsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("unable to write tools file: %s", err)
+	}
+	return path
+}
+
+func TestValidateCommand(t *testing.T) {
+	database := filepath.Join(t.TempDir(), "test.db")
+	tcs := []struct {
+		desc      string
+		args      []string
+		want      string
+		errString string
+	}{
+		{
+			desc: "valid tools file",
+			args: []string{"validate", "--tools-file", writeSQLiteToolsFile(t, database, "add")},
+			want: "Tools files are valid: 1 sources, 0 authServices, 0 embeddingModels, 1 tools, 1 toolsets, 0 prompts",
+		},
+		{
+			desc: "valid tools file without connecting",
+			args: []string{"validate", "--skip-connect", "--tools-file", writeSQLiteToolsFile(t, database, "add")},
+			want: "Tools files are valid",
+		},
+		{
+			desc:      "missing tool",
+			args:      []string{"validate", "--skip-connect", "--tools-file", writeSQLiteToolsFile(t, database, "add", "missing")},
+			errString: `toolset "my-toolset" references tool "missing", which does not exist`,
+		},
+		{
+			desc: "incompatible source without connecting",
+			args: []string{"validate", "--skip-connect", "--tools-file", writeToolsFile(t, `kind: sources
+name: my-mysql
+type: mysql
+host: 127.0.0.1
+port: 3306
+database: my_db
+user: my_user
+password: my_pass
+---
+kind: tools
+name: list-flights
+type: postgres-sql
+source: my-mysql
+description: Lists flights.
+statement: SELECT * FROM flights
+`)},
+			errString: `tool "list-flights" of type "postgres-sql" references source "my-mysql" of type "mysql", which is not compatible`,
+		},
+		{
+			desc:      "unreachable source",
+			args:      []string{"validate", "--tools-file", writeSQLiteToolsFile(t, filepath.Join(t.TempDir(), "missing", "test.db"), "add")},
+			errString: `unable to initialize source "my-sqlite"`,
+		},
+		{
+			desc:      "invalid tools file",
+			args:      []string{"validate", "--tools-file", filepath.Join(t.TempDir(), "missing.yaml")},
+			errString: "unable to read tool file",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, output, err := invokeCommandWithContext(context.Background(), tc.args)
+			if tc.errString != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errString) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.errString)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !strings.Contains(output, tc.want) {
+				t.Fatalf("unexpected output: got %q, want %q", output, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records an audit event for every tool invocation.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+)
+
+// Transports through which a tool can be invoked.
+const (
+	TransportREST  = "rest"
+	TransportMCP   = "mcp"
+	TransportStdio = "stdio"
+)
+
+// Redacted replaces the value of sensitive parameters.
+const Redacted = "[REDACTED]"
+
+// sensitiveParams are the substrings of parameter names whose values are
+// always redacted.
+var sensitiveParams = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "private_key"}
+
+// Config configures the audit log.
+type Config struct {
+	// Sinks are the destinations of the audit events: "stdout", "otel" or the
+	// path of a JSONL file.
+	Sinks []string
+	// MaxFileSizeMB is the size at which the audit file is rotated.
+	MaxFileSizeMB int
+	// MaxFileBackups is the number of rotated audit files that are kept.
+	MaxFileBackups int
+	// Claims are the claims of the verified auth services that are recorded.
+	Claims []string
+	// RedactParams are the names of additional parameters whose values are
+	// redacted.
+	RedactParams []string
+}
+
+// Event is the audit record of a single tool invocation.
+type Event struct {
+	Timestamp    time.Time                 `json:"timestamp"`
+	Transport    string                    `json:"transport"`
+	Toolset      string                    `json:"toolset"`
+	Tool         string                    `json:"tool"`
+	AuthServices []string                  `json:"authServices"`
+	Claims       map[string]map[string]any `json:"claims,omitempty"`
+	Parameters   map[string]any            `json:"parameters,omitempty"`
+	DurationMs   float64                   `json:"durationMs"`
+	RowCount     *int                      `json:"rowCount,omitempty"`
+	Status       string                    `json:"status"`
+	Error        string                    `json:"error,omitempty"`
+}
+
+// Sink is a destination of audit events.
+type Sink interface {
+	Write(ctx context.Context, e Event) error
+	Close() error
+}
+
+// Logger writes audit events to its sinks.
+type Logger struct {
+	cfg    Config
+	sinks  []Sink
+	logger log.Logger
+}
+
+// New returns a Logger writing to the sinks of the config, or nil if no sink
+// is configured. Errors of the sinks are reported to logger.
+func New(ctx context.Context, cfg Config, logger log.Logger) (*Logger, error) {
+	if len(cfg.Sinks) == 0 {
+		return nil, nil
+	}
+	l := &Logger{cfg: cfg, logger: logger}
+	for _, name := range cfg.Sinks {
+		var sink Sink
+		var err error
+		switch name {
+		case "stdout":
+			sink = NewWriterSink(stdout)
+		case "otel":
+			sink = NewOTelSink()
+		case "":
+			err = fmt.Errorf("audit sink must not be empty")
+		default:
+			sink, err = NewFileSink(name, cfg.MaxFileSizeMB, cfg.MaxFileBackups)
+		}
+		if err != nil {
+			return nil, errors.Join(err, l.Close())
+		}
+		l.sinks = append(l.sinks, sink)
+	}
+	return l, nil
+}
+
+// Log writes the event to every sink. Events are never dropped because of a
+// failing sink; the failure is reported instead.
+func (l *Logger) Log(ctx context.Context, e Event) {
+	if l == nil {
+		return
+	}
+	for _, sink := range l.sinks {
+		if err := sink.Write(ctx, e); err != nil && l.logger != nil {
+			l.logger.ErrorContext(ctx, fmt.Sprintf("unable to write audit event: %s", err))
+		}
+	}
+}
+
+// Close closes every sink.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	var err error
+	for _, sink := range l.sinks {
+		err = errors.Join(err, sink.Close())
+	}
+	return err
+}
+
+// redact returns the parameters with the values of sensitive parameters
+// replaced, including the fields of object parameters.
+func (l *Logger) redact(params parameters.ParamValues) map[string]any {
+	out := make(map[string]any, len(params))
+	for _, p := range params {
+		if l.isSensitive(p.Name) {
+			out[p.Name] = Redacted
+			continue
+		}
+		out[p.Name] = l.redactValue(p.Value)
+	}
+	return out
+}
+
+// redactValue returns a copy of an object or array value with the values of
+// sensitive fields replaced, and other values as is.
+func (l *Logger) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, fv := range v {
+			if l.isSensitive(k) {
+				out[k] = Redacted
+				continue
+			}
+			out[k] = l.redactValue(fv)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, ev := range v {
+			out[i] = l.redactValue(ev)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (l *Logger) isSensitive(name string) bool {
+	lower := strings.ToLower(name)
+	for _, s := range sensitiveParams {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return slices.ContainsFunc(l.cfg.RedactParams, func(p string) bool { return strings.EqualFold(p, name) })
+}
+
+// selectClaims returns the configured claims of each verified auth service.
+func (l *Logger) selectClaims(claimsFromAuth map[string]map[string]any) map[string]map[string]any {
+	out := make(map[string]map[string]any)
+	for name, claims := range claimsFromAuth {
+		selected := make(map[string]any)
+		for _, c := range l.cfg.Claims {
+			if v, ok := claims[c]; ok {
+				selected[c] = v
+			}
+		}
+		if len(selected) > 0 {
+			out[name] = selected
+		}
+	}
+	return out
+}
+
+type contextKey string
+
+const (
+	loggerKey    contextKey = "auditLogger"
+	transportKey contextKey = "auditTransport"
+)
+
+// WithLogger adds the audit logger and the transport of the request into the
+// context.
+func WithLogger(ctx context.Context, l *Logger, transport string) context.Context {
+	ctx = context.WithValue(ctx, loggerKey, l)
+	return context.WithValue(ctx, transportKey, transport)
+}
+
+// Invocation collects the audit event of a tool invocation. A nil Invocation
+// is valid and records nothing.
+type Invocation struct {
+	logger    *Logger
+	start     time.Time
+	event     Event
+	invokeErr error
+}
+
+// Start starts recording the invocation of a tool. It returns nil if the
+// context has no audit logger.
+func Start(ctx context.Context, toolset, tool string) *Invocation {
+	l, ok := ctx.Value(loggerKey).(*Logger)
+	if !ok || l == nil {
+		return nil
+	}
+	transport, _ := ctx.Value(transportKey).(string)
+	now := time.Now()
+	return &Invocation{
+		logger: l,
+		start:  now,
+		event: Event{
+			Timestamp:    now.UTC(),
+			Transport:    transport,
+			Toolset:      toolset,
+			Tool:         tool,
+			AuthServices: []string{},
+		},
+	}
+}
+
+// SetClaims records the verified auth services and their selected claims.
+func (i *Invocation) SetClaims(claimsFromAuth map[string]map[string]any) {
+	if i == nil {
+		return
+	}
+	names := make([]string, 0, len(claimsFromAuth))
+	for name := range claimsFromAuth {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	i.event.AuthServices = names
+	i.event.Claims = i.logger.selectClaims(claimsFromAuth)
+}
+
+// SetParams records the parameters of the invocation, with the values of
+// sensitive parameters redacted.
+func (i *Invocation) SetParams(params parameters.ParamValues) {
+	if i == nil {
+		return
+	}
+	i.event.Parameters = i.logger.redact(params)
+}
+
+// SetResult records the number of rows returned by the tool, or the error of
+// the tool if it failed.
+func (i *Invocation) SetResult(res any, err error) {
+	if i == nil {
+		return
+	}
+	if err != nil {
+		i.invokeErr = err
+		return
+	}
+	if rows, ok := sqlutil.ResultRows(res); ok {
+		n := len(rows)
+		i.event.RowCount = &n
+	}
+}
+
+// Finish writes the audit event. err is the error the invocation failed with
+// before the tool was invoked, if any.
+func (i *Invocation) Finish(ctx context.Context, err error) {
+	if i == nil {
+		return
+	}
+	if i.invokeErr != nil {
+		err = i.invokeErr
+	}
+	i.event.DurationMs = float64(time.Since(i.start).Microseconds()) / 1000
+	i.event.Status = "success"
+	if err != nil {
+		i.event.Status = "error"
+		i.event.Error = err.Error()
+	}
+	i.logger.Log(ctx, i.event)
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+)
+
+func TestInvocation(t *testing.T) {
+	var buf bytes.Buffer
+	stdout = &buf
+	defer func() { stdout = os.Stdout }()
+
+	l, err := New(context.Background(), Config{Sinks: []string{"stdout"}, Claims: []string{"sub", "email"}, RedactParams: []string{"SSN"}}, nil)
+	if err != nil {
+		t.Fatalf("unable to create audit logger: %s", err)
+	}
+	defer l.Close()
+	ctx := WithLogger(context.Background(), l, TransportStdio)
+
+	claims := map[string]map[string]any{
+		"my-oidc":        {"sub": "123", "email": "alice@corp.com", "groups": []any{"dba"}},
+		"my-google-auth": {"hd": "corp.com"},
+	}
+	params := parameters.ParamValues{
+		{Name: "id", Value: 42},
+		{Name: "db_password", Value: "hunter2"},
+		{Name: "apiKey", Value: "abc"},
+		{Name: "ssn", Value: "123-45-6789"},
+		{Name: "connection", Value: map[string]any{"host": "localhost", "password": "hunter2", "options": map[string]any{"SSN": "123-45-6789"}}},
+		{Name: "users", Value: []any{map[string]any{"name": "alice", "token": "abc"}}},
+	}
+
+	i := Start(ctx, "my-toolset", "my-tool")
+	i.SetClaims(claims)
+	i.SetParams(params)
+	i.SetResult(sqlutil.TruncatedResult{Rows: []any{1, 2}, RowCount: 2, Truncated: true}, nil)
+	i.Finish(ctx, nil)
+	if params[4].Value.(map[string]any)["password"] != "hunter2" {
+		t.Fatalf("parameters of the invocation were modified: %v", params[4].Value)
+	}
+
+	i = Start(ctx, "my-toolset", "my-tool")
+	i.SetResult(nil, errors.New("connection refused"))
+	i.Finish(ctx, nil)
+
+	var got []Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("unable to unmarshal audit event %q: %s", line, err)
+		}
+		got = append(got, e)
+	}
+	rowCount := 2
+	want := []Event{
+		{
+			Transport:    TransportStdio,
+			Toolset:      "my-toolset",
+			Tool:         "my-tool",
+			AuthServices: []string{"my-google-auth", "my-oidc"},
+			Claims:       map[string]map[string]any{"my-oidc": {"sub": "123", "email": "alice@corp.com"}},
+			Parameters: map[string]any{
+				"id":          float64(42),
+				"db_password": Redacted,
+				"apiKey":      Redacted,
+				"ssn":         Redacted,
+				"connection":  map[string]any{"host": "localhost", "password": Redacted, "options": map[string]any{"SSN": Redacted}},
+				"users":       []any{map[string]any{"name": "alice", "token": Redacted}},
+			},
+			RowCount: &rowCount,
+			Status:   "success",
+		},
+		{
+			Transport:    TransportStdio,
+			Toolset:      "my-toolset",
+			Tool:         "my-tool",
+			AuthServices: []string{},
+			Status:       "error",
+			Error:        "connection refused",
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Event{}, "Timestamp", "DurationMs")); diff != "" {
+		t.Fatalf("unexpected audit events (-want +got):\n%s", diff)
+	}
+}
+
+func TestStartWithoutLogger(t *testing.T) {
+	i := Start(context.Background(), "", "my-tool")
+	if i != nil {
+		t.Fatalf("expect no invocation without an audit logger")
+	}
+	// a nil invocation records nothing
+	i.SetParams(parameters.ParamValues{{Name: "id", Value: 1}})
+	i.Finish(context.Background(), nil)
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	s, err := NewFileSink(path, 1, 2)
+	if err != nil {
+		t.Fatalf("unable to create file sink: %s", err)
+	}
+	defer s.Close()
+
+	// each event is a little over half a megabyte, so every second event
+	// rotates the file
+	e := Event{Tool: "my-tool", Error: strings.Repeat("x", 600*1024)}
+	for range 4 {
+		if err := s.Write(context.Background(), e); err != nil {
+			t.Fatalf("unable to write event: %s", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("unable to read %q: %s", name, err)
+		}
+		if n := strings.Count(string(data), "\n"); n != 1 {
+			t.Fatalf("expect %q to hold 1 event, got %d", name, n)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expect only 2 backups to be kept")
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(context.Background(), Config{Sinks: []string{""}}, nil); err == nil {
+		t.Fatalf("expect empty sink to be rejected")
+	}
+	path := filepath.Join(t.TempDir(), "missing", "audit.jsonl")
+	if _, err := New(context.Background(), Config{Sinks: []string{path}}, nil); err == nil {
+		t.Fatalf("expect unwritable audit log to be rejected")
+	}
+	l, err := New(context.Background(), Config{}, nil)
+	if err != nil || l != nil {
+		t.Fatalf("expect no audit logger without sinks, got %v, %v", l, err)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// stdout is the destination of the "stdout" sink.
+var stdout io.Writer = os.Stdout
+
+// instrumentationName is the name of the OpenTelemetry logger.
+const instrumentationName = "github.com/googleapis/genai-toolbox/internal/audit"
+
+// WriterSink writes events as JSON lines to a writer.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink returns a sink writing to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Write(_ context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to marshal audit event: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+func (s *WriterSink) Close() error {
+	return nil
+}
+
+// FileSink writes events as JSON lines to a file. The file is rotated once it
+// reaches its maximum size: path is renamed to path.1, path.1 to path.2 and so
+// on, up to the number of backups kept.
+type FileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+// NewFileSink returns a sink appending to the file at path. A maxSizeMB of 0
+// disables rotation.
+func NewFileSink(path string, maxSizeMB, maxBackups int) (*FileSink, error) {
+	if maxSizeMB < 0 || maxBackups < 0 {
+		return nil, fmt.Errorf("audit log size and backups must not be negative")
+	}
+	s := &FileSink{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log %q: %w", s.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("unable to stat audit log %q: %w", s.path, err)
+	}
+	s.f = f
+	s.size = info.Size()
+	return nil
+}
+
+// rotate closes the current file, shifts the backups and opens a new file.
+func (s *FileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return fmt.Errorf("unable to close audit log %q: %w", s.path, err)
+	}
+	if s.maxBackups == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove audit log %q: %w", s.path, err)
+		}
+		return s.open()
+	}
+	for i := s.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to rotate audit log %q: %w", s.path, err)
+		}
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil {
+		return fmt.Errorf("unable to rotate audit log %q: %w", s.path, err)
+	}
+	return s.open()
+}
+
+func (s *FileSink) Write(_ context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to marshal audit event: %w", err)
+	}
+	b = append(b, '\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return fmt.Errorf("audit log %q is closed", s.path)
+	}
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(b)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.f.Write(b)
+	s.size += int64(n)
+	return err
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// OTelSink emits events as OpenTelemetry log records through the global
+// logger provider, which exports them to the configured OTLP endpoint.
+type OTelSink struct {
+	logger otellog.Logger
+}
+
+// NewOTelSink returns a sink emitting OpenTelemetry log records.
+func NewOTelSink() *OTelSink {
+	return &OTelSink{logger: global.Logger(instrumentationName)}
+}
+
+func (s *OTelSink) Write(ctx context.Context, e Event) error {
+	var r otellog.Record
+	r.SetEventName("toolbox.tool.invocation")
+	r.SetTimestamp(e.Timestamp)
+	r.SetSeverity(otellog.SeverityInfo)
+	if e.Status != "success" {
+		r.SetSeverity(otellog.SeverityWarn)
+	}
+	r.SetBody(otellog.StringValue(fmt.Sprintf("tool %q invoked", e.Tool)))
+
+	authServices := make([]otellog.Value, 0, len(e.AuthServices))
+	for _, a := range e.AuthServices {
+		authServices = append(authServices, otellog.StringValue(a))
+	}
+	r.AddAttributes(
+		otellog.String("transport", e.Transport),
+		otellog.String("toolset", e.Toolset),
+		otellog.String("tool", e.Tool),
+		otellog.Slice("authServices", authServices...),
+		otellog.Float64("durationMs", e.DurationMs),
+		otellog.String("status", e.Status),
+	)
+	// claims and parameters have arbitrary values, so they are recorded as
+	// their JSON encoding
+	if len(e.Claims) > 0 {
+		b, err := json.Marshal(e.Claims)
+		if err != nil {
+			return fmt.Errorf("unable to marshal audit claims: %w", err)
+		}
+		r.AddAttributes(otellog.String("claims", string(b)))
+	}
+	if len(e.Parameters) > 0 {
+		b, err := json.Marshal(e.Parameters)
+		if err != nil {
+			return fmt.Errorf("unable to marshal audit parameters: %w", err)
+		}
+		r.AddAttributes(otellog.String("parameters", string(b)))
+	}
+	if e.RowCount != nil {
+		r.AddAttributes(otellog.Int("rowCount", *e.RowCount))
+	}
+	if e.Error != "" {
+		r.AddAttributes(otellog.String("error", e.Error))
+	}
+	s.logger.Emit(ctx, r)
+	return nil
+}
+
+func (s *OTelSink) Close() error {
+	return nil
+}
@@ -16,9 +16,41 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+
+	"github.com/goccy/go-yaml"
 )
 
+// AuthServiceConfigFactory defines the function signature for creating an AuthServiceConfig.
+type AuthServiceConfigFactory func(ctx context.Context, name string, decoder *yaml.Decoder) (AuthServiceConfig, error)
+
+var authServiceRegistry = make(map[string]AuthServiceConfigFactory)
+
+// Register registers a new auth service type with its factory.
+// It returns false if the type is already registered.
+func Register(authServiceType string, factory AuthServiceConfigFactory) bool {
+	if _, exists := authServiceRegistry[authServiceType]; exists {
+		// Auth service with this type already exists, do not overwrite.
+		return false
+	}
+	authServiceRegistry[authServiceType] = factory
+	return true
+}
+
+// DecodeConfig decodes an auth service configuration using the registered factory for the given type.
+func DecodeConfig(ctx context.Context, authServiceType string, name string, decoder *yaml.Decoder) (AuthServiceConfig, error) {
+	factory, found := authServiceRegistry[authServiceType]
+	if !found {
+		return nil, fmt.Errorf("%s is not a valid type of auth service", authServiceType)
+	}
+	authServiceConfig, err := factory(ctx, name, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse as %s: %w", name, err)
+	}
+	return authServiceConfig, nil
+}
+
 // AuthServiceConfig is the interface for configuring authentication services.
 type AuthServiceConfig interface {
 	AuthServiceConfigType() string
@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"google.golang.org/api/idtoken"
 )
 
@@ -28,6 +30,20 @@ const AuthServiceType string = "google"
 // validate interface
 var _ auth.AuthServiceConfig = Config{}
 
+func init() {
+	if !auth.Register(AuthServiceType, newConfig) {
+		panic(fmt.Sprintf("auth service type %q already registered", AuthServiceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (auth.AuthServiceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // Auth service configuration
 type Config struct {
 	Name     string `yaml:"name" validate:"required"`
@@ -61,7 +77,7 @@ func (a AuthService) AuthServiceType() string {
 }
 
 func (a AuthService) ToConfig() auth.AuthServiceConfig {
-	return a.Config
+	return secrets.Redact(a.Config)
 }
 
 // Returns the name of the auth service
@@ -0,0 +1,395 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"golang.org/x/sync/singleflight"
+)
+
+const AuthServiceType string = "oidc"
+
+// defaults used when the optional fields are not set
+const (
+	defaultClockSkew     = time.Minute
+	defaultCacheDuration = time.Hour
+	// minRefreshInterval limits how often the keys are fetched again when a
+	// token is signed with an unknown key
+	minRefreshInterval = time.Minute
+)
+
+var defaultAlgorithms = []string{string(jose.RS256)}
+
+// supportedAlgorithms are the signature algorithms that can be configured
+var supportedAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+func init() {
+	if !auth.Register(AuthServiceType, newConfig) {
+		panic(fmt.Sprintf("auth service type %q already registered", AuthServiceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (auth.AuthServiceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Auth service configuration
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Type string `yaml:"type" validate:"required"`
+	// Issuer is the expected `iss` claim. Unless JwksURI or JwksFile is set,
+	// the keys are discovered from the OpenID configuration of the issuer.
+	Issuer string `yaml:"issuer"`
+	// JwksURI is the URL of the JSON Web Key Set used to verify tokens.
+	JwksURI string `yaml:"jwksUri"`
+	// JwksFile is the path of a local JSON Web Key Set used to verify tokens.
+	JwksFile string `yaml:"jwksFile"`
+	// Audiences are the accepted values of the `aud` claim.
+	Audiences []string `yaml:"audiences" validate:"required,min=1"`
+	// Algorithms are the accepted signature algorithms. Defaults to RS256.
+	Algorithms []string `yaml:"algorithms"`
+	// ClockSkew is the leeway allowed when validating time based claims.
+	// Defaults to 1m.
+	ClockSkew string `yaml:"clockSkew"`
+	// CacheDuration is how long keys fetched from a remote JWKS are cached.
+	// Defaults to 1h.
+	CacheDuration string `yaml:"cacheDuration"`
+}
+
+// Returns the auth service type
+func (cfg Config) AuthServiceConfigType() string {
+	return AuthServiceType
+}
+
+// Initialize an OIDC auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	if cfg.Issuer == "" && cfg.JwksURI == "" && cfg.JwksFile == "" {
+		return nil, fmt.Errorf("one of issuer, jwksUri or jwksFile is required for auth service %q", cfg.Name)
+	}
+	if cfg.JwksURI != "" && cfg.JwksFile != "" {
+		return nil, fmt.Errorf("jwksUri and jwksFile cannot both be set for auth service %q", cfg.Name)
+	}
+
+	algorithmNames := cfg.Algorithms
+	if len(algorithmNames) == 0 {
+		algorithmNames = defaultAlgorithms
+	}
+	var algorithms []jose.SignatureAlgorithm
+	for _, name := range algorithmNames {
+		alg := jose.SignatureAlgorithm(name)
+		if !slices.Contains(supportedAlgorithms, alg) {
+			return nil, fmt.Errorf("unsupported algorithm %q for auth service %q", name, cfg.Name)
+		}
+		algorithms = append(algorithms, alg)
+	}
+
+	clockSkew, err := parseDuration(cfg.ClockSkew, defaultClockSkew)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for clockSkew: %w", err)
+	}
+	cacheDuration, err := parseDuration(cfg.CacheDuration, defaultCacheDuration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for cacheDuration: %w", err)
+	}
+
+	keys := &keySet{
+		client:        &http.Client{Timeout: 10 * time.Second},
+		issuer:        cfg.Issuer,
+		jwksURI:       cfg.JwksURI,
+		cacheDuration: cacheDuration,
+	}
+	if cfg.JwksFile != "" {
+		jwks, err := readJwksFile(cfg.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+		keys.static = true
+		keys.keys = jwks
+	}
+
+	a := &AuthService{
+		Config:     cfg,
+		algorithms: algorithms,
+		clockSkew:  clockSkew,
+		keys:       keys,
+	}
+	return a, nil
+}
+
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func readJwksFile(path string) (*jose.JSONWebKeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read JWKS file %q: %w", path, err)
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("unable to parse JWKS file %q: %w", path, err)
+	}
+	return &jwks, nil
+}
+
+var _ auth.AuthService = &AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Config
+	algorithms []jose.SignatureAlgorithm
+	clockSkew  time.Duration
+	keys       *keySet
+}
+
+// Returns the auth service type
+func (a *AuthService) AuthServiceType() string {
+	return AuthServiceType
+}
+
+func (a *AuthService) ToConfig() auth.AuthServiceConfig {
+	return secrets.Redact(a.Config)
+}
+
+// Returns the name of the auth service
+func (a *AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the JWT and return claims
+func (a *AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	if token := h.Get(a.Name + "_token"); token != "" {
+		claims, err := a.verify(ctx, token, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("OIDC token verification failure: %w", err) //nolint:staticcheck
+		}
+		return claims, nil
+	}
+	return nil, nil
+}
+
+// verify checks the signature and the registered claims of a token, and
+// returns all of its claims.
+func (a *AuthService) verify(ctx context.Context, token string, now time.Time) (map[string]any, error) {
+	tok, err := jwt.ParseSigned(token, a.algorithms)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse token: %w", err)
+	}
+	keys, err := a.keys.get(ctx, tok.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var registered jwt.Claims
+	var claims map[string]any
+	verified := false
+	for _, key := range keys {
+		if err := tok.Claims(key.Key, &registered, &claims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	if registered.Expiry == nil {
+		return nil, fmt.Errorf("token is missing the exp claim")
+	}
+	expected := jwt.Expected{
+		Issuer:      a.Issuer,
+		AnyAudience: a.Audiences,
+		Time:        now,
+	}
+	if err := registered.ValidateWithLeeway(expected, a.clockSkew); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// keySet caches the keys used to verify tokens.
+type keySet struct {
+	client        *http.Client
+	issuer        string
+	jwksURI       string
+	cacheDuration time.Duration
+	// static is true if the keys are read from a local file
+	static bool
+	// refreshes shares a single fetch between concurrent refreshes
+	refreshes singleflight.Group
+
+	mu        sync.Mutex
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+	// attemptedAt is the time of the last fetch, even if it failed
+	attemptedAt time.Time
+}
+
+// get returns the signing keys matching the key id, or all signing keys if
+// the key id is empty. Remote keys are fetched again when the cache expires,
+// or when no key matches to support key rotation. The last keys fetched keep
+// being used if fetching them again fails.
+func (k *keySet) get(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	if k.static {
+		return matchingKeys(k.keys, kid)
+	}
+	jwks, fetchedAt, attemptedAt := k.cached()
+	expired := time.Since(fetchedAt) > k.cacheDuration
+	// do not retry a failed fetch on every token
+	failedRecently := attemptedAt.After(fetchedAt) && time.Since(attemptedAt) < minRefreshInterval
+	if jwks == nil || (expired && !failedRecently) {
+		if err := k.refresh(ctx); err != nil && jwks == nil {
+			return nil, err
+		}
+		jwks, _, attemptedAt = k.cached()
+	}
+	if len(signingKeys(jwks, kid)) == 0 && time.Since(attemptedAt) > minRefreshInterval {
+		if err := k.refresh(ctx); err != nil {
+			return nil, err
+		}
+		jwks, _, _ = k.cached()
+	}
+	return matchingKeys(jwks, kid)
+}
+
+// cached returns the cached keys, with the times of the last successful and
+// attempted fetches.
+func (k *keySet) cached() (*jose.JSONWebKeySet, time.Time, time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.keys, k.fetchedAt, k.attemptedAt
+}
+
+// refresh fetches the keys again. The lock is not held during the fetch, so
+// that tokens are verified with the cached keys meanwhile, and concurrent
+// refreshes wait for the same fetch.
+func (k *keySet) refresh(ctx context.Context) error {
+	ch := k.refreshes.DoChan("", func() (any, error) {
+		// the fetch is shared, so it is not cancelled with the first caller
+		jwks, err := k.fetch(context.WithoutCancel(ctx))
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		k.attemptedAt = time.Now()
+		if err != nil {
+			return nil, err
+		}
+		k.keys = jwks
+		k.fetchedAt = k.attemptedAt
+		return nil, nil
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// matchingKeys returns the signing keys matching the key id, or an error if
+// there is none.
+func matchingKeys(jwks *jose.JSONWebKeySet, kid string) ([]jose.JSONWebKey, error) {
+	keys := signingKeys(jwks, kid)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no key found for key id %q", kid)
+	}
+	return keys, nil
+}
+
+func signingKeys(jwks *jose.JSONWebKeySet, kid string) []jose.JSONWebKey {
+	if jwks == nil {
+		return nil
+	}
+	candidates := jwks.Keys
+	if kid != "" {
+		candidates = jwks.Key(kid)
+	}
+	var keys []jose.JSONWebKey
+	for _, key := range candidates {
+		if key.Use == "" || key.Use == "sig" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// fetch retrieves the keys from the JWKS URI, which is discovered from the
+// OpenID configuration of the issuer if not configured.
+func (k *keySet) fetch(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	if k.jwksURI == "" {
+		var discovery struct {
+			JwksURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(k.issuer, "/") + "/.well-known/openid-configuration"
+		if err := k.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return nil, fmt.Errorf("unable to retrieve OpenID configuration: %w", err)
+		}
+		if discovery.JwksURI == "" {
+			return nil, fmt.Errorf("OpenID configuration of %q is missing jwks_uri", k.issuer)
+		}
+		// only written by the single fetch in progress
+		k.jwksURI = discovery.JwksURI
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err := k.getJSON(ctx, k.jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("unable to retrieve JWKS: %w", err)
+	}
+	return &jwks, nil
+}
+
+func (k *keySet) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %q from %s", resp.Status, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
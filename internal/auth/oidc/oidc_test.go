@@ -0,0 +1,353 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "toolbox"
+)
+
+type testKey struct {
+	kid string
+	alg jose.SignatureAlgorithm
+	key any
+	pub any
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	return testKey{kid: kid, alg: jose.RS256, key: key, pub: &key.PublicKey}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	return testKey{kid: kid, alg: jose.ES256, key: key, pub: &key.PublicKey}
+}
+
+func jwks(keys ...testKey) jose.JSONWebKeySet {
+	var set jose.JSONWebKeySet
+	for _, k := range keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: k.pub, KeyID: k.kid, Algorithm: string(k.alg), Use: "sig"})
+	}
+	return set
+}
+
+func writeJwksFile(t *testing.T, keys ...testKey) string {
+	t.Helper()
+	data, err := json.Marshal(jwks(keys...))
+	if err != nil {
+		t.Fatalf("unable to marshal JWKS: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("unable to write JWKS file: %s", err)
+	}
+	return path
+}
+
+func signToken(t *testing.T, k testKey, claims map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: k.alg, Key: k.key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", k.kid))
+	if err != nil {
+		t.Fatalf("unable to create signer: %s", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatalf("unable to sign token: %s", err)
+	}
+	return token
+}
+
+func validClaims(now time.Time) map[string]any {
+	return map[string]any{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   "user-1",
+		"email": "user@example.com",
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+	}
+}
+
+func TestInitializeErrors(t *testing.T) {
+	jwksFile := writeJwksFile(t, newRSAKey(t, "key-1"))
+	tcs := []struct {
+		desc string
+		cfg  Config
+		err  string
+	}{
+		{
+			desc: "missing key source",
+			cfg:  Config{Name: "my-oidc", Audiences: []string{testAudience}},
+			err:  `one of issuer, jwksUri or jwksFile is required for auth service "my-oidc"`,
+		},
+		{
+			desc: "jwksUri and jwksFile",
+			cfg:  Config{Name: "my-oidc", JwksURI: "https://example.com/jwks", JwksFile: jwksFile, Audiences: []string{testAudience}},
+			err:  `jwksUri and jwksFile cannot both be set for auth service "my-oidc"`,
+		},
+		{
+			desc: "unsupported algorithm",
+			cfg:  Config{Name: "my-oidc", JwksFile: jwksFile, Audiences: []string{testAudience}, Algorithms: []string{"HS256"}},
+			err:  `unsupported algorithm "HS256" for auth service "my-oidc"`,
+		},
+		{
+			desc: "invalid clock skew",
+			cfg:  Config{Name: "my-oidc", JwksFile: jwksFile, Audiences: []string{testAudience}, ClockSkew: "soon"},
+			err:  `invalid value for clockSkew: time: invalid duration "soon"`,
+		},
+		{
+			desc: "missing jwks file",
+			cfg:  Config{Name: "my-oidc", JwksFile: "does-not-exist.json", Audiences: []string{testAudience}},
+			err:  `unable to read JWKS file "does-not-exist.json"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+			if !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want prefix %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestVerifyWithJwksFile(t *testing.T) {
+	now := time.Now()
+	rsaKey := newRSAKey(t, "rsa-key")
+	ecKey := newECKey(t, "ec-key")
+	otherKey := newRSAKey(t, "rsa-key")
+
+	cfg := Config{
+		Name:       "my-oidc",
+		Type:       AuthServiceType,
+		Issuer:     testIssuer,
+		JwksFile:   writeJwksFile(t, rsaKey, ecKey),
+		Audiences:  []string{"other", testAudience},
+		Algorithms: []string{"RS256"},
+		ClockSkew:  "30s",
+	}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+
+	withClaims := func(changes map[string]any) map[string]any {
+		claims := validClaims(now)
+		for k, v := range changes {
+			if v == nil {
+				delete(claims, k)
+				continue
+			}
+			claims[k] = v
+		}
+		return claims
+	}
+
+	tcs := []struct {
+		desc  string
+		token string
+		err   string
+	}{
+		{
+			desc:  "valid token",
+			token: signToken(t, rsaKey, validClaims(now)),
+		},
+		{
+			desc:  "expired within clock skew",
+			token: signToken(t, rsaKey, withClaims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()})),
+		},
+		{
+			desc:  "expired",
+			token: signToken(t, rsaKey, withClaims(map[string]any{"exp": now.Add(-time.Minute).Unix()})),
+			err:   "go-jose/go-jose/jwt: validation failed, token is expired (exp)",
+		},
+		{
+			desc:  "missing expiry",
+			token: signToken(t, rsaKey, withClaims(map[string]any{"exp": nil})),
+			err:   "token is missing the exp claim",
+		},
+		{
+			desc:  "wrong audience",
+			token: signToken(t, rsaKey, withClaims(map[string]any{"aud": "someone-else"})),
+			err:   "go-jose/go-jose/jwt: validation failed, invalid audience claim (aud)",
+		},
+		{
+			desc:  "wrong issuer",
+			token: signToken(t, rsaKey, withClaims(map[string]any{"iss": "https://evil.example.com"})),
+			err:   "go-jose/go-jose/jwt: validation failed, invalid issuer claim (iss)",
+		},
+		{
+			desc:  "algorithm not allowed",
+			token: signToken(t, ecKey, validClaims(now)),
+			err:   "unable to parse token",
+		},
+		{
+			desc:  "signed by unknown key",
+			token: signToken(t, otherKey, validClaims(now)),
+			err:   "invalid token signature",
+		},
+		{
+			desc:  "malformed token",
+			token: "not-a-token",
+			err:   "unable to parse token",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("my-oidc_token", tc.token)
+			claims, err := a.GetClaimsFromHeader(context.Background(), h)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("expect verification to fail")
+				}
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if claims["email"] != "user@example.com" || claims["sub"] != "user-1" {
+				t.Fatalf("unexpected claims: %v", claims)
+			}
+		})
+	}
+
+	t.Run("missing header", func(t *testing.T) {
+		claims, err := a.GetClaimsFromHeader(context.Background(), http.Header{})
+		if err != nil || claims != nil {
+			t.Fatalf("expect no claims and no error, got %v, %v", claims, err)
+		}
+	})
+}
+
+func TestVerifyWithIssuerDiscovery(t *testing.T) {
+	now := time.Now()
+	oldKey := newRSAKey(t, "old-key")
+	newKey := newRSAKey(t, "new-key")
+
+	current := jwks(oldKey)
+	fetches := 0
+	failing := false
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]any{"issuer": ts.URL, "jwks_uri": ts.URL + "/keys"})
+		case "/keys":
+			fetches++
+			if failing {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(current)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	cfg := Config{
+		Name:      "my-oidc",
+		Type:      AuthServiceType,
+		Issuer:    ts.URL,
+		Audiences: []string{testAudience},
+	}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+	service := a.(*AuthService)
+
+	claims := validClaims(now)
+	claims["iss"] = ts.URL
+
+	// keys are fetched once and cached
+	for range 2 {
+		if _, err := service.verify(context.Background(), signToken(t, oldKey, claims), now); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("unexpected number of JWKS fetches: got %d, want 1", fetches)
+	}
+
+	// rotated keys are fetched again once the refresh interval has passed
+	current = jwks(newKey)
+	service.keys.fetchedAt = time.Now().Add(-2 * minRefreshInterval)
+	service.keys.attemptedAt = service.keys.fetchedAt
+	if _, err := service.verify(context.Background(), signToken(t, newKey, claims), now); err != nil {
+		t.Fatalf("unexpected error after key rotation: %s", err)
+	}
+	if fetches != 2 {
+		t.Fatalf("unexpected number of JWKS fetches: got %d, want 2", fetches)
+	}
+
+	// unknown keys do not trigger a fetch within the refresh interval
+	_, err = service.verify(context.Background(), signToken(t, newRSAKey(t, "unknown-key"), claims), now)
+	if err == nil || !strings.Contains(err.Error(), `no key found for key id "unknown-key"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 2 {
+		t.Fatalf("unexpected number of JWKS fetches: got %d, want 2", fetches)
+	}
+
+	// the last keys keep being used when fetching expired keys fails
+	failing = true
+	service.keys.fetchedAt = time.Now().Add(-2 * defaultCacheDuration)
+	service.keys.attemptedAt = service.keys.fetchedAt
+	for range 2 {
+		if _, err := service.verify(context.Background(), signToken(t, newKey, claims), now); err != nil {
+			t.Fatalf("unexpected error when the JWKS is unavailable: %s", err)
+		}
+	}
+	// the failed fetch is not retried within the refresh interval
+	if fetches != 3 {
+		t.Fatalf("unexpected number of JWKS fetches: got %d, want 3", fetches)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var _ EmbeddingModel = &batchingModel{}
+
+// batchTimeout bounds the request of a batch to the wrapped model, which is
+// not cancelled with the requests of the batch.
+const batchTimeout = time.Minute
+
+// batchingModel merges the texts of concurrent requests received within a
+// window into a single request to the wrapped model.
+type batchingModel struct {
+	EmbeddingModel
+	window  time.Duration
+	maxSize int
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending *pendingBatch
+}
+
+// pendingBatch is a batch of texts waiting to be sent to the model. The
+// embeddings and error are set before done is closed.
+type pendingBatch struct {
+	// ctx is the context of the first request of the batch, without its
+	// cancellation, as the batch outlives it if the request is cancelled.
+	ctx        context.Context
+	texts      []string
+	timer      *time.Timer
+	done       chan struct{}
+	embeddings [][]float32
+	err        error
+}
+
+func newBatchingModel(m EmbeddingModel, window time.Duration, maxSize int) *batchingModel {
+	return &batchingModel{EmbeddingModel: m, window: window, maxSize: maxSize, timeout: batchTimeout}
+}
+
+// EmbedParameters adds the texts to the pending batch, and waits for the
+// embeddings of the batch.
+func (m *batchingModel) EmbedParameters(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	m.mu.Lock()
+	if m.pending != nil && len(m.pending.texts)+len(texts) > m.maxSize {
+		// the texts do not fit in the pending batch, which is sent now
+		m.flushLocked()
+	}
+	if m.pending == nil {
+		b := &pendingBatch{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		b.timer = time.AfterFunc(m.window, func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if m.pending == b {
+				m.flushLocked()
+			}
+		})
+		m.pending = b
+	}
+	b := m.pending
+	offset := len(b.texts)
+	b.texts = append(b.texts, texts...)
+	if len(b.texts) >= m.maxSize {
+		m.flushLocked()
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.embeddings[offset : offset+len(texts)], nil
+}
+
+// flushLocked sends the pending batch to the wrapped model in the background.
+// m.mu must be held.
+func (m *batchingModel) flushLocked() {
+	b := m.pending
+	m.pending = nil
+	b.timer.Stop()
+	go func() {
+		defer close(b.done)
+		ctx, cancel := context.WithTimeoutCause(b.ctx, m.timeout, fmt.Errorf("embedding batch exceeded its timeout of %s", m.timeout))
+		defer cancel()
+		b.embeddings, b.err = m.EmbeddingModel.EmbedParameters(ctx, b.texts)
+		if b.err != nil && context.Cause(ctx) != nil {
+			b.err = fmt.Errorf("%w: %w", context.Cause(ctx), b.err)
+		}
+		if b.err == nil && len(b.embeddings) != len(b.texts) {
+			b.err = fmt.Errorf("model returned %d embeddings for %d inputs", len(b.embeddings), len(b.texts))
+		}
+	}()
+}
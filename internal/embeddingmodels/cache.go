@@ -0,0 +1,244 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// cacheKeyFields are the fields of the model config that change the embedding
+// of a text, and are part of the cache key. Secrets, such as in headers, are
+// redacted in the config and never part of the key.
+var cacheKeyFields = []string{"model", "dimension", "taskPrefix", "baseUrl", "url", "headers", "body", "inputField", "embeddingsField"}
+
+var _ EmbeddingModel = &cachingModel{}
+
+// cachingModel caches the embeddings of the wrapped model in a LRU cache, and
+// optionally in a directory.
+type cachingModel struct {
+	EmbeddingModel
+	size      int
+	ttl       time.Duration
+	dir       string
+	keyPrefix string
+	// now returns the current time, and is replaced in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// cacheEntry is an embedding cached in memory.
+type cacheEntry struct {
+	key       string
+	embedding []float32
+	expires   time.Time
+}
+
+func newCachingModel(m EmbeddingModel, size int, ttl time.Duration, dir string) (*cachingModel, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("unable to create embedding cache directory: %w", err)
+		}
+	}
+	return &cachingModel{
+		EmbeddingModel: m,
+		size:           size,
+		ttl:            ttl,
+		dir:            dir,
+		keyPrefix:      cacheKeyPrefix(m),
+		now:            time.Now,
+		lru:            list.New(),
+		entries:        make(map[string]*list.Element),
+	}, nil
+}
+
+// cacheKeyPrefix identifies the model in the cache keys, from its type and the
+// cacheKeyFields of its config.
+func cacheKeyPrefix(m EmbeddingModel) string {
+	parts := []string{m.EmbeddingModelType()}
+	v := reflect.Indirect(reflect.ValueOf(m.ToConfig()))
+	if v.Kind() == reflect.Struct {
+		for _, field := range cacheKeyFields {
+			for i := 0; i < v.NumField(); i++ {
+				tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+				if tag == field {
+					parts = append(parts, fmt.Sprintf("%s=%v", field, v.Field(i).Interface()))
+				}
+			}
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// key returns the cache key of a text.
+func (m *cachingModel) key(text string) string {
+	h := sha256.Sum256([]byte(m.keyPrefix + "\x00" + text))
+	return hex.EncodeToString(h[:])
+}
+
+// EmbedParameters returns the cached embeddings of the texts, and embeds the
+// other texts with the wrapped model.
+func (m *cachingModel) EmbedParameters(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	keys := make([]string, len(texts))
+	// missing maps the texts that are not cached to their indexes
+	missing := make(map[string][]int)
+	var missingTexts []string
+	for i, text := range texts {
+		keys[i] = m.key(text)
+		if e, ok := m.get(keys[i]); ok {
+			embeddings[i] = e
+			continue
+		}
+		if _, ok := missing[text]; !ok {
+			missingTexts = append(missingTexts, text)
+		}
+		missing[text] = append(missing[text], i)
+	}
+	if len(missingTexts) == 0 {
+		return embeddings, nil
+	}
+
+	embedded, err := m.EmbeddingModel.EmbedParameters(ctx, missingTexts)
+	if err != nil {
+		return nil, err
+	}
+	if len(embedded) != len(missingTexts) {
+		return nil, fmt.Errorf("model returned %d embeddings for %d inputs", len(embedded), len(missingTexts))
+	}
+	for i, text := range missingTexts {
+		indexes := missing[text]
+		for _, idx := range indexes {
+			embeddings[idx] = embedded[i]
+		}
+		m.put(ctx, keys[indexes[0]], embedded[i])
+	}
+	return embeddings, nil
+}
+
+// get returns the embedding of the key from memory, or from the directory.
+func (m *cachingModel) get(key string) ([]float32, bool) {
+	now := m.now()
+	m.mu.Lock()
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*cacheEntry)
+		if e.expires.IsZero() || now.Before(e.expires) {
+			m.lru.MoveToFront(el)
+			m.mu.Unlock()
+			return e.embedding, true
+		}
+		m.lru.Remove(el)
+		delete(m.entries, key)
+	}
+	m.mu.Unlock()
+
+	if m.dir == "" {
+		return nil, false
+	}
+	path := filepath.Join(m.dir, key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	// the modification time of the file is the time it was cached
+	var expires time.Time
+	if m.ttl > 0 {
+		expires = info.ModTime().Add(m.ttl)
+		if !now.Before(expires) {
+			_ = os.Remove(path)
+			return nil, false
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil || len(b)%4 != 0 {
+		return nil, false
+	}
+	embedding := make([]float32, len(b)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[i*4:]))
+	}
+	m.putMemory(key, embedding, expires)
+	return embedding, true
+}
+
+// put caches the embedding of the key in memory and in the directory.
+func (m *cachingModel) put(ctx context.Context, key string, embedding []float32) {
+	var expires time.Time
+	if m.ttl > 0 {
+		expires = m.now().Add(m.ttl)
+	}
+	m.putMemory(key, embedding, expires)
+	if m.dir == "" {
+		return
+	}
+	if err := writeFileAtomic(filepath.Join(m.dir, key), FormatVectorForSQLiteVec(embedding).([]byte)); err != nil {
+		if logger, lErr := util.LoggerFromContext(ctx); lErr == nil {
+			logger.WarnContext(ctx, fmt.Sprintf("unable to store embedding in cache directory: %s", err))
+		}
+	}
+}
+
+// putMemory caches the embedding in memory, evicting the least recently used
+// embedding if the cache is full.
+func (m *cachingModel) putMemory(key string, embedding []float32, expires time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		el.Value = &cacheEntry{key: key, embedding: embedding, expires: expires}
+		m.lru.MoveToFront(el)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(&cacheEntry{key: key, embedding: embedding, expires: expires})
+	for m.lru.Len() > m.size {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// writeFileAtomic writes the file through a temporary file, so concurrent
+// readers never see a partial file.
+func writeFileAtomic(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
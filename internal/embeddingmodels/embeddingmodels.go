@@ -16,10 +16,44 @@ package embeddingmodels
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
+
+	"github.com/goccy/go-yaml"
 )
 
+// EmbeddingModelConfigFactory defines the function signature for creating an EmbeddingModelConfig.
+type EmbeddingModelConfigFactory func(ctx context.Context, name string, decoder *yaml.Decoder) (EmbeddingModelConfig, error)
+
+var embeddingModelRegistry = make(map[string]EmbeddingModelConfigFactory)
+
+// Register registers a new embedding model type with its factory.
+// It returns false if the type is already registered.
+func Register(embeddingModelType string, factory EmbeddingModelConfigFactory) bool {
+	if _, exists := embeddingModelRegistry[embeddingModelType]; exists {
+		// Embedding model with this type already exists, do not overwrite.
+		return false
+	}
+	embeddingModelRegistry[embeddingModelType] = factory
+	return true
+}
+
+// DecodeConfig decodes an embedding model configuration using the registered factory for the given type.
+func DecodeConfig(ctx context.Context, embeddingModelType string, name string, decoder *yaml.Decoder) (EmbeddingModelConfig, error) {
+	factory, found := embeddingModelRegistry[embeddingModelType]
+	if !found {
+		return nil, fmt.Errorf("%s is not a valid type of embedding model", embeddingModelType)
+	}
+	embeddingModelConfig, err := factory(ctx, name, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+	}
+	return embeddingModelConfig, nil
+}
+
 // EmbeddingModelConfig is the interface for configuring embedding models.
 type EmbeddingModelConfig interface {
 	EmbeddingModelConfigType() string
@@ -56,4 +90,55 @@ func FormatVectorForPgvector(vectorFloats []float32) any {
 	return b.String()
 }
 
-var _ VectorFormatter = FormatVectorForPgvector
+// FormatVectorAsJSONArray converts a slice of floats into a JSON array string: '[x,y,z]'.
+// It is the text representation of vectors in MySQL (with STRING_TO_VECTOR),
+// TiDB, OceanBase, SingleStore, Oracle (with TO_VECTOR), SQL Server and Snowflake.
+func FormatVectorAsJSONArray(vectorFloats []float32) any {
+	b := make([]byte, 0, len(vectorFloats)*10+2)
+	b = append(b, '[')
+	for i, f := range vectorFloats {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendFloat(b, float64(f), 'g', -1, 32)
+	}
+	b = append(b, ']')
+	return string(b)
+}
+
+// FormatVectorForSQLiteVec converts a slice of floats into the compact blob
+// format of sqlite-vec: the little-endian bytes of each float32.
+func FormatVectorForSQLiteVec(vectorFloats []float32) any {
+	b := make([]byte, 0, len(vectorFloats)*4)
+	for _, f := range vectorFloats {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(f))
+	}
+	return b
+}
+
+// FormatVectorAsFloat32Array returns the floats as is, for drivers binding
+// []float32 natively, such as Spanner ARRAY<FLOAT32> and ClickHouse
+// Array(Float32), and for JSON queries such as Elasticsearch kNN and MongoDB
+// $vectorSearch.
+func FormatVectorAsFloat32Array(vectorFloats []float32) any {
+	return vectorFloats
+}
+
+// FormatVectorAsFloat64Array converts a slice of floats into a slice of
+// float64, for drivers binding []float64 natively such as BigQuery
+// ARRAY<FLOAT64>.
+func FormatVectorAsFloat64Array(vectorFloats []float32) any {
+	rtn := make([]float64, len(vectorFloats))
+	for i, f := range vectorFloats {
+		rtn[i] = float64(f)
+	}
+	return rtn
+}
+
+var (
+	_ VectorFormatter = FormatVectorForPgvector
+	_ VectorFormatter = FormatVectorAsJSONArray
+	_ VectorFormatter = FormatVectorForSQLiteVec
+	_ VectorFormatter = FormatVectorAsFloat32Array
+	_ VectorFormatter = FormatVectorAsFloat64Array
+)
@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+)
+
+func TestVectorFormatters(t *testing.T) {
+	vector := []float32{1, -0.5, 0.25}
+	tcs := []struct {
+		desc      string
+		formatter embeddingmodels.VectorFormatter
+		want      any
+	}{
+		{
+			desc:      "pgvector",
+			formatter: embeddingmodels.FormatVectorForPgvector,
+			want:      "[1, -0.5, 0.25]",
+		},
+		{
+			desc:      "json array",
+			formatter: embeddingmodels.FormatVectorAsJSONArray,
+			want:      "[1,-0.5,0.25]",
+		},
+		{
+			desc:      "sqlite-vec",
+			formatter: embeddingmodels.FormatVectorForSQLiteVec,
+			want:      []byte{0x00, 0x00, 0x80, 0x3f, 0x00, 0x00, 0x00, 0xbf, 0x00, 0x00, 0x80, 0x3e},
+		},
+		{
+			desc:      "float32 array",
+			formatter: embeddingmodels.FormatVectorAsFloat32Array,
+			want:      []float32{1, -0.5, 0.25},
+		},
+		{
+			desc:      "float64 array",
+			formatter: embeddingmodels.FormatVectorAsFloat64Array,
+			want:      []float64{1, -0.5, 0.25},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.formatter(vector)); diff != "" {
+				t.Fatalf("unexpected vector (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if got := embeddingmodels.FormatVectorAsJSONArray(nil); got != "[]" {
+		t.Fatalf("unexpected empty vector: %v", got)
+	}
+}
@@ -18,13 +18,29 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"google.golang.org/genai"
 )
 
 const EmbeddingModelType string = "gemini"
 
+func init() {
+	if !embeddingmodels.Register(EmbeddingModelType, newConfig) {
+		panic(fmt.Sprintf("embedding model type %q already registered", EmbeddingModelType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (embeddingmodels.EmbeddingModelConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // validate interface
 var _ embeddingmodels.EmbeddingModelConfig = Config{}
 
@@ -75,7 +91,7 @@ func (m EmbeddingModel) EmbeddingModelType() string {
 }
 
 func (m EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
-	return m.Config
+	return secrets.Redact(m.Config)
 }
 
 func (m EmbeddingModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const EmbeddingModelType string = "http"
+
+// defaultInputField is the field of the request body containing the texts.
+const defaultInputField = "input"
+
+func init() {
+	if !embeddingmodels.Register(EmbeddingModelType, newConfig) {
+		panic(fmt.Sprintf("embedding model type %q already registered", EmbeddingModelType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (embeddingmodels.EmbeddingModelConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// validate interface
+var _ embeddingmodels.EmbeddingModelConfig = Config{}
+
+// Config is the configuration of an embedding model served by a generic HTTP
+// endpoint, such as Text Embeddings Inference or Ollama. The texts are sent in
+// the InputField of a JSON request body, and the embeddings are read from the
+// EmbeddingsField of the JSON response.
+type Config struct {
+	Name            string            `yaml:"name" validate:"required"`
+	Type            string            `yaml:"type" validate:"required"`
+	URL             string            `yaml:"url" validate:"required"`
+	Model           string            `yaml:"model"`
+	ApiKey          string            `yaml:"apiKey"`
+	AuthHeader      string            `yaml:"authHeader"`
+	Headers         map[string]string `yaml:"headers"`
+	InputField      string            `yaml:"inputField"`
+	EmbeddingsField string            `yaml:"embeddingsField"`
+	Body            map[string]any    `yaml:"body"`
+	Dimension       int               `yaml:"dimension" validate:"gte=0"`
+	BatchSize       int               `yaml:"batchSize" validate:"gte=0"`
+	TaskPrefix      string            `yaml:"taskPrefix"`
+}
+
+// Returns the embedding model type
+func (cfg Config) EmbeddingModelConfigType() string {
+	return EmbeddingModelType
+}
+
+// Initialize an HTTP embedding model
+func (cfg Config) Initialize(ctx context.Context) (embeddingmodels.EmbeddingModel, error) {
+	m := &EmbeddingModel{
+		Config: cfg,
+		Client: &http.Client{},
+	}
+	return m, nil
+}
+
+var _ embeddingmodels.EmbeddingModel = EmbeddingModel{}
+
+type EmbeddingModel struct {
+	Client *http.Client
+	Config
+}
+
+// Returns the embedding model type
+func (m EmbeddingModel) EmbeddingModelType() string {
+	return EmbeddingModelType
+}
+
+func (m EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return secrets.Redact(m.Config)
+}
+
+func (m EmbeddingModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+	}
+
+	headers := maps.Clone(m.Headers)
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	if m.ApiKey != "" {
+		k, v := embeddingmodels.AuthHeader(m.AuthHeader, m.ApiKey)
+		headers[k] = v
+	}
+	inputField := m.InputField
+	if inputField == "" {
+		inputField = defaultInputField
+	}
+
+	texts := embeddingmodels.WithPrefix(m.TaskPrefix, parameters)
+	embeddings, err := embeddingmodels.EmbedInBatches(ctx, texts, m.BatchSize, func(ctx context.Context, batch []string) ([][]float32, error) {
+		req := maps.Clone(m.Body)
+		if req == nil {
+			req = make(map[string]any)
+		}
+		req[inputField] = batch
+		if m.Model != "" {
+			req["model"] = m.Model
+		}
+		if m.Dimension > 0 {
+			req["dimensions"] = m.Dimension
+		}
+		var resp any
+		if err := embeddingmodels.PostJSON(ctx, m.Client, m.URL, headers, req, &resp); err != nil {
+			return nil, err
+		}
+		return extractEmbeddings(resp, m.EmbeddingsField)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to embed parameters using %s: %w", m.URL, err)
+	}
+
+	logger.DebugContext(ctx, fmt.Sprintf("successfully embedded %d text parameters using %s", len(parameters), m.URL))
+	return embeddings, nil
+}
+
+// extractEmbeddings returns the embeddings at the dot-separated field of the
+// response, or the response itself if the field is empty.
+func extractEmbeddings(resp any, field string) ([][]float32, error) {
+	v := resp
+	if field != "" {
+		for _, key := range strings.Split(field, ".") {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unable to find field %q in the response: %q is not an object", field, key)
+			}
+			if v, ok = obj[key]; !ok {
+				return nil, fmt.Errorf("unable to find field %q in the response", field)
+			}
+		}
+	}
+	// the embeddings are decoded again to convert them to floats
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var embeddings [][]float32
+	if err := json.Unmarshal(b, &embeddings); err != nil {
+		return nil, fmt.Errorf("embeddings of the response are not a list of lists of numbers: %w", err)
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	embeddinghttp "github.com/googleapis/genai-toolbox/internal/embeddingmodels/http"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlHTTP(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.EmbeddingModelConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: embeddingModels
+			name: my-tei-model
+			type: http
+			url: http://localhost:8080/embed
+			inputField: inputs
+			`,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-tei-model": embeddinghttp.Config{
+					Name:       "my-tei-model",
+					Type:       embeddinghttp.EmbeddingModelType,
+					URL:        "http://localhost:8080/embed",
+					InputField: "inputs",
+				},
+			},
+		},
+		{
+			desc: "full example with optional fields",
+			in: `
+			kind: embeddingModels
+			name: my-ollama-model
+			type: http
+			url: http://localhost:11434/api/embed
+			model: nomic-embed-text
+			apiKey: test-api-key
+			authHeader: X-Api-Key
+			headers:
+			  X-Tenant: my-tenant
+			embeddingsField: embeddings
+			body:
+			  truncate: true
+			dimension: 768
+			batchSize: 32
+			taskPrefix: "search_query: "
+			`,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-ollama-model": embeddinghttp.Config{
+					Name:            "my-ollama-model",
+					Type:            embeddinghttp.EmbeddingModelType,
+					URL:             "http://localhost:11434/api/embed",
+					Model:           "nomic-embed-text",
+					ApiKey:          "test-api-key",
+					AuthHeader:      "X-Api-Key",
+					Headers:         map[string]string{"X-Tenant": "my-tenant"},
+					EmbeddingsField: "embeddings",
+					Body:            map[string]any{"truncate": true},
+					Dimension:       768,
+					BatchSize:       32,
+					TaskPrefix:      "search_query: ",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, got, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("incorrect parse: %v", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlHTTP(t *testing.T) {
+	in := `
+	kind: embeddingModels
+	name: bad-model
+	type: http
+	`
+	_, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(in))
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	if !strings.Contains(err.Error(), "Field validation for 'URL' failed on the 'required' tag") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestEmbedParameters(t *testing.T) {
+	var requests []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "test-api-key" || r.Header.Get("X-Tenant") != "my-tenant" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+		var embeddings [][]float32
+		for _, in := range req["inputs"].([]any) {
+			embeddings = append(embeddings, []float32{float32(len(in.(string)))})
+		}
+		switch r.URL.Path {
+		case "/embed":
+			_ = json.NewEncoder(w).Encode(embeddings)
+		case "/api/embed":
+			_ = json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"embeddings": embeddings}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to create context: %s", err)
+	}
+	tcs := []struct {
+		desc            string
+		path            string
+		embeddingsField string
+		want            [][]float32
+		wantErr         string
+	}{
+		{
+			desc: "top-level embeddings",
+			path: "/embed",
+			want: [][]float32{{4}, {5}, {6}},
+		},
+		{
+			desc:            "nested embeddings",
+			path:            "/api/embed",
+			embeddingsField: "result.embeddings",
+			want:            [][]float32{{4}, {5}, {6}},
+		},
+		{
+			desc:            "missing embeddings field",
+			path:            "/api/embed",
+			embeddingsField: "embeddings",
+			wantErr:         `unable to find field "embeddings" in the response`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			requests = nil
+			cfg := embeddinghttp.Config{
+				Name:            "my-http-model",
+				Type:            embeddinghttp.EmbeddingModelType,
+				URL:             ts.URL + tc.path,
+				Model:           "my-model",
+				ApiKey:          "test-api-key",
+				AuthHeader:      "X-Api-Key",
+				Headers:         map[string]string{"X-Tenant": "my-tenant"},
+				InputField:      "inputs",
+				EmbeddingsField: tc.embeddingsField,
+				Body:            map[string]any{"truncate": true},
+				BatchSize:       2,
+				TaskPrefix:      "q: ",
+			}
+			m, err := cfg.Initialize(ctx)
+			if err != nil {
+				t.Fatalf("unable to initialize embedding model: %s", err)
+			}
+			got, err := m.EmbedParameters(ctx, []string{"a", "bb", "ccc"})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected embeddings (-want +got):\n%s", diff)
+			}
+			wantRequests := []map[string]any{
+				{"inputs": []any{"q: a", "q: bb"}, "model": "my-model", "truncate": true},
+				{"inputs": []any{"q: ccc"}, "model": "my-model", "truncate": true},
+			}
+			if diff := cmp.Diff(wantRequests, requests); diff != "" {
+				t.Errorf("unexpected requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const EmbeddingModelType string = "openai"
+
+// defaultBaseURL is the base URL of the OpenAI API.
+const defaultBaseURL = "https://api.openai.com/v1"
+
+func init() {
+	if !embeddingmodels.Register(EmbeddingModelType, newConfig) {
+		panic(fmt.Sprintf("embedding model type %q already registered", EmbeddingModelType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (embeddingmodels.EmbeddingModelConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// validate interface
+var _ embeddingmodels.EmbeddingModelConfig = Config{}
+
+// Config is the configuration of an embedding model served with the OpenAI
+// embeddings API, by OpenAI or by compatible servers such as vLLM or Ollama.
+type Config struct {
+	Name       string `yaml:"name" validate:"required"`
+	Type       string `yaml:"type" validate:"required"`
+	Model      string `yaml:"model" validate:"required"`
+	BaseURL    string `yaml:"baseUrl"`
+	ApiKey     string `yaml:"apiKey"`
+	AuthHeader string `yaml:"authHeader"`
+	Dimension  int    `yaml:"dimension" validate:"gte=0"`
+	BatchSize  int    `yaml:"batchSize" validate:"gte=0"`
+	TaskPrefix string `yaml:"taskPrefix"`
+}
+
+// Returns the embedding model type
+func (cfg Config) EmbeddingModelConfigType() string {
+	return EmbeddingModelType
+}
+
+// Initialize an OpenAI-compatible embedding model
+func (cfg Config) Initialize(ctx context.Context) (embeddingmodels.EmbeddingModel, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	m := &EmbeddingModel{
+		Config:   cfg,
+		Client:   &http.Client{},
+		endpoint: strings.TrimSuffix(baseURL, "/") + "/embeddings",
+	}
+	return m, nil
+}
+
+var _ embeddingmodels.EmbeddingModel = EmbeddingModel{}
+
+type EmbeddingModel struct {
+	Client   *http.Client
+	endpoint string
+	Config
+}
+
+// Returns the embedding model type
+func (m EmbeddingModel) EmbeddingModelType() string {
+	return EmbeddingModelType
+}
+
+func (m EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return secrets.Redact(m.Config)
+}
+
+// embeddingsRequest is the request body of the embeddings API.
+type embeddingsRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+}
+
+// embeddingsResponse is the response body of the embeddings API.
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (m EmbeddingModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+	}
+
+	headers := make(map[string]string)
+	if m.ApiKey != "" {
+		k, v := embeddingmodels.AuthHeader(m.AuthHeader, m.ApiKey)
+		headers[k] = v
+	}
+
+	texts := embeddingmodels.WithPrefix(m.TaskPrefix, parameters)
+	embeddings, err := embeddingmodels.EmbedInBatches(ctx, texts, m.BatchSize, func(ctx context.Context, batch []string) ([][]float32, error) {
+		req := embeddingsRequest{
+			Model:          m.Model,
+			Input:          batch,
+			EncodingFormat: "float",
+			Dimensions:     m.Dimension,
+		}
+		var resp embeddingsResponse
+		if err := embeddingmodels.PostJSON(ctx, m.Client, m.endpoint, headers, req, &resp); err != nil {
+			return nil, err
+		}
+		// the embeddings are not guaranteed to be in the order of the input
+		sort.Slice(resp.Data, func(i, j int) bool { return resp.Data[i].Index < resp.Data[j].Index })
+		rtn := make([][]float32, 0, len(resp.Data))
+		for _, d := range resp.Data {
+			rtn = append(rtn, d.Embedding)
+		}
+		return rtn, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to embed parameters using model %s: %w", m.Model, err)
+	}
+
+	logger.DebugContext(ctx, fmt.Sprintf("successfully embedded %d text parameters using model %s", len(parameters), m.Model))
+	return embeddings, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlOpenAI(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.EmbeddingModelConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: embeddingModels
+			name: my-openai-model
+			type: openai
+			model: text-embedding-3-small
+			`,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-openai-model": openai.Config{
+					Name:  "my-openai-model",
+					Type:  openai.EmbeddingModelType,
+					Model: "text-embedding-3-small",
+				},
+			},
+		},
+		{
+			desc: "full example with optional fields",
+			in: `
+			kind: embeddingModels
+			name: my-vllm-model
+			type: openai
+			model: intfloat/e5-large-v2
+			baseUrl: http://localhost:8000/v1
+			apiKey: test-api-key
+			authHeader: api-key
+			dimension: 768
+			batchSize: 16
+			taskPrefix: "query: "
+			`,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-vllm-model": openai.Config{
+					Name:       "my-vllm-model",
+					Type:       openai.EmbeddingModelType,
+					Model:      "intfloat/e5-large-v2",
+					BaseURL:    "http://localhost:8000/v1",
+					ApiKey:     "test-api-key",
+					AuthHeader: "api-key",
+					Dimension:  768,
+					BatchSize:  16,
+					TaskPrefix: "query: ",
+				},
+			},
+		},
+		{
+			desc: "with model options",
+			in: `
+			kind: embeddingModels
+			name: my-openai-model
+			type: openai
+			model: text-embedding-3-small
+			cache:
+			  size: 500
+			  ttl: 24h
+			  dir: /var/cache/toolbox
+			batching:
+			  window: 20ms
+			`,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-openai-model": embeddingmodels.OptionsEmbeddingModelConfig{
+					EmbeddingModelConfig: openai.Config{
+						Name:  "my-openai-model",
+						Type:  openai.EmbeddingModelType,
+						Model: "text-embedding-3-small",
+					},
+					ModelOptions: embeddingmodels.ModelOptions{
+						Cache:    &embeddingmodels.CacheOptions{Size: 500, TTL: "24h", Dir: "/var/cache/toolbox"},
+						Batching: &embeddingmodels.BatchingOptions{Window: "20ms"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, got, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("incorrect parse: %v", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestEmbedParameters(t *testing.T) {
+	var batches [][]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer test-api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+			return
+		}
+		var req struct {
+			Model      string   `json:"model"`
+			Input      []string `json:"input"`
+			Dimensions int      `json:"dimensions"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "my-model" || req.Dimensions != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, req.Input)
+		// return the embeddings in reverse order to check they are sorted
+		type data struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		var resp struct {
+			Data []data `json:"data"`
+		}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, data{Index: i, Embedding: []float32{float32(len(req.Input[i])), 0.5}})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to create context: %s", err)
+	}
+	cfg := openai.Config{
+		Name:       "my-openai-model",
+		Type:       openai.EmbeddingModelType,
+		Model:      "my-model",
+		BaseURL:    ts.URL + "/v1/",
+		ApiKey:     "test-api-key",
+		Dimension:  2,
+		BatchSize:  2,
+		TaskPrefix: "q: ",
+	}
+	m, err := cfg.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize embedding model: %s", err)
+	}
+	got, err := m.EmbedParameters(ctx, []string{"a", "bb", "ccc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := [][]float32{{4, 0.5}, {5, 0.5}, {6, 0.5}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected embeddings (-want +got):\n%s", diff)
+	}
+	wantBatches := [][]string{{"q: a", "q: bb"}, {"q: ccc"}}
+	if diff := cmp.Diff(wantBatches, batches); diff != "" {
+		t.Errorf("unexpected batches (-want +got):\n%s", diff)
+	}
+
+	cfg.ApiKey = "wrong-key"
+	m, err = cfg.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize embedding model: %s", err)
+	}
+	_, err = m.EmbedParameters(ctx, []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), "unexpected status 401") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultCacheSize     = 1000
+	defaultBatchWindow   = 10 * time.Millisecond
+	defaultBatchMaxTexts = 100
+)
+
+// ModelOptions are the options that can be set on any embedding model. They
+// are implemented by wrapping the embedding model.
+type ModelOptions struct {
+	// Cache caches the embeddings of texts to avoid embedding them again.
+	Cache *CacheOptions `yaml:"cache,omitempty"`
+	// Batching merges the concurrent requests to the model into one.
+	Batching *BatchingOptions `yaml:"batching,omitempty"`
+}
+
+// IsZero returns true if no option is set.
+func (o ModelOptions) IsZero() bool {
+	return o.Cache == nil && o.Batching == nil
+}
+
+// Validate returns an error if the options are invalid.
+func (o ModelOptions) Validate() error {
+	if o.Cache != nil {
+		if o.Cache.Size < 0 {
+			return fmt.Errorf("invalid cache size %d: must be positive", o.Cache.Size)
+		}
+		if err := validateDuration("cache ttl", o.Cache.TTL); err != nil {
+			return err
+		}
+	}
+	if o.Batching != nil {
+		if o.Batching.MaxSize < 0 {
+			return fmt.Errorf("invalid batching maxSize %d: must be positive", o.Batching.MaxSize)
+		}
+		if err := validateDuration("batching window", o.Batching.Window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CacheOptions configure the cache of the embeddings of a model, keyed on the
+// model, its dimension and the text.
+type CacheOptions struct {
+	// Size is the maximum number of embeddings kept in memory, 1000 if unset.
+	// The least recently used embeddings are evicted first.
+	Size int `yaml:"size,omitempty"`
+	// TTL is how long an embedding is cached, such as "24h". Embeddings do
+	// not expire if unset.
+	TTL string `yaml:"ttl,omitempty"`
+	// Dir is an optional directory where the embeddings are also stored, so
+	// they are kept across restarts.
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// BatchingOptions configure the micro-batching of the concurrent requests to
+// a model.
+type BatchingOptions struct {
+	// Window is how long a request waits for other requests to join its
+	// batch, "10ms" if unset.
+	Window string `yaml:"window,omitempty"`
+	// MaxSize is the number of texts that sends a batch before the end of
+	// the window, 100 if unset.
+	MaxSize int `yaml:"maxSize,omitempty"`
+}
+
+// validateDuration returns an error if the value is set and not a positive
+// duration.
+func validateDuration(name, v string) error {
+	if v == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+		return fmt.Errorf("invalid %s %q: must be a positive duration such as \"1s\"", name, v)
+	}
+	return nil
+}
+
+// parseDuration returns the duration of a validated value, or the default.
+func parseDuration(v string, defaultV time.Duration) time.Duration {
+	if d, err := time.ParseDuration(v); err == nil {
+		return d
+	}
+	return defaultV
+}
+
+var _ EmbeddingModelConfig = OptionsEmbeddingModelConfig{}
+
+// OptionsEmbeddingModelConfig wraps the config of an embedding model that has
+// model options.
+type OptionsEmbeddingModelConfig struct {
+	EmbeddingModelConfig `yaml:",inline"`
+	ModelOptions         `yaml:",inline"`
+}
+
+// Initialize initializes the wrapped embedding model, and wraps it to
+// implement the model options.
+func (cfg OptionsEmbeddingModelConfig) Initialize(ctx context.Context) (EmbeddingModel, error) {
+	m, err := cfg.EmbeddingModelConfig.Initialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if b := cfg.Batching; b != nil {
+		m = newBatchingModel(m, parseDuration(b.Window, defaultBatchWindow), cmp.Or(b.MaxSize, defaultBatchMaxTexts))
+	}
+	if c := cfg.Cache; c != nil {
+		m, err = newCachingModel(m, cmp.Or(c.Size, defaultCacheSize), parseDuration(c.TTL, 0), c.Dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return optionsModel{EmbeddingModel: m, cfg: cfg}, nil
+}
+
+// optionsModel returns the config with the model options from ToConfig.
+type optionsModel struct {
+	EmbeddingModel
+	cfg OptionsEmbeddingModelConfig
+}
+
+func (m optionsModel) ToConfig() EmbeddingModelConfig {
+	return m.cfg
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeConfig is the config of fakeModel.
+type fakeConfig struct {
+	Model     string         `yaml:"model"`
+	Dimension int            `yaml:"dimension"`
+	Body      map[string]any `yaml:"body"`
+}
+
+func (cfg fakeConfig) EmbeddingModelConfigType() string { return "fake" }
+
+func (cfg fakeConfig) Initialize(context.Context) (EmbeddingModel, error) {
+	return &fakeModel{cfg: cfg}, nil
+}
+
+// fakeModel embeds a text as its length, and records the requests.
+type fakeModel struct {
+	cfg fakeConfig
+
+	mu       sync.Mutex
+	requests [][]string
+}
+
+func (m *fakeModel) EmbeddingModelType() string { return "fake" }
+
+func (m *fakeModel) ToConfig() EmbeddingModelConfig { return m.cfg }
+
+func (m *fakeModel) EmbedParameters(_ context.Context, texts []string) ([][]float32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, texts)
+	rtn := make([][]float32, len(texts))
+	for i, t := range texts {
+		rtn[i] = []float32{float32(len(t)), float32(m.cfg.Dimension)}
+	}
+	return rtn, nil
+}
+
+func (m *fakeModel) Requests() [][]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests
+}
+
+func TestCachingModel(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeModel{cfg: fakeConfig{Model: "m", Dimension: 2}}
+	cm, err := newCachingModel(fake, 2, time.Minute, "")
+	if err != nil {
+		t.Fatalf("unable to create caching model: %s", err)
+	}
+	now := time.Now()
+	cm.now = func() time.Time { return now }
+
+	embed := func(texts ...string) [][]float32 {
+		got, err := cm.EmbedParameters(ctx, texts)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return got
+	}
+
+	// duplicated texts are embedded once
+	if diff := cmp.Diff([][]float32{{1, 2}, {2, 2}, {1, 2}}, embed("a", "bb", "a")); diff != "" {
+		t.Fatalf("unexpected embeddings (-want +got):\n%s", diff)
+	}
+	// cached texts are not embedded again
+	embed("bb", "a")
+	// "a" is the least recently used text, and is evicted
+	embed("bb")
+	embed("ccc")
+	embed("a")
+	// cached texts expire after the ttl
+	now = now.Add(2 * time.Minute)
+	embed("a")
+
+	want := [][]string{{"a", "bb"}, {"ccc"}, {"a"}, {"a"}}
+	if diff := cmp.Diff(want, fake.Requests()); diff != "" {
+		t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+	}
+
+	// models with another dimension do not share the cache entries
+	other := &fakeModel{cfg: fakeConfig{Model: "m", Dimension: 3}}
+	if cacheKeyPrefix(other) == cm.keyPrefix {
+		t.Fatalf("cache key of models with different dimensions are equal: %q", cm.keyPrefix)
+	}
+	// nor do models sending another request body
+	other = &fakeModel{cfg: fakeConfig{Model: "m", Dimension: 2, Body: map[string]any{"truncate": "END"}}}
+	if cacheKeyPrefix(other) == cm.keyPrefix {
+		t.Fatalf("cache key of models with different bodies are equal: %q", cm.keyPrefix)
+	}
+}
+
+func TestCachingModelDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	fake := &fakeModel{cfg: fakeConfig{Model: "m", Dimension: 2}}
+	cm, err := newCachingModel(fake, 10, 0, dir)
+	if err != nil {
+		t.Fatalf("unable to create caching model: %s", err)
+	}
+	if _, err := cm.EmbedParameters(ctx, []string{"a", "bb"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// a new cache, such as after a restart, loads the embeddings from the directory
+	restarted := &fakeModel{cfg: fakeConfig{Model: "m", Dimension: 2}}
+	cm, err = newCachingModel(restarted, 10, 0, dir)
+	if err != nil {
+		t.Fatalf("unable to create caching model: %s", err)
+	}
+	got, err := cm.EmbedParameters(ctx, []string{"bb", "a", "ccc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float32{{2, 2}, {1, 2}, {3, 2}}, got); diff != "" {
+		t.Fatalf("unexpected embeddings (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([][]string{{"ccc"}}, restarted.Requests()); diff != "" {
+		t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchingModel(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeModel{cfg: fakeConfig{Model: "m"}}
+	bm := newBatchingModel(fake, 100*time.Millisecond, 4)
+
+	// concurrent requests within the window are sent together
+	inputs := [][]string{{"a"}, {"bb", "ccc"}}
+	var wg sync.WaitGroup
+	results := make([][][]float32, len(inputs))
+	for i, texts := range inputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := bm.EmbedParameters(ctx, texts)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			results[i] = got
+		}()
+	}
+	wg.Wait()
+	if diff := cmp.Diff([][][]float32{{{1, 0}}, {{2, 0}, {3, 0}}}, results); diff != "" {
+		t.Fatalf("unexpected embeddings (-want +got):\n%s", diff)
+	}
+	if got := fake.Requests(); len(got) != 1 || len(got[0]) != 3 {
+		t.Fatalf("expected a single request with 3 texts, got %v", got)
+	}
+
+	// a full batch is sent without waiting for the window
+	start := time.Now()
+	if _, err := bm.EmbedParameters(ctx, []string{"a", "b", "c", "d"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		t.Fatalf("full batch waited for the window: %s", elapsed)
+	}
+}
+
+// hangingModel never responds, until its context is done.
+type hangingModel struct {
+	fakeModel
+}
+
+func (m *hangingModel) EmbedParameters(ctx context.Context, _ []string) ([][]float32, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestBatchingModelTimeout(t *testing.T) {
+	bm := newBatchingModel(&hangingModel{}, time.Millisecond, 4)
+	bm.timeout = 50 * time.Millisecond
+
+	// the batch is not cancelled with its requests, but by its own timeout
+	_, err := bm.EmbedParameters(context.Background(), []string{"a"})
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "embedding batch exceeded its timeout of 50ms") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOptionsEmbeddingModelConfig(t *testing.T) {
+	cfg := OptionsEmbeddingModelConfig{
+		EmbeddingModelConfig: fakeConfig{Model: "m"},
+		ModelOptions: ModelOptions{
+			Cache:    &CacheOptions{TTL: "1h"},
+			Batching: &BatchingOptions{Window: "1ms"},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	m, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize model: %s", err)
+	}
+	if diff := cmp.Diff(EmbeddingModelConfig(cfg), m.ToConfig()); diff != "" {
+		t.Fatalf("unexpected config (-want +got):\n%s", diff)
+	}
+	if _, err := m.EmbedParameters(context.Background(), []string{"a"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	invalid := ModelOptions{Cache: &CacheOptions{TTL: "forever"}}
+	if err := invalid.Validate(); err == nil {
+		t.Fatalf("expected error for invalid ttl")
+	}
+}
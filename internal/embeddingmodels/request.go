@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyBytes limits how much of an error response is included in errors.
+const maxErrorBodyBytes = 1024
+
+// EmbedInBatches embeds the texts with at most batchSize texts per call of
+// embed, and returns the embeddings in the order of the texts. A batchSize
+// lower than 1 embeds all the texts in a single call.
+func EmbedInBatches(ctx context.Context, texts []string, batchSize int, embed func(context.Context, []string) ([][]float32, error)) ([][]float32, error) {
+	if batchSize < 1 {
+		batchSize = len(texts)
+	}
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+		batch, err := embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) != end-start {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", end-start, len(batch))
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return embeddings, nil
+}
+
+// WithPrefix returns the texts prefixed with the prefix, such as the task
+// prefix expected by some models like "query: ".
+func WithPrefix(prefix string, texts []string) []string {
+	if prefix == "" {
+		return texts
+	}
+	prefixed := make([]string, len(texts))
+	for i, t := range texts {
+		prefixed[i] = prefix + t
+	}
+	return prefixed
+}
+
+// AuthHeader returns the header and value used to send the API key. The key
+// is sent as a bearer token in the Authorization header by default, and as is
+// in any other header.
+func AuthHeader(header, apiKey string) (string, string) {
+	if header == "" || strings.EqualFold(header, "Authorization") {
+		return "Authorization", "Bearer " + apiKey
+	}
+	return header, apiKey
+}
+
+// PostJSON sends the body as JSON to the url and decodes the JSON response
+// into out.
+func PostJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("unable to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send request to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, url, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("unable to decode response from %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestorerules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/api/firebaserules/v1"
+)
+
+const resourceType string = "firestore-rules"
+
+func init() {
+	if !mcpresources.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("resource type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (mcpresources.ResourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetRules(context.Context) (any, error)
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Type        string `yaml:"type" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description"`
+
+	mcpresources.Access `yaml:",inline"`
+}
+
+// validate interface
+var _ mcpresources.ResourceConfig = Config{}
+
+func (cfg Config) ResourceConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (mcpresources.Resource, error) {
+	if err := cfg.Access.Validate(); err != nil {
+		return nil, err
+	}
+	s, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+	if _, ok := s.(compatibleSource); !ok {
+		return nil, fmt.Errorf("invalid source for %q resource: source %q is not a compatible type", resourceType, cfg.Source)
+	}
+
+	description := cfg.Description
+	if description == "" {
+		description = fmt.Sprintf("The active security rules of the %q Firestore source.", cfg.Source)
+	}
+	r := Resource{
+		Config: cfg,
+		mcpManifest: mcpresources.McpManifest{
+			URI:         fmt.Sprintf("firestore://%s/rules", cfg.Source),
+			Name:        cfg.Name,
+			Description: description,
+			MimeType:    "text/plain",
+		},
+	}
+	return r, nil
+}
+
+// validate interface
+var _ mcpresources.Resource = Resource{}
+
+type Resource struct {
+	Config
+	mcpManifest mcpresources.McpManifest
+}
+
+func (r Resource) ToConfig() mcpresources.ResourceConfig {
+	return r.Config
+}
+
+func (r Resource) McpManifest() mcpresources.McpManifest {
+	return r.mcpManifest
+}
+
+func (r Resource) Read(ctx context.Context, resourceMgr tools.SourceProvider, uri string, vars map[string]string) ([]mcpresources.Contents, error) {
+	source, err := mcpresources.GetCompatibleSource[compatibleSource](resourceMgr, r.Source, r.Name, r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := source.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ruleset, ok := res.(*firebaserules.Ruleset)
+	if !ok || ruleset.Source == nil {
+		return nil, fmt.Errorf("unexpected rules response of type %T", res)
+	}
+
+	// rulesets may be split over several files, concatenate them in order
+	files := make([]string, 0, len(ruleset.Source.Files))
+	for _, f := range ruleset.Source.Files {
+		files = append(files, f.Content)
+	}
+	return []mcpresources.Contents{{URI: uri, MimeType: r.mcpManifest.MimeType, Text: strings.Join(files, "\n")}}, nil
+}
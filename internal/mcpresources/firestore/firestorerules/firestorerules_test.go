@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestorerules_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources/firestore/firestorerules"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/firestore"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestParseFromYamlFirestoreRules(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ResourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: resources
+            name: firestore_rules
+            type: firestore-rules
+            source: my-firestore
+			`,
+			want: server.ResourceConfigs{
+				"firestore_rules": firestorerules.Config{
+					Name:   "firestore_rules",
+					Type:   "firestore-rules",
+					Source: "my-firestore",
+				},
+			},
+		},
+		{
+			desc: "with access controls",
+			in: `
+            kind: resources
+            name: firestore_rules
+            type: firestore-rules
+            source: my-firestore
+            authRequired:
+              - my-google-auth
+            policies:
+              - claim: email
+                endsWith: "@corp.com"
+			`,
+			want: server.ResourceConfigs{
+				"firestore_rules": firestorerules.Config{
+					Name:   "firestore_rules",
+					Type:   "firestore-rules",
+					Source: "my-firestore",
+					Access: mcpresources.Access{
+						AuthRequired: []string{"my-google-auth"},
+						Policies:     []tools.Policy{{Claim: "email", EndsWith: "@corp.com"}},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, _, _, _, got, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeFirestoreRules(t *testing.T) {
+	srcs := map[string]sources.Source{
+		"my-firestore":   &firestore.Source{},
+		"my-pg-instance": &postgres.Source{},
+	}
+	cfg := firestorerules.Config{
+		Name:   "firestore_rules",
+		Type:   "firestore-rules",
+		Source: "my-firestore",
+	}
+	r, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := mcpresources.McpManifest{
+		URI:         "firestore://my-firestore/rules",
+		Name:        "firestore_rules",
+		Description: `The active security rules of the "my-firestore" Firestore source.`,
+		MimeType:    "text/plain",
+	}
+	if diff := cmp.Diff(want, r.McpManifest()); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+
+	for _, source := range []string{"missing", "my-pg-instance"} {
+		cfg.Source = source
+		if _, err := cfg.Initialize(srcs); err == nil {
+			t.Fatalf("expected error for source %q", source)
+		}
+	}
+
+	// policies are validated when the resource is initialized
+	cfg.Source = "my-firestore"
+	cfg.Policies = []tools.Policy{{Claim: "email"}}
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatalf("expected error for invalid policy")
+	}
+}
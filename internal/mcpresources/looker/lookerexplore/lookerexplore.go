@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lookerexplore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/looker/lookercommon"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	v4 "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+const resourceType string = "looker-explore"
+
+const fieldProperties = "name,type,label,label_short,description,synonyms,tags,hidden,suggestable,suggestions,suggest_dimension,suggest_explore"
+
+const exploreFields = "name,label,description,fields(dimensions(" + fieldProperties + "),measures(" + fieldProperties + "))"
+
+func init() {
+	if !mcpresources.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("resource type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (mcpresources.ResourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	UseClientAuthorization() bool
+	LookerApiSettings() *rtl.ApiSettings
+	GetLookerSDK(string) (*v4.LookerSDK, error)
+	LookerShowHiddenFields() bool
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Type        string `yaml:"type" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description"`
+
+	mcpresources.Access `yaml:",inline"`
+}
+
+// validate interface
+var _ mcpresources.ResourceConfig = Config{}
+
+func (cfg Config) ResourceConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (mcpresources.Resource, error) {
+	if err := cfg.Access.Validate(); err != nil {
+		return nil, err
+	}
+	s, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+	source, ok := s.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q resource: source %q is not a compatible type", resourceType, cfg.Source)
+	}
+	// resources are read without request headers, so there is no client
+	// token to forward to Looker
+	if source.UseClientAuthorization() {
+		return nil, fmt.Errorf("source %q uses client authorization, which is not supported by %q resources", cfg.Source, resourceType)
+	}
+
+	description := cfg.Description
+	if description == "" {
+		description = fmt.Sprintf("Dimensions and measures of an explore in the %q Looker source.", cfg.Source)
+	}
+	r := Resource{
+		Config: cfg,
+		mcpManifest: mcpresources.McpManifest{
+			URITemplate: fmt.Sprintf("looker://%s/{model}/{explore}", cfg.Source),
+			Name:        cfg.Name,
+			Description: description,
+			MimeType:    "application/json",
+		},
+	}
+	return r, nil
+}
+
+// validate interface
+var _ mcpresources.Resource = Resource{}
+
+type Resource struct {
+	Config
+	mcpManifest mcpresources.McpManifest
+}
+
+func (r Resource) ToConfig() mcpresources.ResourceConfig {
+	return r.Config
+}
+
+func (r Resource) McpManifest() mcpresources.McpManifest {
+	return r.mcpManifest
+}
+
+func (r Resource) Read(ctx context.Context, resourceMgr tools.SourceProvider, uri string, vars map[string]string) ([]mcpresources.Contents, error) {
+	source, err := mcpresources.GetCompatibleSource[compatibleSource](resourceMgr, r.Source, r.Name, r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk, err := source.GetLookerSDK("")
+	if err != nil {
+		return nil, fmt.Errorf("error getting sdk: %w", err)
+	}
+	fields := exploreFields
+	req := v4.RequestLookmlModelExplore{
+		LookmlModelName: vars["model"],
+		ExploreName:     vars["explore"],
+		Fields:          &fields,
+	}
+	resp, err := sdk.LookmlModelExplore(req, source.LookerApiSettings())
+	if err != nil {
+		return nil, fmt.Errorf("error getting explore: %w", err)
+	}
+	if err := lookercommon.CheckLookerExploreFields(&resp); err != nil {
+		return nil, err
+	}
+
+	dimensions, err := lookercommon.ExtractLookerFieldProperties(ctx, resp.Fields.Dimensions, source.LookerShowHiddenFields())
+	if err != nil {
+		return nil, fmt.Errorf("error extracting dimensions: %w", err)
+	}
+	measures, err := lookercommon.ExtractLookerFieldProperties(ctx, resp.Fields.Measures, source.LookerShowHiddenFields())
+	if err != nil {
+		return nil, fmt.Errorf("error extracting measures: %w", err)
+	}
+
+	explore := map[string]any{
+		"model":      vars["model"],
+		"explore":    vars["explore"],
+		"dimensions": dimensions,
+		"measures":   measures,
+	}
+	if resp.Label != nil {
+		explore["label"] = *resp.Label
+	}
+	if resp.Description != nil {
+		explore["description"] = *resp.Description
+	}
+	text, err := json.Marshal(explore)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal explore: %w", err)
+	}
+	return []mcpresources.Contents{{URI: uri, MimeType: r.mcpManifest.MimeType, Text: string(text)}}, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lookerexplore_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources/looker/lookerexplore"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/looker"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlLookerExplore(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ResourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: resources
+            name: looker_explores
+            type: looker-explore
+            source: my-looker-instance
+            description: some description
+			`,
+			want: server.ResourceConfigs{
+				"looker_explores": lookerexplore.Config{
+					Name:        "looker_explores",
+					Type:        "looker-explore",
+					Source:      "my-looker-instance",
+					Description: "some description",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, _, _, _, got, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeLookerExplore(t *testing.T) {
+	srcs := map[string]sources.Source{
+		"my-looker-instance": &looker.Source{Config: looker.Config{UseClientOAuth: "false"}},
+		"my-oauth-instance":  &looker.Source{Config: looker.Config{UseClientOAuth: "true"}},
+		"my-pg-instance":     &postgres.Source{},
+	}
+	cfg := lookerexplore.Config{
+		Name:   "looker_explores",
+		Type:   "looker-explore",
+		Source: "my-looker-instance",
+	}
+	r, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := mcpresources.McpManifest{
+		URITemplate: "looker://my-looker-instance/{model}/{explore}",
+		Name:        "looker_explores",
+		Description: `Dimensions and measures of an explore in the "my-looker-instance" Looker source.`,
+		MimeType:    "application/json",
+	}
+	if diff := cmp.Diff(want, r.McpManifest()); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+
+	for _, source := range []string{"missing", "my-oauth-instance", "my-pg-instance"} {
+		cfg.Source = source
+		if _, err := cfg.Initialize(srcs); err == nil {
+			t.Fatalf("expected error for source %q", source)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcpresources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// ResourceConfigFactory defines the signature for a function that creates and
+// decodes a specific resource's configuration.
+type ResourceConfigFactory func(ctx context.Context, name string, decoder *yaml.Decoder) (ResourceConfig, error)
+
+var resourceRegistry = make(map[string]ResourceConfigFactory)
+
+// Register allows individual resource packages to register their configuration
+// factory function. This is typically called from an init() function in the
+// resource's package. It associates a 'type' string with a function that can
+// produce the specific ResourceConfig type. It returns true if the registration
+// was successful, and false if a resource with the same type was already
+// registered.
+func Register(resourceType string, factory ResourceConfigFactory) bool {
+	if _, exists := resourceRegistry[resourceType]; exists {
+		// Resource with this type already exists, do not overwrite.
+		return false
+	}
+	resourceRegistry[resourceType] = factory
+	return true
+}
+
+// DecodeConfig looks up the registered factory for the given type and uses it
+// to decode the resource configuration.
+func DecodeConfig(ctx context.Context, resourceType string, name string, decoder *yaml.Decoder) (ResourceConfig, error) {
+	factory, found := resourceRegistry[resourceType]
+	if !found {
+		return nil, fmt.Errorf("unknown resource type: %q", resourceType)
+	}
+	resourceConfig, err := factory(ctx, name, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse resource %q as type %q: %w", name, resourceType, err)
+	}
+	return resourceConfig, nil
+}
+
+type ResourceConfig interface {
+	ResourceConfigType() string
+	Initialize(map[string]sources.Source) (Resource, error)
+}
+
+type Resource interface {
+	// Read returns the contents of the resource identified by uri. For
+	// resource templates, vars contains the values of the template variables.
+	Read(ctx context.Context, resourceMgr tools.SourceProvider, uri string, vars map[string]string) ([]Contents, error)
+	McpManifest() McpManifest
+	Authorized(verifiedAuthServices []string) bool
+	GetPolicies() []tools.Policy
+	ToConfig() ResourceConfig
+}
+
+// Access are the access controls that can be set on any resource. They are
+// enforced by the server in the same way as for tool calls.
+type Access struct {
+	// AuthRequired lists the auth services, one of which must be verified to
+	// read the resource.
+	AuthRequired []string `yaml:"authRequired"`
+	// Policies are the authorization policies of the resource.
+	Policies []tools.Policy `yaml:"policies"`
+}
+
+// Validate checks that the policies are well-formed.
+func (a Access) Validate() error {
+	for _, p := range a.Policies {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Authorized returns true if one of the required auth services is verified.
+func (a Access) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(a.AuthRequired, verifiedAuthServices)
+}
+
+// GetPolicies returns the authorization policies of the resource.
+func (a Access) GetPolicies() []tools.Policy {
+	return a.Policies
+}
+
+// Authorize checks that the resource may be read through the toolset, given
+// the claims of the verified auth services. Like for tool calls, the auth
+// services and policies of the resource are checked, followed by the policies
+// of the toolset.
+func Authorize(r Resource, toolset tools.Toolset, claimsFromAuth map[string]map[string]any) error {
+	verifiedAuthServices := make([]string, 0, len(claimsFromAuth))
+	for name := range claimsFromAuth {
+		verifiedAuthServices = append(verifiedAuthServices, name)
+	}
+	if !r.Authorized(verifiedAuthServices) {
+		return fmt.Errorf("unauthorized resource read: Please make sure your specify correct auth headers: %w", util.ErrUnauthorized)
+	}
+	if err := tools.EvaluatePolicies(r.GetPolicies(), claimsFromAuth); err != nil {
+		return fmt.Errorf("forbidden resource read: %w", err)
+	}
+	if err := tools.EvaluatePolicies(toolset.Policies, claimsFromAuth); err != nil {
+		return fmt.Errorf("forbidden resource read: %w", err)
+	}
+	return nil
+}
+
+// InToolset returns the resources of resourcesMap that are part of the
+// toolset.
+func InToolset(resourcesMap map[string]Resource, toolset tools.Toolset) map[string]Resource {
+	filtered := make(map[string]Resource, len(resourcesMap))
+	for name, r := range resourcesMap {
+		if toolset.ContainsResource(name) {
+			filtered[name] = r
+		}
+	}
+	return filtered
+}
+
+// McpManifest is the definition for a resource or resource template the MCP
+// client can list. Exactly one of URI and URITemplate is set.
+type McpManifest struct {
+	// The URI of the resource.
+	URI string `json:"uri,omitempty"`
+	// A RFC 6570 URI template that can be used to construct resource URIs.
+	URITemplate string `json:"uriTemplate,omitempty"`
+	// The name of the resource.
+	Name string `json:"name"`
+	// A description of what this resource represents.
+	Description string `json:"description,omitempty"`
+	// The MIME type of the resource contents, if known.
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// IsTemplate returns true if the manifest describes a resource template.
+func (m McpManifest) IsTemplate() bool {
+	return m.URITemplate != ""
+}
+
+// Contents is the text contents of a specific resource.
+type Contents struct {
+	// The URI of this resource.
+	URI string `json:"uri"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+	// The text of the resource.
+	Text string `json:"text"`
+}
+
+// templateVarRegex matches simple string expansions such as `{table}`.
+var templateVarRegex = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// MatchURITemplate matches the uri against a template containing simple
+// string expansions. Each variable matches a single, non-empty path segment.
+// Returns the variable values and whether the uri matched.
+func MatchURITemplate(template, uri string) (map[string]string, bool) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	var names []string
+	last := 0
+	for _, loc := range templateVarRegex.FindAllStringSubmatchIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString("([^/]+)")
+		names = append(names, template[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, false
+	}
+	matches := re.FindStringSubmatch(uri)
+	if matches == nil {
+		return nil, false
+	}
+	vars := make(map[string]string, len(names))
+	for i, name := range names {
+		vars[name] = matches[i+1]
+	}
+	return vars, true
+}
+
+// GetMcpManifests returns the manifests of the provided resources, split into
+// concrete resources and resource templates. Both lists are sorted by name.
+func GetMcpManifests(resourcesMap map[string]Resource) ([]McpManifest, []McpManifest) {
+	resources := make([]McpManifest, 0)
+	templates := make([]McpManifest, 0)
+	for _, r := range resourcesMap {
+		m := r.McpManifest()
+		if m.IsTemplate() {
+			templates = append(templates, m)
+		} else {
+			resources = append(resources, m)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return resources, templates
+}
+
+// FindResource returns the resource that serves the provided uri, along with
+// the template variables extracted from it. Concrete resources take
+// precedence over resource templates.
+func FindResource(resourcesMap map[string]Resource, uri string) (Resource, map[string]string, bool) {
+	names := make([]string, 0, len(resourcesMap))
+	for name := range resourcesMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r := resourcesMap[name]
+		if m := r.McpManifest(); !m.IsTemplate() && m.URI == uri {
+			return r, map[string]string{}, true
+		}
+	}
+	for _, name := range names {
+		r := resourcesMap[name]
+		m := r.McpManifest()
+		if !m.IsTemplate() {
+			continue
+		}
+		if vars, ok := MatchURITemplate(m.URITemplate, uri); ok {
+			return r, vars, true
+		}
+	}
+	return nil, nil, false
+}
+
+// GetCompatibleSource retrieves the source for a resource and checks that it
+// implements the interface required by the resource.
+func GetCompatibleSource[T any](resourceMgr tools.SourceProvider, sourceName, resourceName, resourceType string) (T, error) {
+	var zero T
+	s, ok := resourceMgr.GetSource(sourceName)
+	if !ok {
+		return zero, fmt.Errorf("unable to retrieve source %q for resource %q", sourceName, resourceName)
+	}
+	source, ok := s.(T)
+	if !ok {
+		return zero, fmt.Errorf("invalid source for %q resource: source %q is not a compatible type", resourceType, sourceName)
+	}
+	return source, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcpresources_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+type mockResource struct {
+	mcpresources.Access
+	manifest mcpresources.McpManifest
+}
+
+func (r mockResource) Read(context.Context, tools.SourceProvider, string, map[string]string) ([]mcpresources.Contents, error) {
+	return nil, nil
+}
+
+func (r mockResource) McpManifest() mcpresources.McpManifest {
+	return r.manifest
+}
+
+func (r mockResource) ToConfig() mcpresources.ResourceConfig {
+	return nil
+}
+
+func TestMatchURITemplate(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		template string
+		uri      string
+		want     map[string]string
+		wantOk   bool
+	}{
+		{
+			desc:     "matching uri",
+			template: "postgres://my-pg/{schema}/{table}",
+			uri:      "postgres://my-pg/public/users",
+			want:     map[string]string{"schema": "public", "table": "users"},
+			wantOk:   true,
+		},
+		{
+			desc:     "missing segment",
+			template: "postgres://my-pg/{schema}/{table}",
+			uri:      "postgres://my-pg/public",
+		},
+		{
+			desc:     "variables do not span segments",
+			template: "postgres://my-pg/{schema}/{table}",
+			uri:      "postgres://my-pg/public/users/extra",
+		},
+		{
+			desc:     "literal parts are not regular expressions",
+			template: "looker://a.b/{model}",
+			uri:      "looker://aXb/model",
+		},
+		{
+			desc:     "no variables",
+			template: "firestore://db/rules",
+			uri:      "firestore://db/rules",
+			want:     map[string]string{},
+			wantOk:   true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := mcpresources.MatchURITemplate(tc.template, tc.uri)
+			if ok != tc.wantOk {
+				t.Fatalf("unexpected match result: got %t, want %t", ok, tc.wantOk)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected variables (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindResource(t *testing.T) {
+	static := mockResource{manifest: mcpresources.McpManifest{Name: "static", URI: "mock://tables/public/users"}}
+	template := mockResource{manifest: mcpresources.McpManifest{Name: "template", URITemplate: "mock://tables/{schema}/{table}"}}
+	resourcesMap := map[string]mcpresources.Resource{
+		"static":   static,
+		"template": template,
+	}
+
+	r, vars, ok := mcpresources.FindResource(resourcesMap, "mock://tables/public/users")
+	if !ok || r.McpManifest().Name != "static" || len(vars) != 0 {
+		t.Fatalf("expected concrete resource to take precedence, got %v %v %t", r, vars, ok)
+	}
+
+	r, vars, ok = mcpresources.FindResource(resourcesMap, "mock://tables/public/orders")
+	if !ok || r.McpManifest().Name != "template" {
+		t.Fatalf("expected template resource, got %v %t", r, ok)
+	}
+	if diff := cmp.Diff(map[string]string{"schema": "public", "table": "orders"}, vars); diff != "" {
+		t.Fatalf("unexpected variables (-want +got):\n%s", diff)
+	}
+
+	if _, _, ok := mcpresources.FindResource(resourcesMap, "mock://other"); ok {
+		t.Fatalf("expected no resource to match")
+	}
+
+	resources, templates := mcpresources.GetMcpManifests(resourcesMap)
+	if len(resources) != 1 || resources[0].Name != "static" {
+		t.Fatalf("unexpected resources: %v", resources)
+	}
+	if len(templates) != 1 || templates[0].Name != "template" {
+		t.Fatalf("unexpected resource templates: %v", templates)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	claims := map[string]map[string]any{"my-auth": {"email": "alice@corp.com"}}
+	// only the policies of the toolset the resource is read through apply
+	restricted := tools.Toolset{ToolsetConfig: tools.ToolsetConfig{
+		ResourceNames: []string{"mock"},
+		Policies:      []tools.Policy{{Claim: "groups", Contains: "dba"}},
+	}}
+	corp := tools.Toolset{ToolsetConfig: tools.ToolsetConfig{
+		ResourceNames: []string{"mock"},
+		Policies:      []tools.Policy{{Claim: "email", EndsWith: "@corp.com"}},
+	}}
+	tcs := []struct {
+		desc     string
+		resource mockResource
+		toolset  tools.Toolset
+		claims   map[string]map[string]any
+		wantErr  error
+	}{
+		{
+			desc:     "no access controls",
+			resource: mockResource{},
+		},
+		{
+			desc:     "auth service verified",
+			resource: mockResource{Access: mcpresources.Access{AuthRequired: []string{"my-auth"}}},
+			claims:   claims,
+		},
+		{
+			desc:     "auth service not verified",
+			resource: mockResource{Access: mcpresources.Access{AuthRequired: []string{"my-auth"}}},
+			wantErr:  util.ErrUnauthorized,
+		},
+		{
+			desc:     "resource policy satisfied",
+			resource: mockResource{Access: mcpresources.Access{Policies: []tools.Policy{{Claim: "email", EndsWith: "@corp.com"}}}},
+			claims:   claims,
+		},
+		{
+			desc:     "resource policy denied",
+			resource: mockResource{Access: mcpresources.Access{Policies: []tools.Policy{{Claim: "email", EndsWith: "@example.com"}}}},
+			claims:   claims,
+			wantErr:  util.ErrForbidden,
+		},
+		{
+			desc:     "toolset policy satisfied",
+			resource: mockResource{manifest: mcpresources.McpManifest{Name: "mock"}},
+			toolset:  corp,
+			claims:   claims,
+		},
+		{
+			desc:     "toolset policy denied",
+			resource: mockResource{manifest: mcpresources.McpManifest{Name: "mock"}},
+			toolset:  restricted,
+			claims:   claims,
+			wantErr:  util.ErrForbidden,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := mcpresources.Authorize(tc.resource, tc.toolset, tc.claims)
+			if !errors.Is(err, tc.wantErr) || (err == nil) != (tc.wantErr == nil) {
+				t.Fatalf("unexpected error: got %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestInToolset(t *testing.T) {
+	resourcesMap := map[string]mcpresources.Resource{
+		"a": mockResource{},
+		"b": mockResource{},
+	}
+	toolset := tools.Toolset{ToolsetConfig: tools.ToolsetConfig{ResourceNames: []string{"b"}}}
+	got := mcpresources.InToolset(resourcesMap, toolset)
+	if len(got) != 1 || got["b"] == nil {
+		t.Fatalf("unexpected resources: %v", got)
+	}
+}
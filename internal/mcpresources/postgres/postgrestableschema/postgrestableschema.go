@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrestableschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const resourceType string = "postgres-table-schema"
+
+const columnsStatement = `
+	SELECT column_name, data_type, is_nullable, column_default
+	FROM information_schema.columns
+	WHERE table_schema = $1 AND table_name = $2
+	ORDER BY ordinal_position`
+
+func init() {
+	if !mcpresources.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("resource type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (mcpresources.ResourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+	RunSQL(context.Context, string, []any) (any, error)
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Type        string `yaml:"type" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description"`
+
+	mcpresources.Access `yaml:",inline"`
+}
+
+// validate interface
+var _ mcpresources.ResourceConfig = Config{}
+
+func (cfg Config) ResourceConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (mcpresources.Resource, error) {
+	if err := cfg.Access.Validate(); err != nil {
+		return nil, err
+	}
+	s, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+	if _, ok := s.(compatibleSource); !ok {
+		return nil, fmt.Errorf("invalid source for %q resource: source %q is not a compatible type", resourceType, cfg.Source)
+	}
+
+	description := cfg.Description
+	if description == "" {
+		description = fmt.Sprintf("Column definitions of a table in the %q Postgres source.", cfg.Source)
+	}
+	r := Resource{
+		Config: cfg,
+		mcpManifest: mcpresources.McpManifest{
+			URITemplate: fmt.Sprintf("postgres://%s/{schema}/{table}", cfg.Source),
+			Name:        cfg.Name,
+			Description: description,
+			MimeType:    "application/json",
+		},
+	}
+	return r, nil
+}
+
+// validate interface
+var _ mcpresources.Resource = Resource{}
+
+type Resource struct {
+	Config
+	mcpManifest mcpresources.McpManifest
+}
+
+func (r Resource) ToConfig() mcpresources.ResourceConfig {
+	return r.Config
+}
+
+func (r Resource) McpManifest() mcpresources.McpManifest {
+	return r.mcpManifest
+}
+
+func (r Resource) Read(ctx context.Context, resourceMgr tools.SourceProvider, uri string, vars map[string]string) ([]mcpresources.Contents, error) {
+	source, err := mcpresources.GetCompatibleSource[compatibleSource](resourceMgr, r.Source, r.Name, r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, table := vars["schema"], vars["table"]
+	columns, err := source.RunSQL(ctx, columnsStatement, []any{schema, table})
+	if err != nil {
+		return nil, err
+	}
+	if rows, ok := sqlutil.ResultRows(columns); !ok || len(rows) == 0 {
+		return nil, fmt.Errorf("table %q does not exist in schema %q", table, schema)
+	}
+
+	text, err := json.Marshal(map[string]any{
+		"schema":  schema,
+		"table":   table,
+		"columns": columns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal table schema: %w", err)
+	}
+	return []mcpresources.Contents{{URI: uri, MimeType: r.mcpManifest.MimeType, Text: string(text)}}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrestableschema_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources/postgres/postgrestableschema"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlPostgresTableSchema(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ResourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: resources
+            name: pg_tables
+            type: postgres-table-schema
+            source: my-pg-instance
+            description: some description
+			`,
+			want: server.ResourceConfigs{
+				"pg_tables": postgrestableschema.Config{
+					Name:        "pg_tables",
+					Type:        "postgres-table-schema",
+					Source:      "my-pg-instance",
+					Description: "some description",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, _, _, _, got, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializePostgresTableSchema(t *testing.T) {
+	srcs := map[string]sources.Source{
+		"my-pg-instance":    &postgres.Source{},
+		"my-mysql-instance": &mysql.Source{},
+	}
+	cfg := postgrestableschema.Config{
+		Name:   "pg_tables",
+		Type:   "postgres-table-schema",
+		Source: "my-pg-instance",
+	}
+	r, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := mcpresources.McpManifest{
+		URITemplate: "postgres://my-pg-instance/{schema}/{table}",
+		Name:        "pg_tables",
+		Description: `Column definitions of a table in the "my-pg-instance" Postgres source.`,
+		MimeType:    "application/json",
+	}
+	if diff := cmp.Diff(want, r.McpManifest()); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+
+	cfg.Source = "missing"
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatalf("expected error for missing source")
+	}
+
+	// other SQL sources can run the statement, but are not Postgres
+	cfg.Source = "my-mysql-instance"
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatalf("expected error for incompatible source")
+	}
+}
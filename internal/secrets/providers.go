@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+)
+
+// execTimeout is the maximum duration of the command of an ExecProvider.
+const execTimeout = 30 * time.Second
+
+// FileProvider resolves the path of a file to its content, without the
+// trailing newline. It is compatible with Docker and Kubernetes secrets
+// mounted as files.
+type FileProvider struct{}
+
+func (FileProvider) Resolve(_ context.Context, ref string) (string, error) {
+	b, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// ExecProvider resolves references by running a command with the reference as
+// its last argument. The secret is the standard output of the command, without
+// the trailing newline.
+type ExecProvider struct {
+	Command []string
+}
+
+// NewExecProvider returns an ExecProvider running the command line, split on
+// white spaces.
+func NewExecProvider(commandLine string) (*ExecProvider, error) {
+	command := strings.Fields(commandLine)
+	if len(command) == 0 {
+		return nil, fmt.Errorf("missing command")
+	}
+	return &ExecProvider{Command: command}, nil
+}
+
+func (p *ExecProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+	args := append(append([]string{}, p.Command[1:]...), ref)
+	cmd := exec.CommandContext(ctx, p.Command[0], args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("command %q failed: %w: %s", p.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// SecretManagerProvider resolves references of the form
+// gcp/projects/<project>/secrets/<secret>[/versions/<version>] with Google
+// Cloud Secret Manager, using Application Default Credentials. The latest
+// version is used if none is given.
+type SecretManagerProvider struct {
+	// Endpoint overrides the Secret Manager endpoint.
+	Endpoint string
+	// Client overrides the HTTP client using Application Default Credentials.
+	Client *http.Client
+}
+
+func (p *SecretManagerProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, "gcp/")
+	if !ok {
+		return "", fmt.Errorf("unsupported secret reference, expected the format gcp/projects/<project>/secrets/<secret>[/versions/<version>]")
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretmanager.googleapis.com"
+	}
+	client := p.Client
+	if client == nil {
+		var err error
+		client, err = google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+		if err != nil {
+			return "", fmt.Errorf("unable to find default credentials: %w", err)
+		}
+	}
+
+	var res struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/v1/%s:access", endpoint, name), nil, &res); err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode secret payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// VaultProvider resolves references of the form <path>#<field> with the HTTP
+// API of HashiCorp Vault, e.g. secret/data/postgres#password. Both the KV
+// version 1 and 2 secrets engines are supported. The address and token are
+// read from the VAULT_ADDR and VAULT_TOKEN environment variables.
+type VaultProvider struct {
+	// Client overrides the default HTTP client.
+	Client *http.Client
+}
+
+func (p *VaultProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || field == "" {
+		return "", fmt.Errorf("unsupported secret reference, expected the format <path>#<field>")
+	}
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var res struct {
+		Data map[string]any `json:"data"`
+	}
+	header := http.Header{"X-Vault-Token": []string{os.Getenv("VAULT_TOKEN")}}
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(addr, "/"), strings.TrimPrefix(path, "/"))
+	if err := getJSON(ctx, client, url, header, &res); err != nil {
+		return "", err
+	}
+	data := res.Data
+	// the KV version 2 secrets engine nests the secret in data.data
+	if nested, ok := data["data"].(map[string]any); ok {
+		data = nested
+	}
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("field %q not found", field)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("field %q is not a string", field)
+	}
+	return s, nil
+}
+
+// getJSON sends a GET request and decodes the JSON response into out.
+func getJSON(ctx context.Context, client *http.Client, url string, header http.Header, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Redacted replaces the resolved secrets in redacted configs.
+const Redacted = "[REDACTED]"
+
+var (
+	resolvedMu sync.RWMutex
+	// resolved holds the values of the resolved secrets, and of the fields
+	// containing them. Values of rotated secrets are kept, so that stale
+	// copies of configs stay redacted.
+	resolved = map[string]struct{}{}
+)
+
+// Record records the value of a field containing a resolved secret, such as
+// "Bearer <token>", so that the whole value is redacted by Redact.
+func Record(value string) {
+	if value == "" {
+		return
+	}
+	resolvedMu.Lock()
+	defer resolvedMu.Unlock()
+	resolved[value] = struct{}{}
+}
+
+// RedactString returns Redacted if s is a resolved secret or the recorded
+// value of a field containing one, and s otherwise. Secrets are never replaced
+// within other values, so that unrelated values equal to part of a secret are
+// kept as is.
+func RedactString(s string) string {
+	resolvedMu.RLock()
+	defer resolvedMu.RUnlock()
+	if _, ok := resolved[s]; ok {
+		return Redacted
+	}
+	return s
+}
+
+// Redact returns a copy of a config in which the exported string fields that
+// are resolved secrets, or recorded values containing one, are replaced,
+// including those of nested structs, pointers, slices and maps. The config
+// itself is not modified.
+func Redact[T any](cfg T) T {
+	resolvedMu.RLock()
+	n := len(resolved)
+	resolvedMu.RUnlock()
+	if n == 0 {
+		return cfg
+	}
+	v := redactValue(reflect.ValueOf(&cfg).Elem())
+	return v.Interface().(T)
+}
+
+func redactValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		out := reflect.New(v.Type()).Elem()
+		out.SetString(RedactString(v.String()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if out.Field(i).CanSet() {
+				out.Field(i).Set(redactValue(v.Field(i)))
+			}
+		}
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redactValue(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactValue(v.Elem()))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redactValue(iter.Value()))
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets resolves references to secrets in tools files, such as
+// ${file:/run/secrets/pg_password}, with pluggable providers.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Provider resolves the references to secrets of a scheme, e.g. the path of a
+// file for the "file" scheme.
+type Provider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func(ctx context.Context, ref string) (string, error)
+
+func (f ProviderFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	providersMu      sync.RWMutex
+	providerRegistry = map[string]Provider{
+		"file":   FileProvider{},
+		"secret": &SecretManagerProvider{},
+		"vault":  &VaultProvider{},
+	}
+)
+
+// Register registers the provider of a scheme, replacing the provider that is
+// registered for the scheme, if any.
+func Register(scheme string, p Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providerRegistry[scheme] = p
+}
+
+// IsScheme returns true if a provider is registered for the scheme.
+func IsScheme(scheme string) bool {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	_, ok := providerRegistry[scheme]
+	return ok
+}
+
+// Schemes returns the sorted schemes that have a registered provider.
+func Schemes() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	schemes := make([]string, 0, len(providerRegistry))
+	for s := range providerRegistry {
+		schemes = append(schemes, s)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Resolve resolves the reference to a secret with the provider of the scheme.
+// The value is recorded so that it is redacted by Redact.
+func Resolve(ctx context.Context, scheme, ref string) (string, error) {
+	providersMu.RLock()
+	p, ok := providerRegistry[scheme]
+	providersMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider %q", scheme)
+	}
+	value, err := p.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve secret %q with provider %q: %w", ref, scheme, err)
+	}
+	Record(value)
+	return value, nil
+}
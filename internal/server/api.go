@@ -24,6 +24,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
@@ -39,13 +40,20 @@ func apiRouter(s *Server) (chi.Router, error) {
 	r.Use(middleware.StripSlashes)
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 
+	r.Get("/openapi.json", func(w http.ResponseWriter, r *http.Request) { openAPIHandler(s, w, r) })
 	r.Get("/toolset", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
 	r.Get("/toolset/{toolsetName}", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
+	r.Get("/toolset/{toolsetName}/openapi.json", func(w http.ResponseWriter, r *http.Request) { openAPIHandler(s, w, r) })
 
 	r.Route("/tool/{toolName}", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
 		r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
 	})
+	// toolset scoped routes only allow access to tools within the toolset
+	r.Route("/toolset/{toolsetName}/tool/{toolName}", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
+		r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
+	})
 
 	return r, nil
 }
@@ -92,8 +100,10 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/get")
 	r = r.WithContext(ctx)
 
+	toolsetName := chi.URLParam(r, "toolsetName")
 	toolName := chi.URLParam(r, "toolName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
+	span.SetAttributes(attribute.String("toolset_name", toolsetName))
 	span.SetAttributes(attribute.String("tool_name", toolName))
 	var err error
 	defer func() {
@@ -113,9 +123,8 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			metric.WithAttributes(attribute.String("toolbox.operation.status", status)),
 		)
 	}()
-	tool, ok := s.ResourceMgr.GetTool(toolName)
-	if !ok {
-		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+	_, tool, err := lookupTool(s, toolsetName, toolName)
+	if err != nil {
 		s.logger.DebugContext(ctx, err.Error())
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
@@ -136,16 +145,22 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke")
 	r = r.WithContext(ctx)
 	ctx = util.WithLogger(r.Context(), s.logger)
+	ctx = util.WithInstrumentation(ctx, s.instrumentation)
 
+	toolsetName := chi.URLParam(r, "toolsetName")
 	toolName := chi.URLParam(r, "toolName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
+	span.SetAttributes(attribute.String("toolset_name", toolsetName))
 	span.SetAttributes(attribute.String("tool_name", toolName))
+	ctx = audit.WithLogger(ctx, s.audit, audit.TransportREST)
+	invocation := audit.Start(ctx, toolsetName, toolName)
 	var err error
 	defer func() {
 		if err != nil {
 			span.SetStatus(codes.Error, err.Error())
 		}
 		span.End()
+		invocation.Finish(ctx, err)
 
 		status := "success"
 		if err != nil {
@@ -159,9 +174,8 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		)
 	}()
 
-	tool, ok := s.ResourceMgr.GetTool(toolName)
-	if !ok {
-		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+	toolset, tool, err := lookupTool(s, toolsetName, toolName)
+	if err != nil {
 		s.logger.DebugContext(ctx, err.Error())
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
@@ -203,6 +217,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		}
 		claimsFromAuth[aS.GetName()] = claims
 	}
+	invocation.SetClaims(claimsFromAuth)
 
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
@@ -220,6 +235,14 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
 		return
 	}
+
+	// Check the authorization policies of the tool and the toolset
+	if err = tools.CheckPolicies(tool, toolset, claimsFromAuth); err != nil {
+		err = fmt.Errorf("tool invocation forbidden: %w", err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
 
 	var data map[string]any
@@ -245,6 +268,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
+	invocation.SetParams(params)
 
 	params, err = tool.EmbedParams(ctx, params, s.ResourceMgr.GetEmbeddingModelMap())
 	if err != nil {
@@ -254,7 +278,10 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics := tools.StartInvocationMetrics(ctx, toolName, tool, s.ResourceMgr)
 	res, err := tool.Invoke(ctx, s.ResourceMgr, params, accessToken)
+	metrics.Done(ctx, err)
+	invocation.SetResult(res, err)
 
 	// Determine what error to return to the users.
 	if err != nil {
@@ -296,9 +323,31 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.RecordResult(ctx, res, len(resMarshal))
+
 	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal)})
 }
 
+// lookupTool retrieves a tool by name. If a toolset is specified, tools outside
+// of that toolset are treated as non-existent.
+func lookupTool(s *Server, toolsetName, toolName string) (tools.Toolset, tools.Tool, error) {
+	// the default endpoints use the default toolset, which contains every tool
+	toolset, ok := s.ResourceMgr.GetToolset(toolsetName)
+	if toolsetName != "" {
+		if !ok {
+			return tools.Toolset{}, nil, fmt.Errorf("toolset %q does not exist", toolsetName)
+		}
+		if !toolset.ContainsTool(toolName) {
+			return tools.Toolset{}, nil, fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		}
+	}
+	tool, ok := s.ResourceMgr.GetTool(toolName)
+	if !ok {
+		return tools.Toolset{}, nil, fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+	}
+	return toolset, tool, nil
+}
+
 var _ render.Renderer = &resultResponse{} // Renderer interface for managing response payloads.
 
 // resultResponse is the response sent back when the tool was invocated successfully.
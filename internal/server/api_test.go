@@ -16,20 +16,27 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
 func TestToolsetEndpoint(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2}
 	toolsMap, toolsets, _, _ := setUpResources(t, mockTools, nil)
-	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -126,7 +133,7 @@ func TestToolsetEndpoint(t *testing.T) {
 func TestToolGetEndpoint(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2}
 	toolsMap, toolsets, _, _ := setUpResources(t, mockTools, nil)
-	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -214,7 +221,7 @@ func TestToolGetEndpoint(t *testing.T) {
 func TestToolInvokeEndpoint(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2, tool4, tool5}
 	toolsMap, toolsets, _, _ := setUpResources(t, mockTools, nil)
-	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -295,3 +302,279 @@ func TestToolInvokeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestToolsetScopedToolInvokeEndpoint(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	toolsMap, toolsets, _, _ := setUpResources(t, mockTools, nil)
+	restrictedConfig := tools.ToolsetConfig{
+		Name:      "restricted",
+		ToolNames: []string{tool2.Name},
+		Policies:  []tools.Policy{{Claim: "hd", Equals: "example.com"}},
+	}
+	restricted, err := restrictedConfig.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets[restricted.Name] = restricted
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		toolsetName    string
+		toolName       string
+		requestBody    io.Reader
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "tool within toolset",
+			toolsetName:    "tool1_only",
+			toolName:       tool1.Name,
+			requestBody:    bytes.NewBuffer([]byte(`{}`)),
+			wantStatusCode: http.StatusOK,
+			want:           "{result:[no_params]}\n",
+		},
+		{
+			name:           "tool outside of toolset",
+			toolsetName:    "tool1_only",
+			toolName:       tool2.Name,
+			requestBody:    bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "invalid toolset",
+			toolsetName:    "some_imaginary_toolset",
+			toolName:       tool1.Name,
+			requestBody:    bytes.NewBuffer([]byte(`{}`)),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "toolset denied by policy",
+			toolsetName:    "restricted",
+			toolName:       tool2.Name,
+			requestBody:    bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)),
+			wantStatusCode: http.StatusForbidden,
+			want:           "{status:Forbidden,error:tool invocation forbidden: denied by policy: claim hd must equal example.com: forbidden}\n",
+		},
+		{
+			name:           "same tool through toolset without policies",
+			toolsetName:    "tool2_only",
+			toolName:       tool2.Name,
+			requestBody:    bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)),
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/toolset/%s/tool/%s/invoke", tc.toolsetName, tc.toolName), tc.requestBody, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+
+			if resp.StatusCode != tc.wantStatusCode {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatusCode, resp.StatusCode, string(body))
+			}
+			if tc.want == "" {
+				return
+			}
+
+			got := strings.ReplaceAll(strings.ReplaceAll(string(body), "\\", ""), "\"", "")
+			if got != tc.want {
+				t.Fatalf("unexpected value: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	// only the policies of the toolset the tool is invoked through apply
+	resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool2.Name), bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusOK, resp.StatusCode, string(body))
+	}
+}
+
+func TestToolInvokeAuditLog(t *testing.T) {
+	loginTool := MockTool{
+		Name: "login",
+		Params: parameters.Parameters{
+			parameters.NewStringParameter("user", "The user name."),
+			parameters.NewStringParameter("password", "The password."),
+		},
+		rows: 3,
+	}
+	toolsMap, toolsets, _, _ := setUpResources(t, []MockTool{loginTool, tool1}, nil)
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLogger, err := audit.New(context.Background(), audit.Config{Sinks: []string{path}, Claims: []string{"sub", "email"}}, nil)
+	if err != nil {
+		t.Fatalf("unable to create audit logger: %s", err)
+	}
+	defer auditLogger.Close()
+
+	r, shutdown := setUpAuditedServer(t, "api", auditLogger, toolsMap, toolsets, nil, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		toolName string
+		body     string
+	}{
+		{toolName: loginTool.Name, body: `{"user": "alice", "password": "hunter2"}`},
+		{toolName: "some_imaginary_tool", body: `{}`},
+	} {
+		if _, _, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tc.toolName), bytes.NewBufferString(tc.body), nil); err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read audit log: %s", err)
+	}
+	var got []audit.Event
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var e audit.Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("unable to unmarshal audit event %q: %s", line, err)
+		}
+		got = append(got, e)
+	}
+	rowCount := 3
+	want := []audit.Event{
+		{
+			Transport:    audit.TransportREST,
+			Tool:         loginTool.Name,
+			AuthServices: []string{},
+			Parameters:   map[string]any{"user": "alice", "password": audit.Redacted},
+			RowCount:     &rowCount,
+			Status:       "success",
+		},
+		{
+			Transport:    audit.TransportREST,
+			Tool:         "some_imaginary_tool",
+			AuthServices: []string{},
+			Status:       "error",
+			Error:        `invalid tool name: tool with name "some_imaginary_tool" does not exist`,
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(audit.Event{}, "Timestamp", "DurationMs")); diff != "" {
+		t.Fatalf("unexpected audit events (-want +got):\n%s", diff)
+	}
+}
+
+func TestOpenAPIEndpoint(t *testing.T) {
+	minV, maxV := 1, 10
+	limit := parameters.NewIntParameterWithDefault("limit", 5, "maximum number of rows")
+	limit.MinValue, limit.MaxValue = &minV, &maxV
+	status := parameters.NewStringParameter("status", "status of the orders")
+	status.AllowedValues = []any{"open", "closed"}
+	authTool := MockTool{
+		Name:        "auth_params",
+		Description: "list orders",
+		Params: parameters.Parameters{
+			status,
+			limit,
+			parameters.NewStringParameterWithAuth("email", "user email", []parameters.ParamAuthService{{Name: "my-google-auth", Field: "email"}}),
+		},
+	}
+	mockTools := []MockTool{authTool, tool5}
+	toolsMap, toolsets, _, _ := setUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name       string
+		path       string
+		statusCode int
+		wantPaths  []string
+	}{
+		{
+			name:       "default toolset",
+			path:       "/openapi.json",
+			statusCode: http.StatusOK,
+			wantPaths:  []string{"/api/tool/auth_params/invoke", "/api/tool/require_client_auth_tool/invoke"},
+		},
+		{
+			name:       "named toolset",
+			path:       "/toolset/tool1_only/openapi.json",
+			statusCode: http.StatusOK,
+			wantPaths:  []string{"/api/toolset/tool1_only/tool/auth_params/invoke"},
+		},
+		{
+			name:       "invalid toolset",
+			path:       "/toolset/foo/openapi.json",
+			statusCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodGet, tc.path, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.statusCode {
+				t.Fatalf("response status code is not %d, got %d: %s", tc.statusCode, resp.StatusCode, string(body))
+			}
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+			var doc struct {
+				OpenAPI string                    `json:"openapi"`
+				Paths   map[string]map[string]any `json:"paths"`
+			}
+			if err := json.Unmarshal(body, &doc); err != nil {
+				t.Fatalf("unable to parse OpenAPI document: %s", err)
+			}
+			if doc.OpenAPI != "3.1.0" {
+				t.Errorf("unexpected OpenAPI version %q", doc.OpenAPI)
+			}
+			var gotPaths []string
+			for p := range doc.Paths {
+				gotPaths = append(gotPaths, p)
+			}
+			if diff := cmp.Diff(tc.wantPaths, gotPaths, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("unexpected paths (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	_, body, err := runRequest(ts, http.MethodGet, "/openapi.json", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("unable to parse OpenAPI document: %s", err)
+	}
+	op := doc["paths"].(map[string]any)["/api/tool/auth_params/invoke"].(map[string]any)["post"].(map[string]any)
+	wantSchema := map[string]any{
+		"type":     "object",
+		"required": []any{"status"},
+		"properties": map[string]any{
+			"status": map[string]any{"type": "string", "description": "status of the orders", "enum": []any{"open", "closed"}},
+			"limit":  map[string]any{"type": "integer", "description": "maximum number of rows", "minimum": 1.0, "maximum": 10.0, "default": 5.0},
+		},
+	}
+	gotSchema := op["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	if diff := cmp.Diff(wantSchema, gotSchema); diff != "" {
+		t.Errorf("unexpected request schema (-want +got):\n%s", diff)
+	}
+	wantSecurity := []any{map[string]any{"my-google-auth_token": []any{}}}
+	if diff := cmp.Diff(wantSecurity, op["security"]); diff != "" {
+		t.Errorf("unexpected security (-want +got):\n%s", diff)
+	}
+	op = doc["paths"].(map[string]any)["/api/tool/require_client_auth_tool/invoke"].(map[string]any)["post"].(map[string]any)
+	wantSecurity = []any{map[string]any{"clientAuthorization": []any{}}}
+	if diff := cmp.Diff(wantSecurity, op["security"]); diff != "" {
+		t.Errorf("unexpected security (-want +got):\n%s", diff)
+	}
+}
@@ -24,21 +24,26 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 // fakeVersionString is used as a temporary version string in tests
 const fakeVersionString = "0.0.0"
 
 var (
-	_ tools.Tool     = MockTool{}
-	_ prompts.Prompt = MockPrompt{}
+	_ tools.Tool            = MockTool{}
+	_ prompts.Prompt        = MockPrompt{}
+	_ mcpresources.Resource = MockResource{}
 )
 
 // MockTool is used to mock tools in tests
@@ -49,9 +54,34 @@ type MockTool struct {
 	manifest                     tools.Manifest
 	unauthorized                 bool
 	requiresClientAuthrorization bool
+	outputSchema                 parameters.Parameters
+	reportsProgress              bool
+	blocking                     bool
+	// rows is the number of rows returned, subject to the result limits
+	rows int
 }
 
-func (t MockTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, error) {
+func (t MockTool) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, error) {
+	if t.reportsProgress {
+		util.ReportProgress(ctx, 1, 2, "halfway there")
+	}
+	// blocking tools only return once they are cancelled
+	if t.blocking {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if t.reportsProgress {
+		util.ReportProgress(ctx, 2, 2, "done")
+	}
+	if t.rows > 0 {
+		out := sqlutil.NewRows(ctx, sqlutil.ResultLimits{})
+		for i := 0; i < t.rows; i++ {
+			if !out.Add(t.Name) {
+				break
+			}
+		}
+		return out.Result(), nil
+	}
 	mock := []any{t.Name}
 	return mock, nil
 }
@@ -118,6 +148,7 @@ func (t MockTool) McpManifest() tools.McpManifest {
 		Description: t.Description,
 		InputSchema: toolsSchema,
 	}
+	mcpManifest.OutputSchema = tools.GetMcpOutputSchema(t.outputSchema)
 
 	if len(authParams) > 0 {
 		mcpManifest.Metadata = map[string]any{
@@ -175,6 +206,37 @@ func (p MockPrompt) ToConfig() prompts.PromptConfig {
 	return nil
 }
 
+// MockResource is used to mock MCP resources in tests
+type MockResource struct {
+	mcpresources.Access
+	Name        string
+	URI         string
+	URITemplate string
+}
+
+func (r MockResource) Read(ctx context.Context, resourceMgr tools.SourceProvider, uri string, vars map[string]string) ([]mcpresources.Contents, error) {
+	return []mcpresources.Contents{
+		{
+			URI:      uri,
+			MimeType: "text/plain",
+			Text:     fmt.Sprintf("read %s %v", r.Name, vars),
+		},
+	}, nil
+}
+
+func (r MockResource) McpManifest() mcpresources.McpManifest {
+	return mcpresources.McpManifest{
+		URI:         r.URI,
+		URITemplate: r.URITemplate,
+		Name:        r.Name,
+		MimeType:    "text/plain",
+	}
+}
+
+func (r MockResource) ToConfig() mcpresources.ResourceConfig {
+	return nil
+}
+
 var tool1 = MockTool{
 	Name:   "no_params",
 	Params: []parameters.Parameter{},
@@ -208,6 +270,27 @@ var tool5 = MockTool{
 	requiresClientAuthrorization: true,
 }
 
+var tool6 = MockTool{
+	Name:   "output_schema_tool",
+	Params: []parameters.Parameter{},
+	outputSchema: parameters.Parameters{
+		parameters.NewStringParameter("name", "name of the tool"),
+	},
+}
+
+var tool7 = MockTool{
+	Name:            "progress_tool",
+	Params:          []parameters.Parameter{},
+	reportsProgress: true,
+}
+
+var tool8 = MockTool{
+	Name:            "blocking_tool",
+	Params:          []parameters.Parameter{},
+	reportsProgress: true,
+	blocking:        true,
+}
+
 var prompt1 = MockPrompt{
 	Name: "prompt1",
 	Args: prompts.Arguments{},
@@ -220,6 +303,22 @@ var prompt2 = MockPrompt{
 	},
 }
 
+var resource1 = MockResource{
+	Name: "static_resource",
+	URI:  "mock://static",
+}
+
+var resource2 = MockResource{
+	Name:        "template_resource",
+	URITemplate: "mock://tables/{schema}/{table}",
+}
+
+var resource3 = MockResource{
+	Access: mcpresources.Access{AuthRequired: []string{"my-google-auth"}},
+	Name:   "auth_resource",
+	URI:    "mock://auth",
+}
+
 // setUpResources setups resources to test against
 func setUpResources(t *testing.T, mockTools []MockTool, mockPrompts []MockPrompt) (map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset) {
 	toolsMap := make(map[string]tools.Tool)
@@ -253,19 +352,30 @@ func setUpResources(t *testing.T, mockTools []MockTool, mockPrompts []MockPrompt
 
 	promptsets := make(map[string]prompts.Promptset)
 	if len(allPrompts) > 0 {
-		psc := prompts.PromptsetConfig{Name: "", PromptNames: allPrompts}
-		ps, err := psc.Initialize(fakeVersionString, promptsMap)
-		if err != nil {
-			t.Fatalf("unable to initialize default promptset: %s", err)
+		for name, l := range map[string][]string{
+			"":             allPrompts,
+			"prompt1_only": {allPrompts[0]},
+		} {
+			psc := prompts.PromptsetConfig{Name: name, PromptNames: l}
+			ps, err := psc.Initialize(fakeVersionString, promptsMap)
+			if err != nil {
+				t.Fatalf("unable to initialize promptset %q: %s", name, err)
+			}
+			promptsets[name] = ps
 		}
-		promptsets[""] = ps
 	}
 
 	return toolsMap, toolsets, promptsMap, promptsets
 }
 
-// setUpServer create a new server with tools, toolsets, prompts, and promptsets.
-func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, prompts map[string]prompts.Prompt, promptsets map[string]prompts.Promptset) (chi.Router, func()) {
+// setUpServer create a new server with tools, toolsets, prompts, promptsets, and resources.
+func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, prompts map[string]prompts.Prompt, promptsets map[string]prompts.Promptset, mcpResources map[string]mcpresources.Resource) (chi.Router, func()) {
+	return setUpAuditedServer(t, router, nil, tools, toolsets, prompts, promptsets, mcpResources)
+}
+
+// setUpAuditedServer sets up a server that records tool invocations to the
+// audit logger.
+func setUpAuditedServer(t *testing.T, router string, auditLogger *audit.Logger, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, prompts map[string]prompts.Prompt, promptsets map[string]prompts.Promptset, mcpResources map[string]mcpresources.Resource) (chi.Router, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
@@ -273,7 +383,7 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 		t.Fatalf("unable to initialize logger: %s", err)
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox", false)
 	if err != nil {
 		t.Fatalf("unable to setup otel: %s", err)
 	}
@@ -285,13 +395,15 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(nil, nil, nil, tools, toolsets, prompts, promptsets)
+	resourceManager := resources.NewResourceManager(nil, nil, nil, tools, toolsets, prompts, promptsets, mcpResources)
 
 	server := Server{
 		version:         fakeVersionString,
 		logger:          testLogger,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
+		httpSessions:    newHttpSessionManager(ctx),
+		audit:           auditLogger,
 		ResourceMgr:     resourceManager,
 	}
 
@@ -20,12 +20,18 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/auth"
-	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	_ "github.com/googleapis/genai-toolbox/internal/auth/google"
+	_ "github.com/googleapis/genai-toolbox/internal/auth/oidc"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
-	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/gemini"
+	_ "github.com/googleapis/genai-toolbox/internal/embeddingmodels/gemini"
+	_ "github.com/googleapis/genai-toolbox/internal/embeddingmodels/http"
+	_ "github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -53,6 +59,8 @@ type ServerConfig struct {
 	PromptConfigs PromptConfigs
 	// PromptsetConfigs defines what prompts are available
 	PromptsetConfigs PromptsetConfigs
+	// ResourceConfigs defines what MCP resources are available
+	ResourceConfigs ResourceConfigs
 	// LoggingFormat defines whether structured loggings are used.
 	LoggingFormat logFormat
 	// LogLevel defines the levels to log.
@@ -63,8 +71,17 @@ type ServerConfig struct {
 	TelemetryOTLP string
 	// TelemetryServiceName defines the value of service.name resource attribute.
 	TelemetryServiceName string
+	// TelemetryPrometheus defines whether metrics are exposed on /metrics in
+	// the Prometheus format.
+	TelemetryPrometheus bool
 	// Stdio indicates if Toolbox is listening via MCP stdio.
 	Stdio bool
+	// StdioToolset is the name of the toolset served via MCP stdio, the
+	// default toolset if empty.
+	StdioToolset string
+	// StdioPromptset is the name of the promptset served via MCP stdio, the
+	// default promptset if empty.
+	StdioPromptset string
 	// DisableReload indicates if the user has disabled dynamic reloading for Toolbox.
 	DisableReload bool
 	// UI indicates if Toolbox UI endpoints (/ui) are available.
@@ -75,6 +92,16 @@ type ServerConfig struct {
 	AllowedHosts []string
 	// UserAgentMetadata specifies additional metadata to append to the User-Agent string.
 	UserAgentMetadata []string
+	// McpBatchConcurrency is the maximum number of messages of a MCP JSON-RPC
+	// batch that are processed concurrently.
+	McpBatchConcurrency int
+	// Audit configures the audit log of tool invocations.
+	Audit audit.Config
+	// OptionalSources are the names of the sources whose failing health
+	// checks do not make the server unready.
+	OptionalSources []string
+	// ReadinessTimeout is the timeout of the health check of each source.
+	ReadinessTimeout time.Duration
 }
 
 type logFormat string
@@ -136,8 +163,9 @@ type ToolConfigs map[string]tools.ToolConfig
 type ToolsetConfigs map[string]tools.ToolsetConfig
 type PromptConfigs map[string]prompts.PromptConfig
 type PromptsetConfigs map[string]prompts.PromptsetConfig
+type ResourceConfigs map[string]mcpresources.ResourceConfig
 
-func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, AuthServiceConfigs, EmbeddingModelConfigs, ToolConfigs, ToolsetConfigs, PromptConfigs, error) {
+func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, AuthServiceConfigs, EmbeddingModelConfigs, ToolConfigs, ToolsetConfigs, PromptConfigs, ResourceConfigs, error) {
 	// prepare configs map
 	var sourceConfigs SourceConfigs
 	var authServiceConfigs AuthServiceConfigs
@@ -145,6 +173,7 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 	var toolConfigs ToolConfigs
 	var toolsetConfigs ToolsetConfigs
 	var promptConfigs PromptConfigs
+	var resourceConfigs ResourceConfigs
 	// promptset configs is not yet supported
 
 	decoder := yaml.NewDecoder(bytes.NewReader(raw))
@@ -155,15 +184,15 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 			if err == io.EOF {
 				break
 			}
-			return nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to decode YAML document: %w", err)
+			return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to decode YAML document: %w", err)
 		}
 		var kind, name string
 		var ok bool
 		if kind, ok = resource["kind"].(string); !ok {
-			return nil, nil, nil, nil, nil, nil, fmt.Errorf("missing 'kind' field or it is not a string: %v", resource)
+			return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("missing 'kind' field or it is not a string: %v", resource)
 		}
 		if name, ok = resource["name"].(string); !ok {
-			return nil, nil, nil, nil, nil, nil, fmt.Errorf("missing 'name' field or it is not a string")
+			return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("missing 'name' field or it is not a string")
 		}
 		// remove 'kind' from map for strict unmarshaling
 		delete(resource, "kind")
@@ -172,7 +201,7 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 		case "sources":
 			c, err := UnmarshalYAMLSourceConfig(ctx, name, resource)
 			if err != nil {
-				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
 			}
 			if sourceConfigs == nil {
 				sourceConfigs = make(SourceConfigs)
@@ -181,7 +210,7 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 		case "authServices":
 			c, err := UnmarshalYAMLAuthServiceConfig(ctx, name, resource)
 			if err != nil {
-				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
 			}
 			if authServiceConfigs == nil {
 				authServiceConfigs = make(AuthServiceConfigs)
@@ -190,7 +219,7 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 		case "tools":
 			c, err := UnmarshalYAMLToolConfig(ctx, name, resource)
 			if err != nil {
-				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
 			}
 			if toolConfigs == nil {
 				toolConfigs = make(ToolConfigs)
@@ -199,7 +228,7 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 		case "toolsets":
 			c, err := UnmarshalYAMLToolsetConfig(ctx, name, resource)
 			if err != nil {
-				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
 			}
 			if toolsetConfigs == nil {
 				toolsetConfigs = make(ToolsetConfigs)
@@ -208,7 +237,7 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 		case "embeddingModels":
 			c, err := UnmarshalYAMLEmbeddingModelConfig(ctx, name, resource)
 			if err != nil {
-				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
 			}
 			if embeddingModelConfigs == nil {
 				embeddingModelConfigs = make(EmbeddingModelConfigs)
@@ -217,17 +246,26 @@ func UnmarshalResourceConfig(ctx context.Context, raw []byte) (SourceConfigs, Au
 		case "prompts":
 			c, err := UnmarshalYAMLPromptConfig(ctx, name, resource)
 			if err != nil {
-				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
 			}
 			if promptConfigs == nil {
 				promptConfigs = make(PromptConfigs)
 			}
 			promptConfigs[name] = c
+		case "resources":
+			c, err := UnmarshalYAMLMcpResourceConfig(ctx, name, resource)
+			if err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %s", kind, err)
+			}
+			if resourceConfigs == nil {
+				resourceConfigs = make(ResourceConfigs)
+			}
+			resourceConfigs[name] = c
 		default:
-			return nil, nil, nil, nil, nil, nil, fmt.Errorf("invalid kind %s", kind)
+			return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("invalid kind %s", kind)
 		}
 	}
-	return sourceConfigs, authServiceConfigs, embeddingModelConfigs, toolConfigs, toolsetConfigs, promptConfigs, nil
+	return sourceConfigs, authServiceConfigs, embeddingModelConfigs, toolConfigs, toolsetConfigs, promptConfigs, resourceConfigs, nil
 }
 
 func UnmarshalYAMLSourceConfig(ctx context.Context, name string, r map[string]any) (sources.SourceConfig, error) {
@@ -235,6 +273,10 @@ func UnmarshalYAMLSourceConfig(ctx context.Context, name string, r map[string]an
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
+	opts, err := unmarshalSourceOptions(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options for source %q: %w", name, err)
+	}
 	dec, err := util.NewStrictDecoder(r)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %w", err)
@@ -243,26 +285,50 @@ func UnmarshalYAMLSourceConfig(ctx context.Context, name string, r map[string]an
 	if err != nil {
 		return nil, err
 	}
+	if !opts.IsZero() {
+		return sources.OptionsSourceConfig{SourceConfig: sourceConfig, SourceOptions: opts}, nil
+	}
 	return sourceConfig, nil
 }
 
+// sourceOptionKeys are the keys of the source options, which can be set on any
+// source.
+var sourceOptionKeys = []string{"lazyInit", "retry"}
+
+// unmarshalSourceOptions decodes the source options and removes them from the
+// raw source config.
+func unmarshalSourceOptions(ctx context.Context, r map[string]any) (sources.SourceOptions, error) {
+	var opts sources.SourceOptions
+	raw := make(map[string]any)
+	for _, k := range sourceOptionKeys {
+		if v, ok := r[k]; ok {
+			raw[k] = v
+			delete(r, k)
+		}
+	}
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return opts, fmt.Errorf("error creating decoder: %s", err)
+	}
+	if err := dec.DecodeContext(ctx, &opts); err != nil {
+		return opts, fmt.Errorf("unable to parse source options: %s", err)
+	}
+	if err := opts.Retry.Validate(); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
 func UnmarshalYAMLAuthServiceConfig(ctx context.Context, name string, r map[string]any) (auth.AuthServiceConfig, error) {
 	resourceType, ok := r["type"].(string)
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
-	if resourceType != google.AuthServiceType {
-		return nil, fmt.Errorf("%s is not a valid type of auth service", resourceType)
-	}
 	dec, err := util.NewStrictDecoder(r)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
-	actual := google.Config{Name: name}
-	if err := dec.DecodeContext(ctx, &actual); err != nil {
-		return nil, fmt.Errorf("unable to parse as %s: %w", name, err)
-	}
-	return actual, nil
+	return auth.DecodeConfig(ctx, resourceType, name, dec)
 }
 
 func UnmarshalYAMLEmbeddingModelConfig(ctx context.Context, name string, r map[string]any) (embeddingmodels.EmbeddingModelConfig, error) {
@@ -270,18 +336,50 @@ func UnmarshalYAMLEmbeddingModelConfig(ctx context.Context, name string, r map[s
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
-	if resourceType != gemini.EmbeddingModelType {
-		return nil, fmt.Errorf("%s is not a valid type of embedding model", resourceType)
+	opts, err := unmarshalEmbeddingModelOptions(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options for embedding model %q: %w", name, err)
 	}
 	dec, err := util.NewStrictDecoder(r)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
-	actual := gemini.Config{Name: name}
-	if err := dec.DecodeContext(ctx, &actual); err != nil {
-		return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+	embeddingModelConfig, err := embeddingmodels.DecodeConfig(ctx, resourceType, name, dec)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.IsZero() {
+		return embeddingmodels.OptionsEmbeddingModelConfig{EmbeddingModelConfig: embeddingModelConfig, ModelOptions: opts}, nil
+	}
+	return embeddingModelConfig, nil
+}
+
+// embeddingModelOptionKeys are the keys of the model options, which can be set
+// on any embedding model.
+var embeddingModelOptionKeys = []string{"cache", "batching"}
+
+// unmarshalEmbeddingModelOptions decodes the model options and removes them
+// from the raw embedding model config.
+func unmarshalEmbeddingModelOptions(ctx context.Context, r map[string]any) (embeddingmodels.ModelOptions, error) {
+	var opts embeddingmodels.ModelOptions
+	raw := make(map[string]any)
+	for _, k := range embeddingModelOptionKeys {
+		if v, ok := r[k]; ok {
+			raw[k] = v
+			delete(r, k)
+		}
+	}
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return opts, fmt.Errorf("error creating decoder: %s", err)
+	}
+	if err := dec.DecodeContext(ctx, &opts); err != nil {
+		return opts, fmt.Errorf("unable to parse embedding model options: %s", err)
+	}
+	if err := opts.Validate(); err != nil {
+		return opts, err
 	}
-	return actual, nil
+	return opts, nil
 }
 
 func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any) (tools.ToolConfig, error) {
@@ -298,6 +396,12 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 		r["authRequired"] = []string{}
 	}
 
+	// tool options are enforced by the server and not part of the tool config
+	opts, err := unmarshalToolOptions(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("tool %q config error: %w", name, err)
+	}
+
 	// validify parameter references
 	if rawParams, ok := r["parameters"]; ok {
 		if paramsList, ok := rawParams.([]any); ok {
@@ -344,16 +448,62 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 	if err != nil {
 		return nil, err
 	}
+	if !opts.IsZero() {
+		return tools.OptionsToolConfig{ToolConfig: toolCfg, ToolOptions: opts}, nil
+	}
 	return toolCfg, nil
 }
 
+// toolOptionKeys are the keys of the tool options, which can be set on any
+// tool.
+var toolOptionKeys = []string{"policies", "maxRows", "maxResultBytes", "invocationTimeout", "outputSchema"}
+
+// unmarshalToolOptions decodes the tool options and removes them from the
+// raw tool config.
+func unmarshalToolOptions(ctx context.Context, r map[string]any) (tools.ToolOptions, error) {
+	var opts tools.ToolOptions
+	raw := make(map[string]any)
+	for _, k := range toolOptionKeys {
+		if v, ok := r[k]; ok {
+			raw[k] = v
+			delete(r, k)
+		}
+	}
+	policies, err := unmarshalPolicies(ctx, raw["policies"])
+	if err != nil {
+		return opts, err
+	}
+	delete(raw, "policies")
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return opts, fmt.Errorf("error creating decoder: %s", err)
+	}
+	if err := dec.DecodeContext(ctx, &opts); err != nil {
+		return opts, fmt.Errorf("unable to parse tool options: %s", err)
+	}
+	opts.Policies = policies
+	if opts.InvocationTimeout != "" {
+		if d, err := time.ParseDuration(opts.InvocationTimeout); err != nil || d <= 0 {
+			return opts, fmt.Errorf("invalid invocationTimeout %q: must be a positive duration such as \"30s\"", opts.InvocationTimeout)
+		}
+	}
+	return opts, nil
+}
+
 func UnmarshalYAMLToolsetConfig(ctx context.Context, name string, r map[string]any) (tools.ToolsetConfig, error) {
 	var toolsetConfig tools.ToolsetConfig
 	toolList, ok := r["tools"].([]any)
 	if !ok {
 		return toolsetConfig, fmt.Errorf("tools is missing or not a list of strings: %v", r)
 	}
+	policies, err := unmarshalPolicies(ctx, r["policies"])
+	if err != nil {
+		return toolsetConfig, fmt.Errorf("toolset %q config error: %w", name, err)
+	}
 	justTools := map[string]any{"tools": toolList}
+	if resourceList, ok := r["resources"]; ok {
+		justTools["resources"] = resourceList
+	}
 	dec, err := util.NewStrictDecoder(justTools)
 	if err != nil {
 		return toolsetConfig, fmt.Errorf("error creating decoder: %s", err)
@@ -362,7 +512,29 @@ func UnmarshalYAMLToolsetConfig(ctx context.Context, name string, r map[string]a
 	if err := dec.DecodeContext(ctx, &raw); err != nil {
 		return toolsetConfig, fmt.Errorf("unable to unmarshal tools: %s", err)
 	}
-	return tools.ToolsetConfig{Name: name, ToolNames: raw["tools"]}, nil
+	return tools.ToolsetConfig{Name: name, ToolNames: raw["tools"], Policies: policies, ResourceNames: raw["resources"]}, nil
+}
+
+// unmarshalPolicies decodes and validates the authorization policies of a
+// tool or toolset.
+func unmarshalPolicies(ctx context.Context, raw any) ([]tools.Policy, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error creating decoder: %s", err)
+	}
+	var policies []tools.Policy
+	if err := dec.DecodeContext(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("unable to parse policies: %s", err)
+	}
+	for _, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
 }
 
 func UnmarshalYAMLPromptConfig(ctx context.Context, name string, r map[string]any) (prompts.PromptConfig, error) {
@@ -389,6 +561,22 @@ func UnmarshalYAMLPromptConfig(ctx context.Context, name string, r map[string]an
 	return promptCfg, nil
 }
 
+func UnmarshalYAMLMcpResourceConfig(ctx context.Context, name string, r map[string]any) (mcpresources.ResourceConfig, error) {
+	resourceType, ok := r["type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing 'type' field or it is not a string")
+	}
+	dec, err := util.NewStrictDecoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("error creating decoder: %s", err)
+	}
+	resourceCfg, err := mcpresources.DecodeConfig(ctx, resourceType, name, dec)
+	if err != nil {
+		return nil, err
+	}
+	return resourceCfg, nil
+}
+
 // Tools naming validation is added in the MCP v2025-11-25, but we'll be
 // implementing it across Toolbox
 // Tool names SHOULD be between 1 and 128 characters in length (inclusive).
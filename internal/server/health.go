@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// defaultReadinessTimeout is the timeout of the health check of each source if
+// none is configured.
+const defaultReadinessTimeout = 5 * time.Second
+
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+
+	sourceStatusUp      = "up"
+	sourceStatusDown    = "down"
+	sourceStatusUnknown = "unknown"
+)
+
+// sourceHealth is the result of the health check of a source.
+type sourceHealth struct {
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	// LatencyMs is the duration of the health check in milliseconds, it is
+	// omitted for sources that do not support health checks.
+	LatencyMs *float64 `json:"latencyMs,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type readinessResponse struct {
+	Status  string                  `json:"status"`
+	Sources map[string]sourceHealth `json:"sources"`
+}
+
+// healthzHandler reports that the server is alive.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, map[string]string{"status": healthStatusOK})
+}
+
+// readyzHandler checks the connectivity of every source and reports whether
+// the server is ready to serve traffic. The server is unready if any critical
+// source is down.
+func readyzHandler(s *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := s.checkSources(r.Context())
+		if res.Status != healthStatusOK {
+			render.Status(r, http.StatusServiceUnavailable)
+		}
+		render.JSON(w, r, res)
+	}
+}
+
+// checkSources runs the health checks of the sources concurrently. Sources
+// that do not implement sources.HealthChecker are reported with an unknown
+// status and do not affect readiness.
+func (s *Server) checkSources(ctx context.Context) readinessResponse {
+	timeout := s.readinessTimeout
+	if timeout <= 0 {
+		timeout = defaultReadinessTimeout
+	}
+
+	res := readinessResponse{Status: healthStatusOK, Sources: map[string]sourceHealth{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, src := range s.ResourceMgr.GetSourcesMap() {
+		_, optional := s.optionalSources[name]
+		h := sourceHealth{Type: src.SourceType(), Status: sourceStatusUnknown, Critical: !optional}
+		checker, ok := src.(sources.HealthChecker)
+		if !ok {
+			mu.Lock()
+			res.Sources[name] = h
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := checker.Ping(pingCtx)
+			latency := float64(time.Since(start).Microseconds()) / 1000
+			h.LatencyMs = &latency
+			h.Status = sourceStatusUp
+			if err != nil {
+				h.Status = sourceStatusDown
+				h.Error = err.Error()
+				s.logger.WarnContext(ctx, fmt.Sprintf("health check of source %q failed: %s", name, err))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			res.Sources[name] = h
+			if err != nil && h.Critical {
+				res.Status = healthStatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+	return res
+}
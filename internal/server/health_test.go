@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// pingSource is a source whose health check returns err.
+type pingSource struct {
+	err error
+}
+
+func (pingSource) SourceType() string             { return "mock-ping" }
+func (pingSource) ToConfig() sources.SourceConfig { return nil }
+func (s pingSource) Ping(_ context.Context) error { return s.err }
+
+// noPingSource is a source that does not support health checks.
+type noPingSource struct{}
+
+func (noPingSource) SourceType() string             { return "mock" }
+func (noPingSource) ToConfig() sources.SourceConfig { return nil }
+
+func TestHealthz(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	healthzHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d, want %d", w.Code, http.StatusOK)
+	}
+	if got, want := w.Body.String(), "{\"status\":\"ok\"}\n"; got != want {
+		t.Fatalf("unexpected body: got %q, want %q", got, want)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+
+	tcs := []struct {
+		name            string
+		sources         map[string]sources.Source
+		optionalSources map[string]struct{}
+		wantCode        int
+		want            readinessResponse
+	}{
+		{
+			name: "all sources up",
+			sources: map[string]sources.Source{
+				"my-db":    pingSource{},
+				"my-other": noPingSource{},
+			},
+			wantCode: http.StatusOK,
+			want: readinessResponse{
+				Status: "ok",
+				Sources: map[string]sourceHealth{
+					"my-db":    {Type: "mock-ping", Status: "up", Critical: true},
+					"my-other": {Type: "mock", Status: "unknown", Critical: true},
+				},
+			},
+		},
+		{
+			name: "critical source down",
+			sources: map[string]sources.Source{
+				"my-db":   pingSource{},
+				"my-down": pingSource{err: fmt.Errorf("connection refused")},
+			},
+			wantCode: http.StatusServiceUnavailable,
+			want: readinessResponse{
+				Status: "unavailable",
+				Sources: map[string]sourceHealth{
+					"my-db":   {Type: "mock-ping", Status: "up", Critical: true},
+					"my-down": {Type: "mock-ping", Status: "down", Critical: true, Error: "connection refused"},
+				},
+			},
+		},
+		{
+			name: "optional source down",
+			sources: map[string]sources.Source{
+				"my-db":   pingSource{},
+				"my-down": pingSource{err: fmt.Errorf("connection refused")},
+			},
+			optionalSources: map[string]struct{}{"my-down": {}},
+			wantCode:        http.StatusOK,
+			want: readinessResponse{
+				Status: "ok",
+				Sources: map[string]sourceHealth{
+					"my-db":   {Type: "mock-ping", Status: "up", Critical: true},
+					"my-down": {Type: "mock-ping", Status: "down", Critical: false, Error: "connection refused"},
+				},
+			},
+		},
+		{
+			name:     "no sources",
+			wantCode: http.StatusOK,
+			want:     readinessResponse{Status: "ok", Sources: map[string]sourceHealth{}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{
+				logger:          testLogger,
+				optionalSources: tc.optionalSources,
+				ResourceMgr:     resources.NewResourceManager(tc.sources, nil, nil, nil, nil, nil, nil, nil),
+			}
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+			readyzHandler(s)(w, req)
+
+			if w.Code != tc.wantCode {
+				t.Fatalf("unexpected status code: got %d, want %d", w.Code, tc.wantCode)
+			}
+			var got readinessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unable to unmarshal response: %s", err)
+			}
+			for name, h := range got.Sources {
+				if _, ok := tc.sources[name].(sources.HealthChecker); ok && h.LatencyMs == nil {
+					t.Errorf("latency of source %q is missing", name)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(sourceHealth{}, "LatencyMs")); diff != "" {
+				t.Fatalf("unexpected response (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
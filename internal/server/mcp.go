@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -31,6 +33,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
@@ -43,11 +46,32 @@ import (
 )
 
 type sseSession struct {
-	writer     http.ResponseWriter
-	flusher    http.Flusher
-	done       chan struct{}
-	eventQueue chan string
-	lastActive time.Time
+	toolsetName   string
+	promptsetName string
+	writer        http.ResponseWriter
+	flusher       http.Flusher
+	done          chan struct{}
+	eventQueue    chan string
+	lastActive    time.Time
+}
+
+// queue adds a message to the event queue of the session.
+func (session *sseSession) queue(ctx context.Context, s *Server, message any) error {
+	eventData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message to JSON: %w", err)
+	}
+	select {
+	case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
+		s.logger.DebugContext(ctx, "event queue successful")
+		return nil
+	case <-session.done:
+		s.logger.DebugContext(ctx, "session is close")
+		return fmt.Errorf("sse session is closed")
+	default:
+		s.logger.DebugContext(ctx, "unable to add to event queue")
+		return fmt.Errorf("unable to add to event queue")
+	}
 }
 
 // sseManager manages and control access to sse sessions
@@ -80,6 +104,17 @@ func (m *sseManager) add(id string, session *sseSession) {
 	session.lastActive = time.Now()
 }
 
+// list returns all active sse sessions.
+func (m *sseManager) list() []*sseSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sessions := make([]*sseSession, 0, len(m.sseSessions))
+	for _, session := range m.sseSessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
 func (m *sseManager) remove(id string) {
 	m.mu.Lock()
 	delete(m.sseSessions, id)
@@ -110,15 +145,316 @@ func (m *sseManager) cleanupRoutine(ctx context.Context) {
 	}
 }
 
+// maxSessionEvents is the number of events kept by a session for resumption.
+const maxSessionEvents = 100
+
+// sessionEvent is a server-initiated message sent on the GET stream of a
+// streamable HTTP session.
+type sessionEvent struct {
+	id   uint64
+	data []byte
+}
+
+// httpSession is the server-side state of a streamable HTTP session, which is
+// identified by the `Mcp-Session-Id` header.
+type httpSession struct {
+	id              string
+	protocolVersion string
+	toolsetName     string
+	promptsetName   string
+
+	mu          sync.Mutex
+	events      []sessionEvent
+	lastEventId uint64
+	delivered   uint64
+	lastActive  time.Time
+	// notify signals the GET stream that new events are available
+	notify chan struct{}
+	// streamDone is closed to end the current GET stream, if any
+	streamDone chan struct{}
+	// done is closed once the session is terminated
+	done chan struct{}
+}
+
+func newHttpSession(id, protocolVersion, toolsetName, promptsetName string) *httpSession {
+	return &httpSession{
+		id:              id,
+		protocolVersion: protocolVersion,
+		toolsetName:     toolsetName,
+		promptsetName:   promptsetName,
+		lastActive:      time.Now(),
+		notify:          make(chan struct{}, 1),
+		done:            make(chan struct{}),
+	}
+}
+
+// send queues a server-initiated message to be sent on the GET stream of the
+// session. Only the latest events are kept to allow clients to resume.
+func (session *httpSession) send(_ context.Context, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message to JSON: %w", err)
+	}
+
+	session.mu.Lock()
+	session.lastEventId++
+	session.events = append(session.events, sessionEvent{id: session.lastEventId, data: data})
+	if len(session.events) > maxSessionEvents {
+		session.events = session.events[len(session.events)-maxSessionEvents:]
+	}
+	session.mu.Unlock()
+
+	select {
+	case session.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// eventsAfter returns the events with an id greater than the given id, and
+// marks them as delivered.
+func (session *httpSession) eventsAfter(id uint64) []sessionEvent {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	var events []sessionEvent
+	for _, e := range session.events {
+		if e.id > id {
+			events = append(events, e)
+		}
+	}
+	if len(events) > 0 && events[len(events)-1].id > session.delivered {
+		session.delivered = events[len(events)-1].id
+	}
+	return events
+}
+
+// openStream ends the current GET stream of the session, if any, and returns
+// the channel that is closed when the new stream should end. It also returns
+// the id of the last delivered event.
+func (session *httpSession) openStream() (chan struct{}, uint64) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.streamDone != nil {
+		close(session.streamDone)
+	}
+	session.streamDone = make(chan struct{})
+	return session.streamDone, session.delivered
+}
+
+// closeStream marks the GET stream as closed, unless it was already replaced.
+func (session *httpSession) closeStream(streamDone chan struct{}) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.streamDone == streamDone {
+		session.streamDone = nil
+	}
+}
+
+// httpSessionManager manages and control access to streamable HTTP sessions
+type httpSessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+}
+
+func newHttpSessionManager(ctx context.Context) *httpSessionManager {
+	m := &httpSessionManager{
+		mu:       sync.Mutex{},
+		sessions: make(map[string]*httpSession),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+func (m *httpSessionManager) get(id string) (*httpSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if ok {
+		session.mu.Lock()
+		session.lastActive = time.Now()
+		session.mu.Unlock()
+	}
+	return session, ok
+}
+
+func (m *httpSessionManager) add(session *httpSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.id] = session
+}
+
+// remove terminates a session. It returns false if the session does not exist.
+func (m *httpSessionManager) remove(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if !ok {
+		return false
+	}
+	delete(m.sessions, id)
+	close(session.done)
+	return true
+}
+
+// list returns all active sessions.
+func (m *httpSessionManager) list() []*httpSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sessions := make([]*httpSession, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+func (m *httpSessionManager) cleanupRoutine(ctx context.Context) {
+	timeout := 10 * time.Minute
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				now := time.Now()
+				for id, session := range m.sessions {
+					session.mu.Lock()
+					// sessions with an open stream are still in use
+					expired := session.streamDone == nil && now.Sub(session.lastActive) > timeout
+					session.mu.Unlock()
+					if expired {
+						delete(m.sessions, id)
+						close(session.done)
+					}
+				}
+			}()
+		}
+	}
+}
+
+// notifyListChanged sends `notifications/tools/list_changed` and
+// `notifications/prompts/list_changed` to the connected MCP clients whose
+// toolset or promptset changed.
+func (s *Server) notifyListChanged(ctx context.Context, changedToolsets, changedPromptsets map[string]bool) {
+	toolsChanged := mcputil.NewListChangedNotification(mcputil.NOTIFICATIONS_TOOLS_LIST_CHANGED)
+	promptsChanged := mcputil.NewListChangedNotification(mcputil.NOTIFICATIONS_PROMPTS_LIST_CHANGED)
+	notify := func(send notifyFunc, toolsetName, promptsetName string) {
+		if changedToolsets[toolsetName] {
+			if err := send(ctx, toolsChanged); err != nil {
+				s.logger.DebugContext(ctx, fmt.Sprintf("unable to send tools list changed notification: %s", err))
+			}
+		}
+		if changedPromptsets[promptsetName] {
+			if err := send(ctx, promptsChanged); err != nil {
+				s.logger.DebugContext(ctx, fmt.Sprintf("unable to send prompts list changed notification: %s", err))
+			}
+		}
+	}
+
+	for _, session := range s.httpSessions.list() {
+		notify(session.send, session.toolsetName, session.promptsetName)
+	}
+	for _, session := range s.sseManager.list() {
+		notify(func(ctx context.Context, message any) error {
+			return session.queue(ctx, s, message)
+		}, session.toolsetName, session.promptsetName)
+	}
+	s.stdioSessions.Range(func(_, value any) bool {
+		session := value.(*stdioSession)
+		notify(session.write, session.toolsetName, session.promptsetName)
+		return true
+	})
+}
+
+// errRequestCancelled is the cause of the context cancellation of requests
+// cancelled by the client through `notifications/cancelled`.
+var errRequestCancelled = errors.New("request cancelled by client")
+
+// inflightRequests keeps track of requests that are being processed, so that
+// they can be cancelled by the client. The zero value is ready to use.
+type inflightRequests struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+// inflightKey returns the key of a request. Request ids are only unique within
+// a session, and string and number ids are distinct.
+func inflightKey(sessionId string, id jsonrpc.RequestId) string {
+	return fmt.Sprintf("%s/%T/%v", sessionId, id, id)
+}
+
+// add registers the cancel function of a request. Requests without a session
+// are not tracked, since their ids could collide with the ids of requests from
+// other clients, and they cannot be cancelled.
+func (m *inflightRequests) add(sessionId string, id jsonrpc.RequestId, cancel context.CancelCauseFunc) {
+	if sessionId == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancels == nil {
+		m.cancels = make(map[string]context.CancelCauseFunc)
+	}
+	m.cancels[inflightKey(sessionId, id)] = cancel
+}
+
+func (m *inflightRequests) remove(sessionId string, id jsonrpc.RequestId) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.cancels, inflightKey(sessionId, id))
+}
+
+// cancel cancels the context of an in-flight request. Unknown or already
+// completed requests are ignored.
+func (m *inflightRequests) cancel(sessionId string, id jsonrpc.RequestId) bool {
+	if sessionId == "" {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cancel, ok := m.cancels[inflightKey(sessionId, id)]
+	if ok {
+		cancel(errRequestCancelled)
+	}
+	return ok
+}
+
+// cancelSession cancels the contexts of all in-flight requests of a session.
+func (m *inflightRequests) cancelSession(sessionId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prefix := sessionId + "/"
+	for key, cancel := range m.cancels {
+		if strings.HasPrefix(key, prefix) {
+			cancel(errRequestCancelled)
+		}
+	}
+}
+
+// notifyFunc sends a server-initiated message, such as a progress
+// notification, to the client.
+type notifyFunc func(ctx context.Context, message any) error
+
 type stdioSession struct {
+	id       string
 	protocol string
-	server   *Server
-	reader   *bufio.Reader
-	writer   io.Writer
+	// toolsetName and promptsetName select the tools and prompts listed to
+	// the client, the default toolset and promptset if empty
+	toolsetName   string
+	promptsetName string
+	server        *Server
+	reader        *bufio.Reader
+	writer        io.Writer
+	writeMu       sync.Mutex
 }
 
 func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession {
 	stdioSession := &stdioSession{
+		id:     uuid.New().String(),
 		server: s,
 		reader: bufio.NewReader(stdin),
 		writer: stdout,
@@ -127,15 +463,28 @@ func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession
 }
 
 func (s *stdioSession) Start(ctx context.Context) error {
+	// register the session to receive list changed notifications
+	s.server.stdioSessions.Store(s.id, s)
+	defer s.server.stdioSessions.Delete(s.id)
 	return s.readInputStream(ctx)
 }
 
 // readInputStream reads requests/notifications from MCP clients through stdin
 func (s *stdioSession) readInputStream(ctx context.Context) error {
+	// wait for in-flight requests to finish writing their responses
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	errCh := make(chan error, 1)
 	for {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
+		select {
+		case err := <-errCh:
+			return err
+		default:
+		}
 		line, err := s.readLine(ctx)
 		if err != nil {
 			if err == io.EOF {
@@ -143,24 +492,53 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 			}
 			return err
 		}
-		v, res, err := processMcpMessage(ctx, []byte(line), s.server, s.protocol, "", "", nil)
-		if err != nil {
-			// errors during the processing of message will generate a valid MCP Error response.
-			// server can continue to run.
-			s.server.logger.ErrorContext(ctx, err.Error())
-		}
-		if v != "" {
-			s.protocol = v
+
+		// Requests other than `initialize`, and batches, are processed concurrently so that
+		// the client is able to cancel them while they are in progress.
+		// Notifications and `initialize` are processed in order since later
+		// messages depend on them.
+		var baseMessage jsonrpc.BaseMessage
+		isRequest := json.Unmarshal([]byte(line), &baseMessage) == nil && baseMessage.Id != nil && baseMessage.Method != mcputil.INITIALIZE
+		if isRequest || isBatchMessage([]byte(line)) {
+			protocol := s.protocol
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := s.processLine(ctx, line, protocol); err != nil {
+					select {
+					case errCh <- err:
+					default:
+					}
+				}
+			}()
+			continue
 		}
-		// no responses for notifications
-		if res != nil {
-			if err = s.write(ctx, res); err != nil {
-				return err
-			}
+		if err := s.processLine(ctx, line, s.protocol); err != nil {
+			return err
 		}
 	}
 }
 
+// processLine processes a single message and writes its response, if any.
+func (s *stdioSession) processLine(ctx context.Context, line, protocol string) error {
+	v, res, err := processMcpMessage(ctx, []byte(line), s.server, protocol, s.toolsetName, s.promptsetName, nil, s.id, s.write)
+	if errors.Is(err, errRequestCancelled) {
+		s.server.logger.DebugContext(ctx, err.Error())
+	} else if err != nil {
+		// errors during the processing of message will generate a valid MCP Error response.
+		// server can continue to run.
+		s.server.logger.ErrorContext(ctx, err.Error())
+	}
+	if v != "" {
+		s.protocol = v
+	}
+	// no responses for notifications
+	if res != nil {
+		return s.write(ctx, res)
+	}
+	return nil
+}
+
 // readLine process each line within the input stream.
 func (s *stdioSession) readLine(ctx context.Context) (string, error) {
 	readChan := make(chan string, 1)
@@ -211,10 +589,65 @@ func (s *stdioSession) write(_ context.Context, response any) error {
 		return fmt.Errorf("failed to marshal response to JSON: %w", err)
 	}
 
+	// responses and notifications of concurrent requests must not interleave
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	_, err = fmt.Fprintf(s.writer, "%s\n", res)
 	return err
 }
 
+// streamWriter switches a streamable HTTP response to an SSE stream when the
+// first server-initiated message is sent, so that notifications can be sent
+// to the client before the response.
+type streamWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	started bool
+}
+
+// newStreamWriter returns a streamWriter if the client accepts SSE streams in
+// the response to a POST request.
+func newStreamWriter(w http.ResponseWriter, r *http.Request) (*streamWriter, bool) {
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return nil, false
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+	return &streamWriter{w: w, flusher: flusher}, true
+}
+
+// send writes a message to the SSE stream, starting the stream if needed.
+func (sw *streamWriter) send(_ context.Context, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message to JSON: %w", err)
+	}
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if !sw.started {
+		sw.w.Header().Set("Content-Type", "text/event-stream")
+		sw.w.Header().Set("Cache-Control", "no-cache")
+		sw.w.WriteHeader(http.StatusOK)
+		sw.started = true
+	}
+	if _, err := fmt.Fprintf(sw.w, "event: message\ndata: %s\n\n", data); err != nil {
+		return err
+	}
+	sw.flusher.Flush()
+	return nil
+}
+
+// isStarted returns true if the response has been switched to an SSE stream.
+func (sw *streamWriter) isStarted() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.started
+}
+
 // mcpRouter creates a router that represents the routes under /mcp
 func mcpRouter(s *Server) (chi.Router, error) {
 	r := chi.NewRouter()
@@ -224,30 +657,54 @@ func mcpRouter(s *Server) (chi.Router, error) {
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 
 	r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-	r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) { httpStreamHandler(s, w, r) })
 	r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-	r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+	r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
 
 	r.Route("/{toolsetName}", func(r chi.Router) {
 		r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { httpStreamHandler(s, w, r) })
 		r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
+
+		r.Route("/{promptsetName}", func(r chi.Router) {
+			r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) { httpStreamHandler(s, w, r) })
+			r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
+			r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
+		})
 	})
 
 	return r, nil
 }
 
+// mcpSetNames returns the names of the toolset and promptset selected by the
+// request, with the /mcp/{toolsetName}/{promptsetName} path or with the
+// `toolset` and `promptset` query parameters. Empty names select the default
+// toolset and promptset.
+func mcpSetNames(r *http.Request) (string, string) {
+	toolsetName := chi.URLParam(r, "toolsetName")
+	if toolsetName == "" {
+		toolsetName = r.URL.Query().Get("toolset")
+	}
+	promptsetName := chi.URLParam(r, "promptsetName")
+	if promptsetName == "" {
+		promptsetName = r.URL.Query().Get("promptset")
+	}
+	return toolsetName, promptsetName
+}
+
 // sseHandler handles sse initialization and message.
 func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/mcp/sse")
 	r = r.WithContext(ctx)
 
 	sessionId := uuid.New().String()
-	toolsetName := chi.URLParam(r, "toolsetName")
-	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
+	toolsetName, promptsetName := mcpSetNames(r)
+	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s, promptset name: %s", toolsetName, promptsetName))
 	span.SetAttributes(attribute.String("session_id", sessionId))
 	span.SetAttributes(attribute.String("toolset_name", toolsetName))
+	span.SetAttributes(attribute.String("promptset_name", promptsetName))
 
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -280,10 +737,12 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 	}
 	session := &sseSession{
-		writer:     w,
-		flusher:    flusher,
-		done:       make(chan struct{}),
-		eventQueue: make(chan string, 100),
+		toolsetName:   toolsetName,
+		promptsetName: promptsetName,
+		writer:        w,
+		flusher:       flusher,
+		done:          make(chan struct{}),
+		eventQueue:    make(chan string, 100),
 	}
 	s.sseManager.add(sessionId, session)
 	defer s.sseManager.remove(sessionId)
@@ -303,7 +762,11 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	if toolsetName != "" {
 		toolsetURL = fmt.Sprintf("/%s", toolsetName)
 	}
-	messageEndpoint := fmt.Sprintf("%s://%s/mcp%s?sessionId=%s", proto, r.Host, toolsetURL, sessionId)
+	query := url.Values{"sessionId": {sessionId}}
+	if promptsetName != "" {
+		query.Set("promptset", promptsetName)
+	}
+	messageEndpoint := fmt.Sprintf("%s://%s/mcp%s?%s", proto, r.Host, toolsetURL, query.Encode())
 	s.logger.DebugContext(ctx, fmt.Sprintf("sending endpoint event: %s", messageEndpoint))
 	fmt.Fprintf(w, "event: endpoint\ndata: %s\n\n", messageEndpoint)
 	flusher.Flush()
@@ -325,11 +788,108 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// methodNotAllowed handles all mcp messages.
-func methodNotAllowed(s *Server, w http.ResponseWriter, r *http.Request) {
-	err := fmt.Errorf("toolbox does not support streaming in streamable HTTP transport")
-	s.logger.DebugContext(r.Context(), err.Error())
-	_ = render.Render(w, r, newErrResponse(err, http.StatusMethodNotAllowed))
+// getHttpSession returns the streamable HTTP session of the request. It renders
+// an error response and returns false if the session is missing or unknown.
+func getHttpSession(s *Server, w http.ResponseWriter, r *http.Request) (*httpSession, bool) {
+	sessionId := r.Header.Get("Mcp-Session-Id")
+	if sessionId == "" {
+		err := fmt.Errorf("missing Mcp-Session-Id header")
+		s.logger.DebugContext(r.Context(), err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return nil, false
+	}
+	session, ok := s.httpSessions.get(sessionId)
+	if !ok {
+		// clients must start a new session by sending a new initialize request
+		err := fmt.Errorf("session %q not found", sessionId)
+		s.logger.DebugContext(r.Context(), err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return nil, false
+	}
+	return session, true
+}
+
+// httpStreamHandler opens a SSE stream for the server to send messages to the
+// client of a streamable HTTP session. Clients can resume a broken stream by
+// sending the `Last-Event-ID` header.
+func httpStreamHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/mcp/stream")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		err := fmt.Errorf("client must accept text/event-stream")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotAcceptable))
+		return
+	}
+	session, ok := getHttpSession(s, w, r)
+	if !ok {
+		return
+	}
+	span.SetAttributes(attribute.String("session_id", session.id))
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := fmt.Errorf("unable to retrieve flusher for sse")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	streamDone, lastEventId := session.openStream()
+	defer session.closeStream(streamDone)
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid Last-Event-ID header: %w", err)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+		lastEventId = id
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		for _, event := range session.eventsAfter(lastEventId) {
+			fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", event.id, event.data)
+			s.logger.DebugContext(ctx, fmt.Sprintf("sending event %d: %s", event.id, event.data))
+			lastEventId = event.id
+		}
+		flusher.Flush()
+
+		select {
+		case <-session.notify:
+		case <-streamDone:
+			s.logger.DebugContext(ctx, "stream replaced by a new stream")
+			return
+		case <-session.done:
+			s.logger.DebugContext(ctx, "session terminated")
+			return
+		case <-ctx.Done():
+			s.logger.DebugContext(ctx, "client disconnected")
+			return
+		}
+	}
+}
+
+// deleteSessionHandler terminates a streamable HTTP session.
+func deleteSessionHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	session, ok := getHttpSession(s, w, r)
+	if !ok {
+		return
+	}
+	if s.httpSessions.remove(session.id) {
+		s.inflight.cancelSession(session.id)
+	}
+	s.logger.DebugContext(r.Context(), fmt.Sprintf("terminated session %s", session.id))
+	w.WriteHeader(http.StatusOK)
 }
 
 // httpHandler handles all mcp messages.
@@ -357,10 +917,15 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check if client have `Mcp-Session-Id` header
-	// `Mcp-Session-Id` is only set for v2025-03-26 in Toolbox
+	// `Mcp-Session-Id` is set for streamable HTTP sessions, which use the
+	// protocol version negotiated during initialization
 	headerSessionId := r.Header.Get("Mcp-Session-Id")
 	if headerSessionId != "" {
-		protocolVersion = v20250326.PROTOCOL_VERSION
+		httpSession, ok := getHttpSession(s, w, r)
+		if !ok {
+			return
+		}
+		protocolVersion = httpSession.protocolVersion
 	}
 
 	// check if client have `MCP-Protocol-Version` header
@@ -375,10 +940,10 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		protocolVersion = headerProtocolVersion
 	}
 
-	toolsetName := chi.URLParam(r, "toolsetName")
-	promptsetName := chi.URLParam(r, "promptsetName")
-	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
+	toolsetName, promptsetName := mcpSetNames(r)
+	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s, promptset name: %s", toolsetName, promptsetName))
 	span.SetAttributes(attribute.String("toolset_name", toolsetName))
+	span.SetAttributes(attribute.String("promptset_name", promptsetName))
 
 	var err error
 	defer func() {
@@ -409,36 +974,60 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName, promptsetName, r.Header)
+	// server-initiated messages are sent through the sse session for
+	// v2024-11-05, or by streaming the response for streamable HTTP
+	var notify notifyFunc
+	var stream *streamWriter
+	if session != nil {
+		notify = func(ctx context.Context, message any) error {
+			return session.queue(ctx, s, message)
+		}
+	} else if sw, ok := newStreamWriter(w, r); ok {
+		stream = sw
+		notify = sw.send
+	}
+	// request ids are scoped to the session the client is connected with,
+	// requests without a known session cannot be cancelled
+	var clientSessionId string
+	if session != nil {
+		clientSessionId = paramSessionId
+	} else if headerSessionId != "" {
+		clientSessionId = headerSessionId
+	}
+
+	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName, promptsetName, r.Header, clientSessionId, notify)
 	if err != nil {
 		s.logger.DebugContext(ctx, fmt.Errorf("error processing message: %w", err).Error())
 	}
 
-	// notifications will return empty string
+	// notifications and cancelled requests will return empty string
 	if res == nil {
 		// Notifications do not expect a response
-		// Toolbox doesn't do anything with notifications yet
-		w.WriteHeader(http.StatusAccepted)
+		if stream == nil || !stream.isStarted() {
+			w.WriteHeader(http.StatusAccepted)
+		}
 		return
 	}
 
-	// for v20250326, add the `Mcp-Session-Id` header
-	if v == v20250326.PROTOCOL_VERSION {
+	// once streaming has started, the response is the last event of the stream
+	if stream != nil && stream.isStarted() {
+		if err := stream.send(ctx, res); err != nil {
+			s.logger.DebugContext(ctx, fmt.Errorf("error sending response: %w", err).Error())
+		}
+		return
+	}
+
+	// start a new session for streamable HTTP clients (v2025-03-26+), and
+	// add the `Mcp-Session-Id` header
+	if v != "" && v != v20241105.PROTOCOL_VERSION && session == nil {
 		sessionId = uuid.New().String()
+		s.httpSessions.add(newHttpSession(sessionId, v, toolsetName, promptsetName))
 		w.Header().Set("Mcp-Session-Id", sessionId)
 	}
 
 	if session != nil {
 		// queue sse event
-		eventData, _ := json.Marshal(res)
-		select {
-		case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
-			s.logger.DebugContext(ctx, "event queue successful")
-		case <-session.done:
-			s.logger.DebugContext(ctx, "session is close")
-		default:
-			s.logger.DebugContext(ctx, "unable to add to event queue")
-		}
+		_ = session.queue(ctx, s, res)
 	}
 	if rpcResponse, ok := res.(jsonrpc.JSONRPCError); ok {
 		code := rpcResponse.Error.Code
@@ -449,6 +1038,8 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			errStr := err.Error()
 			if errors.Is(err, util.ErrUnauthorized) {
 				w.WriteHeader(http.StatusUnauthorized)
+			} else if errors.Is(err, util.ErrForbidden) {
+				w.WriteHeader(http.StatusForbidden)
 			} else if strings.Contains(errStr, "Error 401") {
 				w.WriteHeader(http.StatusUnauthorized)
 			} else if strings.Contains(errStr, "Error 403") {
@@ -461,27 +1052,26 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, res)
 }
 
-// processMcpMessage process the messages received from clients
-func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string, promptsetName string, header http.Header) (string, any, error) {
+// processMcpMessage process the messages received from clients. sessionId
+// scopes the request ids used for cancellation, and notify (which may be nil)
+// is used to send progress notifications to the client.
+func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string, promptsetName string, header http.Header, sessionId string, notify notifyFunc) (string, any, error) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		return "", jsonrpc.NewError("", jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
 	}
 
+	// check if user is sending a batch request
+	if isBatchMessage(body) {
+		res, err := processMcpBatch(ctx, body, s, protocolVersion, toolsetName, promptsetName, header, sessionId, notify)
+		return "", res, err
+	}
+
 	// Generic baseMessage could either be a JSONRPCNotification or JSONRPCRequest
 	var baseMessage jsonrpc.BaseMessage
 	if err = util.DecodeJSON(bytes.NewBuffer(body), &baseMessage); err != nil {
 		// Generate a new uuid if unable to decode
 		id := uuid.New().String()
-
-		// check if user is sending a batch request
-		var a []any
-		unmarshalErr := json.Unmarshal(body, &a)
-		if unmarshalErr == nil {
-			err = fmt.Errorf("not supporting batch requests")
-			return "", jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
-		}
-
 		return "", jsonrpc.NewError(id, jsonrpc.PARSE_ERROR, err.Error(), nil), err
 	}
 
@@ -500,7 +1090,11 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 
 	// Check if message is a notification
 	if baseMessage.Id == nil {
-		err := mcp.NotificationHandler(ctx, body)
+		err := mcp.NotificationHandler(ctx, body, func(id jsonrpc.RequestId, reason string) {
+			if s.inflight.cancel(sessionId, id) {
+				logger.DebugContext(ctx, fmt.Sprintf("cancelled request %v: %s", id, reason))
+			}
+		})
 		return "", nil, err
 	}
 
@@ -522,7 +1116,135 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 			err = fmt.Errorf("promptset does not exist")
 			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 		}
+
+		// stdio requests have no header
+		transport := audit.TransportMCP
+		if header == nil {
+			transport = audit.TransportStdio
+		}
+		ctx = audit.WithLogger(ctx, s.audit, transport)
+		ctx = util.WithInstrumentation(ctx, s.instrumentation)
+
+		// allow the client to cancel the request while it is in progress
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		s.inflight.add(sessionId, baseMessage.Id, cancel)
+		defer s.inflight.remove(sessionId, baseMessage.Id)
+
+		// report progress if the client requested it with a progress token
+		var request jsonrpc.Request
+		if notify != nil && json.Unmarshal(body, &request) == nil && request.Params.Meta.ProgressToken != nil {
+			token := request.Params.Meta.ProgressToken
+			ctx = util.WithProgressReporter(ctx, func(ctx context.Context, progress, total float64, message string) {
+				if err := notify(ctx, mcputil.NewProgressNotification(token, progress, total, message)); err != nil {
+					logger.DebugContext(ctx, fmt.Sprintf("unable to send progress notification: %s", err))
+				}
+			})
+		}
+
 		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, promptset, s.ResourceMgr, body, header)
+		// no response is sent for cancelled requests
+		if errors.Is(context.Cause(ctx), errRequestCancelled) {
+			return "", nil, errRequestCancelled
+		}
 		return "", res, err
 	}
 }
+
+// isBatchMessage returns true if the message is a JSON-RPC batch.
+func isBatchMessage(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// processMcpBatch process a JSON-RPC batch. Messages within the batch are
+// processed concurrently, up to the batch concurrency limit of the server.
+// Responses are returned in the order of the requests, and notifications do
+// not have a response. A nil response is returned if the batch only contains
+// notifications.
+func processMcpBatch(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string, promptsetName string, header http.Header, sessionId string, notify notifyFunc) (any, error) {
+	// Generate a new uuid for errors that are not specific to a message
+	id := uuid.New().String()
+
+	// requests without a negotiated protocol version are processed as
+	// v2024-11-05, like the messages within them
+	if protocolVersion == "" {
+		protocolVersion = v20241105.PROTOCOL_VERSION
+	}
+	// batches were added in v2025-03-26, and removed in v2025-06-18
+	if protocolVersion != v20241105.PROTOCOL_VERSION && protocolVersion != v20250326.PROTOCOL_VERSION {
+		err := fmt.Errorf("batch requests are not supported in protocol version %s", protocolVersion)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	var messages []json.RawMessage
+	if err := json.Unmarshal(body, &messages); err != nil {
+		return jsonrpc.NewError(id, jsonrpc.PARSE_ERROR, err.Error(), nil), err
+	}
+	if len(messages) == 0 {
+		err := fmt.Errorf("empty batch request")
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	// request ids identify the responses and in-flight requests, so they must
+	// be unique within a batch
+	ids := make(map[string]bool)
+	for _, message := range messages {
+		var baseMessage jsonrpc.BaseMessage
+		if err := json.Unmarshal(message, &baseMessage); err != nil || baseMessage.Id == nil {
+			continue
+		}
+		key := inflightKey(sessionId, baseMessage.Id)
+		if ids[key] {
+			err := fmt.Errorf("duplicate request id %v in batch request", baseMessage.Id)
+			return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+		}
+		ids[key] = true
+	}
+
+	limit := s.mcpBatchConcurrency
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	responses := make([]any, len(messages))
+	errs := make([]error, len(messages))
+	var wg sync.WaitGroup
+	for i, message := range messages {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			responses[i], errs[i] = processMcpBatchMessage(ctx, message, s, protocolVersion, toolsetName, promptsetName, header, sessionId, notify)
+		}()
+	}
+	wg.Wait()
+
+	var res []any
+	for _, r := range responses {
+		// no responses for notifications
+		if r != nil {
+			res = append(res, r)
+		}
+	}
+	err := errors.Join(errs...)
+	if len(res) == 0 {
+		return nil, err
+	}
+	return res, err
+}
+
+// processMcpBatchMessage process a single message within a JSON-RPC batch.
+func processMcpBatchMessage(ctx context.Context, message []byte, s *Server, protocolVersion string, toolsetName string, promptsetName string, header http.Header, sessionId string, notify notifyFunc) (any, error) {
+	if isBatchMessage(message) {
+		err := fmt.Errorf("nested batch requests are not supported")
+		return jsonrpc.NewError(uuid.New().String(), jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	var baseMessage jsonrpc.BaseMessage
+	if err := json.Unmarshal(message, &baseMessage); err == nil && baseMessage.Method == mcputil.INITIALIZE {
+		err := fmt.Errorf("initialize request must not be part of a batch")
+		return jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	_, res, err := processMcpMessage(ctx, message, s, protocolVersion, toolsetName, promptsetName, header, sessionId, notify)
+	return res, err
+}
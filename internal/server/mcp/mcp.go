@@ -15,6 +15,7 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -30,6 +31,7 @@ import (
 	v20251125 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20251125"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 // LATEST_PROTOCOL_VERSION is the latest version of the MCP protocol supported.
@@ -62,8 +64,11 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 		protocolVersion = LATEST_PROTOCOL_VERSION
 	}
 
-	toolsListChanged := false
-	promptsListChanged := false
+	// clients are notified when tools or prompts change after a reload
+	toolsListChanged := true
+	promptsListChanged := true
+	resourcesSubscribe := false
+	resourcesListChanged := false
 	result := mcputil.InitializeResult{
 		ProtocolVersion: protocolVersion,
 		Capabilities: mcputil.ServerCapabilities{
@@ -73,6 +78,10 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 			Prompts: &mcputil.ListChanged{
 				ListChanged: &promptsListChanged,
 			},
+			Resources: &mcputil.ResourcesCapability{
+				Subscribe:   &resourcesSubscribe,
+				ListChanged: &resourcesListChanged,
+			},
 		},
 		ServerInfo: mcputil.Implementation{
 			BaseMetadata: mcputil.BaseMetadata{
@@ -91,12 +100,23 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 }
 
 // NotificationHandler process notifications request. It MUST NOT send a response.
-// Currently Toolbox does not process any notifications.
-func NotificationHandler(ctx context.Context, body []byte) error {
+// cancelRequest is called with the request id of `notifications/cancelled`
+// notifications, all other notifications are ignored.
+func NotificationHandler(ctx context.Context, body []byte, cancelRequest func(id jsonrpc.RequestId, reason string)) error {
 	var notification jsonrpc.JSONRPCNotification
 	if err := json.Unmarshal(body, &notification); err != nil {
 		return fmt.Errorf("invalid notification request: %w", err)
 	}
+
+	switch notification.Method {
+	case mcputil.NOTIFICATIONS_CANCELLED:
+		// decode with util.DecodeJSON so that numeric ids match the ids of requests
+		var cancelled mcputil.CancelledNotification
+		if err := util.DecodeJSON(bytes.NewBuffer(body), &cancelled); err != nil {
+			return fmt.Errorf("invalid cancelled notification: %w", err)
+		}
+		cancelRequest(cancelled.Params.RequestId, cancelled.Params.Reason)
+	}
 	return nil
 }
 
@@ -88,8 +88,16 @@ type ClientCapabilities struct {
 // capabilities are defined here, in this schema, but this is not a closed set: any
 // server can define its own, additional capabilities.
 type ServerCapabilities struct {
-	Tools   *ListChanged `json:"tools,omitempty"`
-	Prompts *ListChanged `json:"prompts,omitempty"`
+	Tools     *ListChanged         `json:"tools,omitempty"`
+	Prompts   *ListChanged         `json:"prompts,omitempty"`
+	Resources *ResourcesCapability `json:"resources,omitempty"`
+}
+
+// ResourcesCapability represents whether the server supports subscribing to
+// resource updates and notification for changes to the resource list.
+type ResourcesCapability struct {
+	Subscribe   *bool `json:"subscribe,omitempty"`
+	ListChanged *bool `json:"listChanged,omitempty"`
 }
 
 // Base interface for metadata with name (identifier) and title (display name) properties.
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+)
+
+// notifications that are supported
+const (
+	NOTIFICATIONS_CANCELLED            = "notifications/cancelled"
+	NOTIFICATIONS_PROGRESS             = "notifications/progress"
+	NOTIFICATIONS_TOOLS_LIST_CHANGED   = "notifications/tools/list_changed"
+	NOTIFICATIONS_PROMPTS_LIST_CHANGED = "notifications/prompts/list_changed"
+)
+
+/* Cancellation */
+
+// CancelledNotification can be sent by either side to indicate that it is
+// cancelling a previously-issued request.
+type CancelledNotification struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  struct {
+		// The ID of the request to cancel.
+		RequestId jsonrpc.RequestId `json:"requestId"`
+		// An optional string describing the reason for the cancellation.
+		Reason string `json:"reason,omitempty"`
+	} `json:"params"`
+}
+
+/* Progress */
+
+// ProgressNotification is an out-of-band notification used to inform the
+// receiver of a progress update for a long-running request.
+type ProgressNotification struct {
+	Jsonrpc string         `json:"jsonrpc"`
+	Method  string         `json:"method"`
+	Params  ProgressParams `json:"params"`
+}
+
+type ProgressParams struct {
+	// The progress token which was given in the initial request, used to
+	// associate this notification with the request that is proceeding.
+	ProgressToken jsonrpc.ProgressToken `json:"progressToken"`
+	// The progress thus far. This should increase every time progress is made,
+	// even if the total is unknown.
+	Progress float64 `json:"progress"`
+	// Total number of items to process (or total progress required), if known.
+	Total float64 `json:"total,omitempty"`
+	// An optional message describing the current progress.
+	Message string `json:"message,omitempty"`
+}
+
+// NewProgressNotification returns a progress notification for the request
+// associated with the progress token.
+func NewProgressNotification(token jsonrpc.ProgressToken, progress, total float64, message string) ProgressNotification {
+	return ProgressNotification{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Method:  NOTIFICATIONS_PROGRESS,
+		Params: ProgressParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		},
+	}
+}
+
+/* List changed */
+
+// NewListChangedNotification returns a notification informing the client that
+// a list (e.g. of tools or prompts) has changed.
+func NewListChangedNotification(method string) jsonrpc.JSONRPCNotification {
+	return jsonrpc.JSONRPCNotification{
+		Jsonrpc:      jsonrpc.JSONRPC_VERSION,
+		Notification: jsonrpc.Notification{Method: method},
+	}
+}
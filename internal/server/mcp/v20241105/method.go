@@ -23,11 +23,14 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/googleapis/genai-toolbox/internal/audit"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 // ProcessMethod returns a response for the request.
@@ -38,11 +41,17 @@ func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, too
 	case TOOLS_LIST:
 		return toolsListHandler(id, toolset, body)
 	case TOOLS_CALL:
-		return toolsCallHandler(ctx, id, resourceMgr, body, header)
+		return toolsCallHandler(ctx, id, toolset, resourceMgr, body, header)
 	case PROMPTS_LIST:
 		return promptsListHandler(ctx, id, promptset, body)
 	case PROMPTS_GET:
 		return promptsGetHandler(ctx, id, resourceMgr, body)
+	case RESOURCES_LIST:
+		return resourcesListHandler(ctx, id, toolset, resourceMgr, body)
+	case RESOURCES_TEMPLATES_LIST:
+		return resourceTemplatesListHandler(ctx, id, toolset, resourceMgr, body)
+	case RESOURCES_READ:
+		return resourcesReadHandler(ctx, id, toolset, resourceMgr, body, header)
 	default:
 		err := fmt.Errorf("invalid method %s", method)
 		return jsonrpc.NewError(id, jsonrpc.METHOD_NOT_FOUND, err.Error(), nil), err
@@ -65,10 +74,11 @@ func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
 
-	// exclude annotations from this version
+	// exclude annotations and output schema from this version
 	manifests := make([]tools.McpManifest, len(toolset.McpManifest))
 	for i, m := range toolset.McpManifest {
 		m.Annotations = nil
+		m.OutputSchema = nil
 		manifests[i] = m
 	}
 
@@ -83,7 +93,7 @@ func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte)
 }
 
 // toolsCallHandler generate a response for tools call.
-func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
+func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (_ any, err error) {
 	authServices := resourceMgr.GetAuthServiceMap()
 
 	// retrieve logger from context
@@ -100,9 +110,12 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 
 	toolName := req.Params.Name
 	toolArgument := req.Params.Arguments
+	invocation := audit.Start(ctx, toolset.Name, toolName)
+	defer func() { invocation.Finish(ctx, err) }()
 	logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
 	tool, ok := resourceMgr.GetTool(toolName)
-	if !ok {
+	// tools outside of the connected toolset are treated as non-existent
+	if !ok || !toolset.ContainsTool(toolName) {
 		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
 	}
@@ -159,6 +172,7 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 			claimsFromAuth[aS.GetName()] = claims
 		}
 	}
+	invocation.SetClaims(claimsFromAuth)
 
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
@@ -174,6 +188,12 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 		err = fmt.Errorf("unauthorized Tool call: Please make sure your specify correct auth headers: %w", util.ErrUnauthorized)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
+
+	// Check the authorization policies of the tool and the toolset
+	if err = tools.CheckPolicies(tool, toolset, claimsFromAuth); err != nil {
+		err = fmt.Errorf("forbidden Tool call: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
 	logger.DebugContext(ctx, "tool invocation authorized")
 
 	params, err := tool.ParseParams(data, claimsFromAuth)
@@ -182,6 +202,7 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
+	invocation.SetParams(params)
 
 	embeddingModels := resourceMgr.GetEmbeddingModelMap()
 	params, err = tool.EmbedParams(ctx, params, embeddingModels)
@@ -191,7 +212,10 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 	}
 
 	// run tool invocation and generate response.
+	metrics := tools.StartInvocationMetrics(ctx, toolName, tool, resourceMgr)
 	results, err := tool.Invoke(ctx, resourceMgr, params, accessToken)
+	metrics.Done(ctx, err)
+	invocation.SetResult(results, err)
 	if err != nil {
 		errStr := err.Error()
 		// Missing authService tokens.
@@ -221,11 +245,16 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 
 	content := make([]TextContent, 0)
 
+	// truncated results return their rows followed by a notice
+	truncated, isTruncated := results.(sqlutil.TruncatedResult)
 	sliceRes, ok := results.([]any)
-	if !ok {
+	if isTruncated {
+		sliceRes = truncated.Rows
+	} else if !ok {
 		sliceRes = []any{results}
 	}
 
+	size := 0
 	for _, d := range sliceRes {
 		text := TextContent{Type: "text"}
 		dM, err := json.Marshal(d)
@@ -234,8 +263,13 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 		} else {
 			text.Text = string(dM)
 		}
+		size += len(text.Text)
 		content = append(content, text)
 	}
+	metrics.RecordResult(ctx, results, size)
+	if isTruncated {
+		content = append(content, TextContent{Type: "text", Text: truncated.Message})
+	}
 
 	return jsonrpc.JSONRPCResponse{
 		Jsonrpc: jsonrpc.JSONRPC_VERSION,
@@ -339,3 +373,107 @@ func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *r
 		Result:  result,
 	}, nil
 }
+
+// resourcesListHandler handles the "resources/list" method.
+func resourcesListHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, resourceMgr *resources.ResourceManager, body []byte) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	logger.DebugContext(ctx, "handling resources/list request")
+
+	var req ListResourcesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	manifests, _ := mcpresources.GetMcpManifests(mcpresources.InToolset(resourceMgr.GetResourcesMap(), toolset))
+	logger.DebugContext(ctx, fmt.Sprintf("returning %d resources", len(manifests)))
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListResourcesResult{Resources: manifests},
+	}, nil
+}
+
+// resourceTemplatesListHandler handles the "resources/templates/list" method.
+func resourceTemplatesListHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, resourceMgr *resources.ResourceManager, body []byte) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	logger.DebugContext(ctx, "handling resources/templates/list request")
+
+	var req ListResourceTemplatesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources templates list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	_, templates := mcpresources.GetMcpManifests(mcpresources.InToolset(resourceMgr.GetResourcesMap(), toolset))
+	logger.DebugContext(ctx, fmt.Sprintf("returning %d resource templates", len(templates)))
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListResourceTemplatesResult{ResourceTemplates: templates},
+	}, nil
+}
+
+// resourcesReadHandler handles the "resources/read" method.
+func resourcesReadHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	logger.DebugContext(ctx, "handling resources/read request")
+
+	var req ReadResourceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources/read request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	uri := req.Params.URI
+	logger.DebugContext(ctx, fmt.Sprintf("resource uri: %s", uri))
+	// resources outside of the connected toolset are treated as non-existent
+	resource, vars, ok := mcpresources.FindResource(mcpresources.InToolset(resourceMgr.GetResourcesMap(), toolset), uri)
+	if !ok {
+		err := fmt.Errorf("resource with uri %q does not exist", uri)
+		return jsonrpc.NewError(id, RESOURCE_NOT_FOUND, err.Error(), map[string]string{"uri": uri}), err
+	}
+
+	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
+	claimsFromAuth := make(map[string]map[string]any)
+	// if using stdio, header will be nil and auth will not be supported
+	if header != nil {
+		for _, aS := range resourceMgr.GetAuthServiceMap() {
+			claims, err := aS.GetClaimsFromHeader(ctx, header)
+			if err != nil {
+				logger.DebugContext(ctx, err.Error())
+				continue
+			}
+			if claims != nil {
+				claimsFromAuth[aS.GetName()] = claims
+			}
+		}
+	}
+	if err := mcpresources.Authorize(resource, toolset, claimsFromAuth); err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	contents, err := resource.Read(ctx, resourceMgr, uri, vars)
+	if err != nil {
+		err = fmt.Errorf("error reading resource %q: %w", uri, err)
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ReadResourceResult{Contents: contents},
+	}, nil
+}
@@ -19,18 +19,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 const jsonrpcVersion = "2.0"
@@ -79,7 +91,7 @@ func TestMcpEndpointWithoutInitialized(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2, tool3, tool4, tool5}
 	mockPrompts := []MockPrompt{prompt1, prompt2}
 	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, mockTools, mockPrompts)
-	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -424,7 +436,7 @@ func TestMcpEndpoint(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2, tool3, tool4, tool5}
 	mockPrompts := []MockPrompt{prompt1, prompt2}
 	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, mockTools, mockPrompts)
-	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -445,8 +457,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2024-11-05",
 					"capabilities": map[string]any{
-						"tools":   map[string]any{"listChanged": false},
-						"prompts": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"prompts":   map[string]any{"listChanged": true},
+						"resources": map[string]any{"subscribe": false, "listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -462,8 +475,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2025-03-26",
 					"capabilities": map[string]any{
-						"tools":   map[string]any{"listChanged": false},
-						"prompts": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"prompts":   map[string]any{"listChanged": true},
+						"resources": map[string]any{"subscribe": false, "listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -472,15 +486,16 @@ func TestMcpEndpoint(t *testing.T) {
 		{
 			name:     "version 2025-06-18",
 			protocol: protocolVersion20250618,
-			idHeader: false,
+			idHeader: true,
 			initWant: map[string]any{
 				"jsonrpc": "2.0",
 				"id":      "mcp-initialize",
 				"result": map[string]any{
 					"protocolVersion": "2025-06-18",
 					"capabilities": map[string]any{
-						"tools":   map[string]any{"listChanged": false},
-						"prompts": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"prompts":   map[string]any{"listChanged": true},
+						"resources": map[string]any{"subscribe": false, "listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -489,15 +504,16 @@ func TestMcpEndpoint(t *testing.T) {
 		{
 			name:     "version 2025-11-25",
 			protocol: protocolVersion20251125,
-			idHeader: false,
+			idHeader: true,
 			initWant: map[string]any{
 				"jsonrpc": "2.0",
 				"id":      "mcp-initialize",
 				"result": map[string]any{
 					"protocolVersion": "2025-11-25",
 					"capabilities": map[string]any{
-						"tools":   map[string]any{"listChanged": false},
-						"prompts": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"prompts":   map[string]any{"listChanged": true},
+						"resources": map[string]any{"subscribe": false, "listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -677,6 +693,121 @@ func TestMcpEndpoint(t *testing.T) {
 						},
 					},
 				},
+				{
+					name: "prompts/list on tool1_only and prompt1_only",
+					url:  "/tool1_only/prompt1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "prompts-list-prompt1",
+						Request: jsonrpc.Request{
+							Method: "prompts/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "prompts-list-prompt1",
+						"result": map[string]any{
+							"prompts": []any{
+								map[string]any{
+									"name": "prompt1",
+								},
+							},
+						},
+					},
+				},
+				{
+					name: "tools/list on tool1_only and prompt1_only",
+					url:  "/tool1_only/prompt1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "tools-list-tool1-prompt1",
+						Request: jsonrpc.Request{
+							Method: "tools/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "tools-list-tool1-prompt1",
+						"result": map[string]any{
+							"tools": []any{
+								map[string]any{
+									"name":        "no_params",
+									"inputSchema": basicInputSchema,
+								},
+							},
+						},
+					},
+				},
+				{
+					name: "prompts/list with promptset query parameter",
+					url:  "/?promptset=prompt1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "prompts-list-prompt1-query",
+						Request: jsonrpc.Request{
+							Method: "prompts/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "prompts-list-prompt1-query",
+						"result": map[string]any{
+							"prompts": []any{
+								map[string]any{
+									"name": "prompt1",
+								},
+							},
+						},
+					},
+				},
+				{
+					name: "tools/list with toolset query parameter",
+					url:  "/?toolset=tool1_only",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "tools-list-tool1-query",
+						Request: jsonrpc.Request{
+							Method: "tools/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "tools-list-tool1-query",
+						"result": map[string]any{
+							"tools": []any{
+								map[string]any{
+									"name":        "no_params",
+									"inputSchema": basicInputSchema,
+								},
+							},
+						},
+					},
+				},
+				{
+					name:  "prompts/list on invalid prompt set",
+					url:   "/tool1_only/foo",
+					isErr: true,
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "prompts-list-invalid-promptset",
+						Request: jsonrpc.Request{
+							Method: "prompts/list",
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "prompts-list-invalid-promptset",
+						"error": map[string]any{
+							"code":    -32600.0,
+							"message": "promptset does not exist",
+						},
+					},
+				},
 				{
 					name:  "tools/list on invalid tool set",
 					url:   "/foo",
@@ -759,35 +890,6 @@ func TestMcpEndpoint(t *testing.T) {
 						},
 					},
 				},
-				{
-					name:  "batch requests",
-					url:   "/",
-					isErr: true,
-					body: []any{
-						jsonrpc.JSONRPCRequest{
-							Jsonrpc: "1.0",
-							Id:      "batch-requests1",
-							Request: jsonrpc.Request{
-								Method: "foo",
-							},
-						},
-						jsonrpc.JSONRPCRequest{
-							Jsonrpc: jsonrpcVersion,
-							Id:      "batch-requests2",
-							Request: jsonrpc.Request{
-								Method: "tools/list",
-							},
-						},
-					},
-					wantStatusCode: http.StatusOK,
-					want: map[string]any{
-						"jsonrpc": "2.0",
-						"error": map[string]any{
-							"code":    -32600.0,
-							"message": "not supporting batch requests",
-						},
-					},
-				},
 				{
 					name: "call tool1 unauthorized tool",
 					url:  "/",
@@ -815,6 +917,34 @@ func TestMcpEndpoint(t *testing.T) {
 						},
 					},
 				},
+				{
+					name:  "call tool outside of toolset",
+					url:   "/tool1_only",
+					isErr: true,
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "tools-call-outside-toolset",
+						Request: jsonrpc.Request{
+							Method: "tools/call",
+						},
+						Params: map[string]any{
+							"name": "some_params",
+							"arguments": map[string]any{
+								"param1": 1,
+								"param2": 2,
+							},
+						},
+					},
+					wantStatusCode: http.StatusOK,
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "tools-call-outside-toolset",
+						"error": map[string]any{
+							"code":    -32602.0,
+							"message": `invalid tool name: tool with name "some_params" does not exist`,
+						},
+					},
+				},
 				{
 					name: "call tool4 unauthorized tool",
 					url:  "/",
@@ -908,7 +1038,7 @@ func TestMcpEndpoint(t *testing.T) {
 }
 
 func TestInvalidProtocolVersionHeader(t *testing.T) {
-	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil)
+	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -934,45 +1064,256 @@ func TestInvalidProtocolVersionHeader(t *testing.T) {
 }
 
 func TestDeleteEndpoint(t *testing.T) {
-	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil)
+	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
 
-	resp, _, err := runRequest(ts, http.MethodDelete, "/", nil, nil)
-	if resp.Status != "200 OK" {
-		t.Fatalf("unexpected status: %s", resp.Status)
+	testCases := []struct {
+		name       string
+		header     map[string]string
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "missing session id",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "missing Mcp-Session-Id header",
+		},
+		{
+			name:       "unknown session id",
+			header:     map[string]string{"Mcp-Session-Id": "unknown"},
+			wantStatus: http.StatusNotFound,
+			wantError:  `session "unknown" not found`,
+		},
 	}
-	if err != nil {
-		t.Fatalf("unexpected error during request: %s", err)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodDelete, "/", nil, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if got["error"] != tc.wantError {
+				t.Fatalf("unexpected error message: got %s, want %s", got["error"], tc.wantError)
+			}
+		})
 	}
 }
 
 func TestGetEndpoint(t *testing.T) {
-	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil)
+	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
 
-	resp, body, err := runRequest(ts, http.MethodGet, "/", nil, nil)
-	if resp.Status != "405 Method Not Allowed" {
-		t.Fatalf("unexpected status: %s", resp.Status)
+	testCases := []struct {
+		name       string
+		header     map[string]string
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "client does not accept sse",
+			wantStatus: http.StatusNotAcceptable,
+			wantError:  "client must accept text/event-stream",
+		},
+		{
+			name:       "missing session id",
+			header:     map[string]string{"Accept": "text/event-stream"},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "missing Mcp-Session-Id header",
+		},
+		{
+			name:       "unknown session id",
+			header:     map[string]string{"Accept": "text/event-stream", "Mcp-Session-Id": "unknown"},
+			wantStatus: http.StatusNotFound,
+			wantError:  `session "unknown" not found`,
+		},
 	}
-	var got map[string]any
-	if err := json.Unmarshal(body, &got); err != nil {
-		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodGet, "/", nil, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if got["error"] != tc.wantError {
+				t.Fatalf("unexpected error message: got %s, want %s", got["error"], tc.wantError)
+			}
+		})
 	}
-	want := "toolbox does not support streaming in streamable HTTP transport"
-	if got["error"] != want {
-		t.Fatalf("unexpected error message: %s", got["error"])
+}
+
+// readSseEvent reads the next event of a SSE stream.
+func readSseEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+	var id, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unable to read event: %s", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return id, data
+		}
+		if v, ok := strings.CutPrefix(line, "id: "); ok {
+			id = v
+		}
+		if v, ok := strings.CutPrefix(line, "data: "); ok {
+			data = v
+		}
+	}
+}
+
+func TestStreamableHttpSession(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1})
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
 	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
 	if err != nil {
-		t.Fatalf("unexpected error during request: %s", err)
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	server := &Server{
+		version:         fakeVersionString,
+		logger:          testLogger,
+		instrumentation: instrumentation,
+		sseManager:      newSseManager(ctx),
+		httpSessions:    newHttpSessionManager(ctx),
+		ResourceMgr:     resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil),
+	}
+	r, err := mcpRouter(server)
+	if err != nil {
+		t.Fatalf("unable to initialize mcp router: %s", err)
+	}
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	initWant := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "mcp-initialize",
+		"result": map[string]any{
+			"protocolVersion": protocolVersion20250618,
+			"capabilities": map[string]any{
+				"tools":     map[string]any{"listChanged": true},
+				"prompts":   map[string]any{"listChanged": true},
+				"resources": map[string]any{"subscribe": false, "listChanged": false},
+			},
+			"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
+		},
+	}
+	sessionId := runInitializeLifecycle(t, ts, protocolVersion20250618, initWant, true)
+	session, ok := server.httpSessions.get(sessionId)
+	if !ok {
+		t.Fatalf("session %q not found", sessionId)
+	}
+	if session.protocolVersion != protocolVersion20250618 {
+		t.Fatalf("unexpected session protocol version: got %s, want %s", session.protocolVersion, protocolVersion20250618)
+	}
+
+	notification := func(i int) map[string]any {
+		return map[string]any{"jsonrpc": jsonrpcVersion, "method": fmt.Sprintf("notifications/test%d", i)}
+	}
+	openStream := func(t *testing.T, lastEventId string) (*bufio.Reader, func()) {
+		t.Helper()
+		reqCtx, reqCancel := context.WithCancel(ctx)
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, ts.URL+"/", nil)
+		if err != nil {
+			t.Fatalf("unable to create request: %s", err)
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set("Mcp-Session-Id", sessionId)
+		if lastEventId != "" {
+			req.Header.Set("Last-Event-ID", lastEventId)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unable to send request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+			t.Fatalf("unexpected content type: got %s", contentType)
+		}
+		return bufio.NewReader(resp.Body), func() {
+			reqCancel()
+			resp.Body.Close()
+		}
+	}
+	wantEvent := func(t *testing.T, reader *bufio.Reader, wantId string, want map[string]any) {
+		t.Helper()
+		id, data := readSseEvent(t, reader)
+		if id != wantId {
+			t.Fatalf("unexpected event id: got %s, want %s", id, wantId)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(data), &got); err != nil {
+			t.Fatalf("unable to unmarshal event data: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected event: got %v, want %v", got, want)
+		}
 	}
+
+	t.Run("stream delivers pending messages", func(t *testing.T) {
+		_ = session.send(ctx, notification(1))
+		reader, closeStream := openStream(t, "")
+		defer closeStream()
+		wantEvent(t, reader, "1", notification(1))
+		_ = session.send(ctx, notification(2))
+		wantEvent(t, reader, "2", notification(2))
+	})
+
+	t.Run("stream resumes after last event id", func(t *testing.T) {
+		_ = session.send(ctx, notification(3))
+		reader, closeStream := openStream(t, "1")
+		defer closeStream()
+		wantEvent(t, reader, "2", notification(2))
+		wantEvent(t, reader, "3", notification(3))
+	})
+
+	t.Run("delete terminates the session", func(t *testing.T) {
+		header := map[string]string{"Mcp-Session-Id": sessionId}
+		resp, _, err := runRequest(ts, http.MethodDelete, "/", nil, header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+
+		body := `{"jsonrpc":"2.0","id":"tools-list","method":"tools/list"}`
+		resp, _, err = runRequest(ts, http.MethodPost, "/", strings.NewReader(body), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, http.StatusNotFound)
+		}
+	})
 }
 
 func TestSseEndpoint(t *testing.T) {
-	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil)
+	r, shutdown := setUpServer(t, "mcp", nil, nil, nil, nil, nil)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
@@ -1017,6 +1358,12 @@ func TestSseEndpoint(t *testing.T) {
 			path:   "/prompt1_only/sse",
 			event:  fmt.Sprintf("event: endpoint\ndata: http://127.0.0.1:%s/mcp/prompt1_only?sessionId=", tsPort),
 		},
+		{
+			name:   "toolset and promptset",
+			server: ts,
+			path:   "/tool1_only/prompt1_only/sse",
+			event:  fmt.Sprintf("event: endpoint\ndata: http://127.0.0.1:%s/mcp/tool1_only?promptset=prompt1_only&sessionId=", tsPort),
+		},
 		{
 			name:   "basic with http proto",
 			server: ts,
@@ -1106,7 +1453,7 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unable to initialize logger: %s", err)
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox", false)
 	if err != nil {
 		t.Fatalf("unable to setup otel: %s", err)
 	}
@@ -1124,13 +1471,14 @@ func TestStdioSession(t *testing.T) {
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	resourceManager := resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 
 	server := &Server{
 		version:         fakeVersionString,
 		logger:          testLogger,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
+		httpSessions:    newHttpSessionManager(ctx),
 		ResourceMgr:     resourceManager,
 	}
 
@@ -1168,3 +1516,1165 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unexpected read: got %s, want %s", read, want)
 	}
 }
+
+func TestStdioToolsetAndPromptset(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1, prompt2})
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	server := &Server{
+		version:         fakeVersionString,
+		logger:          testLogger,
+		instrumentation: instrumentation,
+		sseManager:      newSseManager(ctx),
+		stdioToolset:    "tool1_only",
+		stdioPromptset:  "prompt1_only",
+		ResourceMgr:     resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil),
+	}
+
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}
+{"jsonrpc":"2.0","id":2,"method":"prompts/list"}
+`)
+	var out bytes.Buffer
+	if err := server.ServeStdio(util.WithLogger(ctx, testLogger), in, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []map[string]any{
+		{
+			"jsonrpc": "2.0",
+			"id":      1.0,
+			"result": map[string]any{
+				"tools": []any{
+					map[string]any{
+						"name":        "no_params",
+						"inputSchema": basicInputSchema,
+					},
+				},
+			},
+		},
+		{
+			"jsonrpc": "2.0",
+			"id":      2.0,
+			"result": map[string]any{
+				"prompts": []any{
+					map[string]any{
+						"name": "prompt1",
+					},
+				},
+			},
+		},
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("unexpected number of responses: got %d, want %d: %s", len(lines), len(want), out.String())
+	}
+	for i, line := range lines {
+		var got map[string]any
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("unable to unmarshal response: %s", err)
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("unexpected response: got %+v, want %+v", got, want[i])
+		}
+	}
+}
+
+func TestMcpStructuredContent(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool6, tool1}, []MockPrompt{prompt1})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	outputSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"result": map[string]any{
+				"type":        "array",
+				"description": "The rows returned by the tool.",
+				"items": map[string]any{
+					"type":        "object",
+					"description": "",
+					"properties": map[string]any{
+						"name": map[string]any{"type": []any{"string", "null"}, "description": "name of the tool"},
+					},
+					"required": []any{"name"},
+				},
+			},
+			"truncated": map[string]any{
+				"type":        "boolean",
+				"description": "Set if the rows were truncated because the result exceeded a limit.",
+			},
+			"rowCount": map[string]any{
+				"type":        "integer",
+				"description": "The number of rows returned when the rows were truncated.",
+			},
+		},
+		"required": []any{"result"},
+	}
+
+	testCases := []struct {
+		name       string
+		header     map[string]string
+		body       jsonrpc.JSONRPCRequest
+		wantResult map[string]any
+	}{
+		{
+			name:   "tools/list with output schema",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250618},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "tools-list",
+				Request: jsonrpc.Request{Method: "tools/list"},
+			},
+			wantResult: map[string]any{
+				"tools": []any{
+					map[string]any{
+						"name":         "output_schema_tool",
+						"inputSchema":  basicInputSchema,
+						"outputSchema": outputSchema,
+					},
+					map[string]any{
+						"name":        "no_params",
+						"inputSchema": basicInputSchema,
+					},
+				},
+			},
+		},
+		{
+			name:   "tools/list excludes output schema before 2025-06-18",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "tools-list",
+				Request: jsonrpc.Request{Method: "tools/list"},
+			},
+			wantResult: map[string]any{
+				"tools": []any{
+					map[string]any{
+						"name":        "output_schema_tool",
+						"inputSchema": basicInputSchema,
+					},
+					map[string]any{
+						"name":        "no_params",
+						"inputSchema": basicInputSchema,
+					},
+				},
+			},
+		},
+		{
+			name:   "tools/call returns structured content",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "tools-call",
+				Request: jsonrpc.Request{Method: "tools/call"},
+				Params:  map[string]any{"name": "output_schema_tool"},
+			},
+			wantResult: map[string]any{
+				"content": []any{
+					map[string]any{"type": "text", "text": `"output_schema_tool"`},
+				},
+				"structuredContent": map[string]any{
+					"result": []any{"output_schema_tool"},
+				},
+			},
+		},
+		{
+			name:   "tools/call without output schema",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250618},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "tools-call",
+				Request: jsonrpc.Request{Method: "tools/call"},
+				Params:  map[string]any{"name": "no_params"},
+			},
+			wantResult: map[string]any{
+				"content": []any{
+					map[string]any{"type": "text", "text": `"no_params"`},
+				},
+			},
+		},
+		{
+			name:   "tools/call excludes structured content before 2025-06-18",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "tools-call",
+				Request: jsonrpc.Request{Method: "tools/call"},
+				Params:  map[string]any{"name": "output_schema_tool"},
+			},
+			wantResult: map[string]any{
+				"content": []any{
+					map[string]any{"type": "text", "text": `"output_schema_tool"`},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if !reflect.DeepEqual(got["result"], tc.wantResult) {
+				t.Fatalf("unexpected result: got %+v, want %+v", got["result"], tc.wantResult)
+			}
+		})
+	}
+}
+
+func TestMcpResources(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1})
+	restrictedResource := MockResource{Name: "restricted_resource", URI: "mock://restricted"}
+	mcpResources := map[string]mcpresources.Resource{
+		resource1.Name:          resource1,
+		resource2.Name:          resource2,
+		resource3.Name:          resource3,
+		restrictedResource.Name: restrictedResource,
+	}
+	for _, tc := range []tools.ToolsetConfig{
+		{Name: "", ToolNames: []string{tool1.Name, tool2.Name}, ResourceNames: []string{resource1.Name, resource2.Name, resource3.Name, restrictedResource.Name}},
+		{Name: "static_only", ToolNames: []string{tool1.Name}, ResourceNames: []string{resource1.Name}},
+		{Name: "restricted", ToolNames: []string{tool1.Name}, ResourceNames: []string{restrictedResource.Name}, Policies: []tools.Policy{{Claim: "groups", Contains: "dba"}}},
+	} {
+		toolset, err := tc.Initialize(fakeVersionString, toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset: %s", err)
+		}
+		toolsets[tc.Name] = toolset
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, mcpResources)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name      string
+		path      string
+		header    map[string]string
+		body      jsonrpc.JSONRPCRequest
+		wantKey   string
+		wantValue map[string]any
+	}{
+		{
+			name:   "resources/list",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-list",
+				Request: jsonrpc.Request{Method: "resources/list"},
+			},
+			wantKey: "result",
+			wantValue: map[string]any{
+				"resources": []any{
+					map[string]any{"uri": "mock://auth", "name": "auth_resource", "mimeType": "text/plain"},
+					map[string]any{"uri": "mock://restricted", "name": "restricted_resource", "mimeType": "text/plain"},
+					map[string]any{"uri": "mock://static", "name": "static_resource", "mimeType": "text/plain"},
+				},
+			},
+		},
+		{
+			name:   "resources/list scoped to toolset",
+			path:   "/static_only",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-list",
+				Request: jsonrpc.Request{Method: "resources/list"},
+			},
+			wantKey: "result",
+			wantValue: map[string]any{
+				"resources": []any{
+					map[string]any{"uri": "mock://static", "name": "static_resource", "mimeType": "text/plain"},
+				},
+			},
+		},
+		{
+			name: "resources/templates/list scoped to toolset",
+			path: "/static_only",
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-templates-list",
+				Request: jsonrpc.Request{Method: "resources/templates/list"},
+			},
+			wantKey:   "result",
+			wantValue: map[string]any{"resourceTemplates": []any{}},
+		},
+		{
+			name: "resources/templates/list",
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-templates-list",
+				Request: jsonrpc.Request{Method: "resources/templates/list"},
+			},
+			wantKey: "result",
+			wantValue: map[string]any{
+				"resourceTemplates": []any{
+					map[string]any{"uriTemplate": "mock://tables/{schema}/{table}", "name": "template_resource", "mimeType": "text/plain"},
+				},
+			},
+		},
+		{
+			name:   "resources/read static resource",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://static"},
+			},
+			wantKey: "result",
+			wantValue: map[string]any{
+				"contents": []any{
+					map[string]any{"uri": "mock://static", "mimeType": "text/plain", "text": "read static_resource map[]"},
+				},
+			},
+		},
+		{
+			name:   "resources/read resource template",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250618},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://tables/public/users"},
+			},
+			wantKey: "result",
+			wantValue: map[string]any{
+				"contents": []any{
+					map[string]any{"uri": "mock://tables/public/users", "mimeType": "text/plain", "text": "read template_resource map[schema:public table:users]"},
+				},
+			},
+		},
+		{
+			name:   "resources/read unknown resource",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://tables/public"},
+			},
+			wantKey: "error",
+			wantValue: map[string]any{
+				"code":    -32002.0,
+				"message": `resource with uri "mock://tables/public" does not exist`,
+				"data":    map[string]any{"uri": "mock://tables/public"},
+			},
+		},
+		{
+			name:   "resources/read resource outside of toolset",
+			path:   "/static_only",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://tables/public/users"},
+			},
+			wantKey: "error",
+			wantValue: map[string]any{
+				"code":    -32002.0,
+				"message": `resource with uri "mock://tables/public/users" does not exist`,
+				"data":    map[string]any{"uri": "mock://tables/public/users"},
+			},
+		},
+		{
+			name:   "resources/read without required auth",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://auth"},
+			},
+			wantKey: "error",
+			wantValue: map[string]any{
+				"code":    -32600.0,
+				"message": "unauthorized resource read: Please make sure your specify correct auth headers: unauthorized",
+			},
+		},
+		{
+			name:   "resources/read denied by toolset policy",
+			path:   "/restricted",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://restricted"},
+			},
+			wantKey: "error",
+			wantValue: map[string]any{
+				"code":    -32600.0,
+				"message": `forbidden resource read: denied by policy: claim "groups" must contain "dba": forbidden`,
+			},
+		},
+		{
+			name:   "resources/read not denied by policy of another toolset",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125},
+			body: jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "resources-read",
+				Request: jsonrpc.Request{Method: "resources/read"},
+				Params:  map[string]any{"uri": "mock://restricted"},
+			},
+			wantKey: "result",
+			wantValue: map[string]any{
+				"contents": []any{
+					map[string]any{"uri": "mock://restricted", "mimeType": "text/plain", "text": "read restricted_resource map[]"},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			path := tc.path
+			if path == "" {
+				path = "/"
+			}
+			_, body, err := runRequest(ts, http.MethodPost, path, bytes.NewBuffer(reqMarshal), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if !reflect.DeepEqual(got[tc.wantKey], tc.wantValue) {
+				t.Fatalf("unexpected %s: got %+v, want %+v", tc.wantKey, got[tc.wantKey], tc.wantValue)
+			}
+		})
+	}
+}
+
+func TestInflightRequests(t *testing.T) {
+	var m inflightRequests
+
+	// requests are cancelled within their own session only
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	m.add("session-1", 1, cancel)
+	if m.cancel("session-2", 1) {
+		t.Fatalf("unexpected cancellation from another session")
+	}
+	if !m.cancel("session-1", 1) {
+		t.Fatalf("expected request to be cancelled")
+	}
+	if !errors.Is(context.Cause(ctx), errRequestCancelled) {
+		t.Fatalf("unexpected cause: %v", context.Cause(ctx))
+	}
+
+	// requests without a session are not tracked
+	ctx, cancel = context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	m.add("", 1, cancel)
+	if m.cancel("", 1) {
+		t.Fatalf("unexpected cancellation of a request without a session")
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("unexpected cancelled context: %v", context.Cause(ctx))
+	}
+}
+
+func TestStdioProgressAndCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool7, tool8}, []MockPrompt{prompt1})
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	server := &Server{
+		version:         fakeVersionString,
+		logger:          testLogger,
+		instrumentation: instrumentation,
+		sseManager:      newSseManager(ctx),
+		ResourceMgr:     resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil),
+	}
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	stdioSession := NewStdioSession(server, inR, outW)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- stdioSession.Start(util.WithLogger(ctx, testLogger))
+	}()
+	out := bufio.NewReader(outR)
+
+	send := func(message string) {
+		t.Helper()
+		if _, err := fmt.Fprintln(inW, message); err != nil {
+			t.Fatalf("unable to write message: %s", err)
+		}
+	}
+	receive := func() map[string]any {
+		t.Helper()
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unable to read message: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("unable to unmarshal message %q: %s", line, err)
+		}
+		return got
+	}
+	wantProgress := func(token any, progress float64, message string) map[string]any {
+		return map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"method":  "notifications/progress",
+			"params": map[string]any{
+				"progressToken": token,
+				"progress":      progress,
+				"total":         float64(2),
+				"message":       message,
+			},
+		}
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	if got := receive(); got["id"] != float64(1) {
+		t.Fatalf("unexpected initialize response: %v", got)
+	}
+	send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	// the blocking tool reports progress and then waits to be cancelled
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"blocking_tool","arguments":{},"_meta":{"progressToken":"blocking"}}}`)
+	if got, want := receive(), wantProgress("blocking", 1, "halfway there"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected progress notification: got %v, want %v", got, want)
+	}
+	send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":2,"reason":"no longer needed"}}`)
+
+	// no response is sent for the cancelled request, so the next message is
+	// for the following request
+	send(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"progress_tool","arguments":{},"_meta":{"progressToken":7}}}`)
+	if got, want := receive(), wantProgress(float64(7), 1, "halfway there"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected progress notification: got %v, want %v", got, want)
+	}
+	if got, want := receive(), wantProgress(float64(7), 2, "done"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected progress notification: got %v, want %v", got, want)
+	}
+	if got := receive(); got["id"] != float64(3) {
+		t.Fatalf("unexpected tools/call response: %v", got)
+	}
+
+	inW.Close()
+	if err := <-errCh; err != nil {
+		t.Fatalf("unexpected error from stdio session: %s", err)
+	}
+}
+
+func TestHttpProgressStreaming(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool7, tool8}, []MockPrompt{prompt1})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	body := `{"jsonrpc":"2.0","id":"call","method":"tools/call","params":{"name":"progress_tool","arguments":{},"_meta":{"progressToken":"token"}}}`
+
+	t.Run("response is streamed when client accepts sse", func(t *testing.T) {
+		header := map[string]string{
+			"MCP-Protocol-Version": protocolVersion20250618,
+			"Accept":               "application/json, text/event-stream",
+		}
+		resp, respBody, err := runRequest(ts, http.MethodPost, "/", strings.NewReader(body), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+			t.Fatalf("unexpected content type: got %q, want %q", contentType, "text/event-stream")
+		}
+
+		var methods []string
+		for _, event := range strings.Split(strings.TrimSpace(string(respBody)), "\n\n") {
+			data, ok := strings.CutPrefix(event, "event: message\ndata: ")
+			if !ok {
+				t.Fatalf("unexpected event: %q", event)
+			}
+			var got map[string]any
+			if err := json.Unmarshal([]byte(data), &got); err != nil {
+				t.Fatalf("unable to unmarshal event data: %s", err)
+			}
+			method, _ := got["method"].(string)
+			if method == "" {
+				method = fmt.Sprintf("response:%v", got["id"])
+			}
+			methods = append(methods, method)
+		}
+		want := []string{"notifications/progress", "notifications/progress", "response:call"}
+		if !reflect.DeepEqual(methods, want) {
+			t.Fatalf("unexpected events: got %v, want %v", methods, want)
+		}
+	})
+
+	t.Run("progress is dropped when client only accepts json", func(t *testing.T) {
+		header := map[string]string{"MCP-Protocol-Version": protocolVersion20250618}
+		resp, respBody, err := runRequest(ts, http.MethodPost, "/", strings.NewReader(body), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: got %d", resp.StatusCode)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(respBody, &got); err != nil {
+			t.Fatalf("unable to unmarshal response: %s", err)
+		}
+		if got["id"] != "call" {
+			t.Fatalf("unexpected response: %v", got)
+		}
+	})
+}
+
+func TestMcpBatchRequests(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool7}, []MockPrompt{prompt1})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	batch := []any{
+		map[string]any{"jsonrpc": "1.0", "id": "invalid", "method": "foo"},
+		map[string]any{"jsonrpc": jsonrpcVersion, "method": "notifications/initialized"},
+		map[string]any{"jsonrpc": jsonrpcVersion, "id": "call-1", "method": "tools/call", "params": map[string]any{"name": "progress_tool"}},
+		map[string]any{"jsonrpc": jsonrpcVersion, "id": "call-2", "method": "tools/call", "params": map[string]any{"name": "no_params"}},
+		map[string]any{"jsonrpc": jsonrpcVersion, "id": "init", "method": "initialize", "params": map[string]any{"protocolVersion": protocolVersion20250326}},
+	}
+	toolResult := func(id, name string) map[string]any {
+		return map[string]any{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"result": map[string]any{
+				"content": []any{map[string]any{"type": "text", "text": fmt.Sprintf("%q", name)}},
+			},
+		}
+	}
+	rpcError := func(id any, code float64, message string) map[string]any {
+		return map[string]any{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"error":   map[string]any{"code": code, "message": message},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		header map[string]string
+		body   any
+		want   any
+	}{
+		{
+			name:   "batch with responses in order",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body:   batch,
+			want: []any{
+				rpcError("invalid", -32600, "invalid json-rpc version"),
+				toolResult("call-1", "progress_tool"),
+				toolResult("call-2", "no_params"),
+				rpcError("init", -32600, "initialize request must not be part of a batch"),
+			},
+		},
+		{
+			name:   "batch of notifications",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body:   []any{map[string]any{"jsonrpc": jsonrpcVersion, "method": "notifications/initialized"}},
+		},
+		{
+			name:   "empty batch",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body:   []any{},
+			want:   rpcError(nil, -32600, "empty batch request"),
+		},
+		{
+			name: "batch without protocol version",
+			body: []any{
+				map[string]any{"jsonrpc": jsonrpcVersion, "id": "call-1", "method": "tools/call", "params": map[string]any{"name": "no_params"}},
+			},
+			want: []any{toolResult("call-1", "no_params")},
+		},
+		{
+			name:   "batch with duplicate ids",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250326},
+			body: []any{
+				map[string]any{"jsonrpc": jsonrpcVersion, "id": 1, "method": "tools/call", "params": map[string]any{"name": "no_params"}},
+				map[string]any{"jsonrpc": jsonrpcVersion, "id": "1", "method": "tools/call", "params": map[string]any{"name": "no_params"}},
+				map[string]any{"jsonrpc": jsonrpcVersion, "id": 1, "method": "tools/call", "params": map[string]any{"name": "progress_tool"}},
+			},
+			want: rpcError(nil, -32600, "duplicate request id 1 in batch request"),
+		},
+		{
+			name:   "batch not supported after 2025-03-26",
+			header: map[string]string{"MCP-Protocol-Version": protocolVersion20250618},
+			body:   batch,
+			want:   rpcError(nil, -32600, "batch requests are not supported in protocol version 2025-06-18"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			resp, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if tc.want == nil {
+				if resp.StatusCode != http.StatusAccepted {
+					t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusAccepted)
+				}
+				return
+			}
+
+			var got any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			// a random uuid is generated for errors of the whole batch
+			if want, ok := tc.want.(map[string]any); ok && want["id"] == nil {
+				if gotMap, ok := got.(map[string]any); ok {
+					want["id"] = gotMap["id"]
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected response: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetResourcesNotifiesListChanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1})
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	server := &Server{
+		version:      fakeVersionString,
+		logger:       testLogger,
+		sseManager:   newSseManager(ctx),
+		httpSessions: newHttpSessionManager(ctx),
+		ResourceMgr:  resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil),
+	}
+
+	// connect a client of each transport
+	defaultSession := newHttpSession("default", protocolVersion20250618, "", "")
+	server.httpSessions.add(defaultSession)
+	tool1Session := newHttpSession("tool1", protocolVersion20250618, "tool1_only", "")
+	server.httpSessions.add(tool1Session)
+	sse := &sseSession{toolsetName: "tool2_only", done: make(chan struct{}), eventQueue: make(chan string, 100)}
+	server.sseManager.add("sse", sse)
+	var stdout bytes.Buffer
+	stdio := NewStdioSession(server, strings.NewReader(""), &stdout)
+	server.stdioSessions.Store(stdio.id, stdio)
+
+	// tool2 is replaced by tool3, which changes the default and tool2_only
+	// toolsets, and prompt2 is added to the default promptset
+	newToolsMap, newToolsets, newPromptsMap, newPromptsets := setUpResources(t, []MockTool{tool1, tool3}, []MockPrompt{prompt1, prompt2})
+	server.SetResources(ctx, nil, nil, nil, newToolsMap, newToolsets, newPromptsMap, newPromptsets, nil)
+
+	methods := func(messages []string) []string {
+		var got []string
+		for _, message := range messages {
+			var m map[string]any
+			if err := json.Unmarshal([]byte(message), &m); err != nil {
+				t.Fatalf("unable to unmarshal message %q: %s", message, err)
+			}
+			got = append(got, m["method"].(string))
+		}
+		return got
+	}
+	sessionMessages := func(session *httpSession) []string {
+		var messages []string
+		for _, event := range session.eventsAfter(0) {
+			messages = append(messages, string(event.data))
+		}
+		return messages
+	}
+	var sseMessages []string
+	for len(sse.eventQueue) > 0 {
+		event := <-sse.eventQueue
+		sseMessages = append(sseMessages, strings.TrimSuffix(strings.TrimPrefix(event, "event: message\ndata: "), "\n\n"))
+	}
+	stdioMessages := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+
+	both := []string{"notifications/tools/list_changed", "notifications/prompts/list_changed"}
+	testCases := []struct {
+		name     string
+		messages []string
+		want     []string
+	}{
+		{name: "http session of changed toolset", messages: sessionMessages(defaultSession), want: both},
+		{name: "http session of unchanged toolset", messages: sessionMessages(tool1Session), want: []string{"notifications/prompts/list_changed"}},
+		{name: "sse session", messages: sseMessages, want: both},
+		{name: "stdio session", messages: stdioMessages, want: both},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := methods(tc.messages); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected notifications: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// reloading the same resources does not notify clients
+	server.SetResources(ctx, nil, nil, nil, newToolsMap, newToolsets, newPromptsMap, newPromptsets, nil)
+	if got := len(defaultSession.eventsAfter(2)); got != 0 {
+		t.Fatalf("unexpected notifications after reloading unchanged resources: %d", got)
+	}
+}
+
+// mockToolConfig initializes a MockTool, and is used to attach policies to it.
+type mockToolConfig struct {
+	tool MockTool
+}
+
+func (c mockToolConfig) ToolConfigType() string {
+	return "mock"
+}
+
+func (c mockToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return c.tool, nil
+}
+
+func TestMcpToolPolicies(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2, tool6}, []MockPrompt{prompt1})
+	policyTool, err := tools.OptionsToolConfig{
+		ToolConfig: mockToolConfig{tool: tool2},
+		ToolOptions: tools.ToolOptions{
+			Policies: []tools.Policy{{Claim: "email", EndsWith: "@corp.com"}},
+		},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	toolsMap[tool2.Name] = policyTool
+	restrictedConfig := tools.ToolsetConfig{
+		Name:      "restricted",
+		ToolNames: []string{tool1.Name},
+		Policies:  []tools.Policy{{Claim: "groups", Contains: "dba"}},
+	}
+	restricted, err := restrictedConfig.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets[restricted.Name] = restricted
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		path           string
+		toolName       string
+		arguments      map[string]any
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:           "tool without policies",
+			path:           "/",
+			toolName:       tool6.Name,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "tool denied by policy",
+			path:           "/",
+			toolName:       tool2.Name,
+			arguments:      map[string]any{"param1": 1, "param2": 2},
+			wantStatusCode: http.StatusForbidden,
+			wantError:      `forbidden Tool call: denied by policy: claim "email" must end with "@corp.com": forbidden`,
+		},
+		{
+			name:           "toolset denied by policy",
+			path:           "/restricted",
+			toolName:       tool1.Name,
+			wantStatusCode: http.StatusForbidden,
+			wantError:      `forbidden Tool call: denied by policy: claim "groups" must contain "dba": forbidden`,
+		},
+		{
+			name:           "toolset policy does not apply through other toolsets",
+			path:           "/tool1_only",
+			toolName:       tool1.Name,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "toolset policy does not apply through default toolset",
+			path:           "/",
+			toolName:       tool1.Name,
+			wantStatusCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(jsonrpc.JSONRPCRequest{
+				Jsonrpc: jsonrpcVersion,
+				Id:      "tools-call",
+				Request: jsonrpc.Request{Method: "tools/call"},
+				Params:  map[string]any{"name": tc.toolName, "arguments": tc.arguments},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			header := map[string]string{"MCP-Protocol-Version": protocolVersion20250618}
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, bytes.NewBuffer(reqMarshal), header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatusCode {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatusCode, resp.StatusCode, string(body))
+			}
+			var got jsonrpc.JSONRPCError
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if got.Error.Message != tc.wantError {
+				t.Fatalf("unexpected error message: got %q, want %q", got.Error.Message, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestMcpResultLimits(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool6}, []MockPrompt{prompt1})
+	rowsTool := tool6
+	rowsTool.rows = 3
+	limitedTool, err := tools.OptionsToolConfig{
+		ToolConfig: mockToolConfig{tool: rowsTool},
+		ToolOptions: tools.ToolOptions{
+			ResultLimits: sqlutil.ResultLimits{MaxRows: 2},
+		},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	toolsMap[tool6.Name] = limitedTool
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(jsonrpc.JSONRPCRequest{
+		Jsonrpc: jsonrpcVersion,
+		Id:      "tools-call",
+		Request: jsonrpc.Request{Method: "tools/call"},
+		Params:  map[string]any{"name": tool6.Name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	header := map[string]string{"MCP-Protocol-Version": protocolVersion20250618}
+	_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	}
+	message := "The result was truncated to 2 rows because it exceeded maxRows. Refine the query, for example by adding filters, selecting fewer columns or using a LIMIT clause, to retrieve the remaining rows."
+	want := map[string]any{
+		"content": []any{
+			map[string]any{"type": "text", "text": `"output_schema_tool"`},
+			map[string]any{"type": "text", "text": `"output_schema_tool"`},
+			map[string]any{"type": "text", "text": message},
+		},
+		"structuredContent": map[string]any{
+			"result":    []any{"output_schema_tool", "output_schema_tool"},
+			"truncated": true,
+			"rowCount":  float64(2),
+		},
+	}
+	if !reflect.DeepEqual(got["result"], want) {
+		t.Fatalf("unexpected result: got %+v, want %+v", got["result"], want)
+	}
+}
+
+func TestMcpToolTimeout(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool8}, []MockPrompt{prompt1})
+	timeoutTool, err := tools.OptionsToolConfig{
+		ToolConfig:  mockToolConfig{tool: tool8},
+		ToolOptions: tools.ToolOptions{InvocationTimeout: "10ms"},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	toolsMap[tool8.Name] = timeoutTool
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(jsonrpc.JSONRPCRequest{
+		Jsonrpc: jsonrpcVersion,
+		Id:      "tools-call",
+		Request: jsonrpc.Request{Method: "tools/call"},
+		Params:  map[string]any{"name": tool8.Name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	header := map[string]string{"MCP-Protocol-Version": protocolVersion20250618}
+	_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	}
+	want := map[string]any{
+		"content": []any{
+			map[string]any{"type": "text", "text": "tool invocation exceeded its timeout of 10ms: context deadline exceeded"},
+		},
+		"isError": true,
+	}
+	if !reflect.DeepEqual(got["result"], want) {
+		t.Fatalf("unexpected result: got %+v, want %+v", got["result"], want)
+	}
+}
+
+// describingToolConfig is a mockToolConfig that derives its output columns.
+type describingToolConfig struct {
+	mockToolConfig
+	columns parameters.Parameters
+}
+
+func (c describingToolConfig) DescribeOutputColumns(context.Context, map[string]sources.Source) (parameters.Parameters, error) {
+	return c.columns, nil
+}
+
+func TestToolOutputSchemaOption(t *testing.T) {
+	columns := parameters.Parameters{parameters.NewStringParameter("name", "the name")}
+	testCases := []struct {
+		name    string
+		cfg     tools.ToolConfig
+		schema  *tools.OutputSchema
+		want    *parameters.McpToolsSchema
+		wantErr string
+	}{
+		{
+			name:   "columns",
+			cfg:    mockToolConfig{tool: tool1},
+			schema: &tools.OutputSchema{Columns: columns},
+			want:   tools.GetMcpOutputSchema(columns),
+		},
+		{
+			name:   "auto",
+			cfg:    describingToolConfig{mockToolConfig: mockToolConfig{tool: tool1}, columns: columns},
+			schema: &tools.OutputSchema{Auto: true},
+			want:   tools.GetMcpOutputSchema(columns),
+		},
+		{
+			name:    "auto without column metadata",
+			cfg:     mockToolConfig{tool: tool1},
+			schema:  &tools.OutputSchema{Auto: true},
+			wantErr: `tool type "mock" cannot derive its output schema, list the columns in outputSchema instead`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, err := tools.OptionsToolConfig{
+				ToolConfig:  tc.cfg,
+				ToolOptions: tools.ToolOptions{OutputSchema: tc.schema},
+			}.Initialize(nil)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to initialize tool: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tool.McpManifest().OutputSchema); diff != "" {
+				t.Fatalf("unexpected output schema (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMcpToolCallAuditLog(t *testing.T) {
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1})
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLogger, err := audit.New(context.Background(), audit.Config{Sinks: []string{path}}, nil)
+	if err != nil {
+		t.Fatalf("unable to create audit logger: %s", err)
+	}
+	defer auditLogger.Close()
+	r, shutdown := setUpAuditedServer(t, "mcp", auditLogger, toolsMap, toolsets, promptsMap, promptsets, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(jsonrpc.JSONRPCRequest{
+		Jsonrpc: jsonrpcVersion,
+		Id:      "tools-call",
+		Request: jsonrpc.Request{Method: "tools/call"},
+		Params:  map[string]any{"name": tool2.Name, "arguments": map[string]any{"param1": 1, "param2": 2}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	header := map[string]string{"MCP-Protocol-Version": protocolVersion20250618}
+	if _, _, err := runRequest(ts, http.MethodPost, "/tool2_only", bytes.NewBuffer(reqMarshal), header); err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read audit log: %s", err)
+	}
+	var got audit.Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unable to unmarshal audit event %q: %s", data, err)
+	}
+	rowCount := 1
+	want := audit.Event{
+		Transport:    audit.TransportMCP,
+		Toolset:      "tool2_only",
+		Tool:         tool2.Name,
+		AuthServices: []string{},
+		Parameters:   map[string]any{"param1": float64(1), "param2": float64(2)},
+		RowCount:     &rowCount,
+		Status:       "success",
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(audit.Event{}, "Timestamp", "DurationMs")); diff != "" {
+		t.Fatalf("unexpected audit event (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// registerMetricsCallback registers the callback observing the active MCP
+// sessions and the connection pools of the sources. Sources are read on every
+// collection, so that reloaded sources are observed.
+func (s *Server) registerMetricsCallback() (metric.Registration, error) {
+	i := s.instrumentation
+	return i.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stdioSessions := 0
+		s.stdioSessions.Range(func(_, _ any) bool {
+			stdioSessions++
+			return true
+		})
+		for transport, n := range map[string]int{
+			"sse":   len(s.sseManager.list()),
+			"http":  len(s.httpSessions.list()),
+			"stdio": stdioSessions,
+		} {
+			o.ObserveInt64(i.McpSessions, int64(n), metric.WithAttributes(attribute.String("toolbox.mcp.transport", transport)))
+		}
+
+		for name, src := range s.ResourceMgr.GetSourcesMap() {
+			p, ok := src.(sources.PoolStatsProvider)
+			if !ok {
+				continue
+			}
+			stats := p.PoolStats()
+			attrs := []attribute.KeyValue{
+				attribute.String("toolbox.source.name", name),
+				attribute.String("toolbox.source.type", src.SourceType()),
+			}
+			o.ObserveInt64(i.SourcePoolConnections, stats.InUse, metric.WithAttributes(attrs...), metric.WithAttributes(attribute.String("state", "in_use")))
+			o.ObserveInt64(i.SourcePoolConnections, stats.Idle, metric.WithAttributes(attrs...), metric.WithAttributes(attribute.String("state", "idle")))
+			o.ObserveInt64(i.SourcePoolMaxConnections, stats.Max, metric.WithAttributes(attrs...))
+		}
+		return nil
+	}, i.McpSessions, i.SourcePoolConnections, i.SourcePoolMaxConnections)
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+)
+
+// poolSource is a source with a connection pool.
+type poolSource struct{}
+
+func (poolSource) SourceType() string             { return "mock-pool" }
+func (poolSource) ToConfig() sources.SourceConfig { return nil }
+func (poolSource) PoolStats() sources.PoolStats   { return sources.PoolStats{InUse: 2, Idle: 3, Max: 10} }
+
+func TestPrometheusMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox", true)
+	if err != nil {
+		t.Fatalf("unable to setup otel: %s", err)
+	}
+	defer func() {
+		if err := otelShutdown(ctx); err != nil {
+			t.Fatalf("error shutting down OpenTelemetry: %s", err)
+		}
+	}()
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+
+	toolsMap, toolsets, _, _ := setUpResources(t, []MockTool{tool1, tool2}, nil)
+	s := &Server{
+		version:         fakeVersionString,
+		logger:          testLogger,
+		instrumentation: instrumentation,
+		sseManager:      newSseManager(ctx),
+		httpSessions:    newHttpSessionManager(ctx),
+		ResourceMgr:     resources.NewResourceManager(map[string]sources.Source{"my-pool": poolSource{}}, nil, nil, toolsMap, toolsets, nil, nil, nil),
+	}
+	registration, err := s.registerMetricsCallback()
+	if err != nil {
+		t.Fatalf("unable to register metrics callback: %s", err)
+	}
+	defer registration.Unregister()
+
+	r, err := apiRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize api router: %s", err)
+	}
+	ts := runServer(r, false)
+	defer ts.Close()
+	if _, _, err := runRequest(ts, http.MethodPost, "/tool/no_params/invoke", bytes.NewBufferString(`{}`), nil); err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+
+	handler := telemetry.PrometheusHandler()
+	if handler == nil {
+		t.Fatalf("expect prometheus handler to be set up")
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("unable to read metrics: %s", err)
+	}
+	got := string(body)
+	for _, want := range []string{
+		`toolbox_server_tool_invoke_duration_seconds_count{`,
+		`toolbox_name="no_params"`,
+		`toolbox_operation_status="success"`,
+		`toolbox_server_tool_result_rows_count{`,
+		`toolbox_server_tool_result_size_bytes_count{`,
+		`toolbox_server_tool_invoke_active{`,
+		`toolbox_server_mcp_sessions{`,
+		`toolbox_source_pool_connections{otel_scope_name="` + telemetry.MetricName,
+		`state="in_use",toolbox_source_name="my-pool",toolbox_source_type="mock-pool"} 2`,
+		`state="idle",toolbox_source_name="my-pool",toolbox_source_type="mock-pool"} 3`,
+		`toolbox_source_pool_max_connections{`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expect metrics to contain %q", want)
+		}
+	}
+	if t.Failed() {
+		t.Logf("metrics:\n%s", got)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// openAPIVersion is the version of the OpenAPI specification of the documents
+// served on /api/openapi.json. Schemas of OpenAPI 3.1 are JSON schemas, so the
+// parameter schemas can be used as is, including `const` and `type` arrays.
+const openAPIVersion = "3.1.0"
+
+// clientAuthScheme is the name of the security scheme of tools that require
+// the client's access token in the Authorization header.
+const clientAuthScheme = "clientAuthorization"
+
+// openAPIHandler handles the request for the OpenAPI document of a Toolset.
+func openAPIHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/openapi/get")
+	r = r.WithContext(ctx)
+
+	toolsetName := chi.URLParam(r, "toolsetName")
+	span.SetAttributes(attribute.String("toolset_name", toolsetName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	toolset, ok := s.ResourceMgr.GetToolset(toolsetName)
+	if !ok {
+		err = fmt.Errorf("toolset %q does not exist", toolsetName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	doc, err := openAPIDocument(s, toolset)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	render.JSON(w, r, doc)
+}
+
+// openAPIDocument builds the OpenAPI document describing the invoke endpoint
+// of each tool of the toolset. The endpoints of a named toolset are scoped to
+// the toolset.
+func openAPIDocument(s *Server, toolset tools.Toolset) (map[string]any, error) {
+	prefix := "/api"
+	title := "Toolbox"
+	if toolset.Name != "" {
+		prefix = fmt.Sprintf("/api/toolset/%s", toolset.Name)
+		title = fmt.Sprintf("Toolbox toolset %s", toolset.Name)
+	}
+
+	paths := make(map[string]any)
+	securitySchemes := make(map[string]any)
+	for _, toolName := range toolset.ToolNames {
+		tool, ok := s.ResourceMgr.GetTool(toolName)
+		if !ok {
+			return nil, fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		}
+		clientAuth, err := tool.RequiresClientAuthorization(s.ResourceMgr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check the authorization of tool %q: %w", toolName, err)
+		}
+
+		// Each group lists auth services of which one token is required: the
+		// authRequired of the tool, and the auth services of each parameter.
+		var groups [][]string
+		if authRequired := tool.Manifest().AuthRequired; len(authRequired) > 0 {
+			groups = append(groups, authRequired)
+		}
+		for _, p := range tool.GetParameters() {
+			var names []string
+			for _, as := range p.GetAuthServices() {
+				names = append(names, as.Name)
+			}
+			if len(names) > 0 {
+				groups = append(groups, names)
+			}
+		}
+		for _, group := range groups {
+			for _, name := range group {
+				securitySchemes[authHeaderName(name)] = map[string]any{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        authHeaderName(name),
+					"description": fmt.Sprintf("Token of the %q auth service.", name),
+				}
+			}
+		}
+		if clientAuth {
+			securitySchemes[clientAuthScheme] = map[string]any{
+				"type":        "http",
+				"scheme":      "bearer",
+				"description": "Access token of the client, used to access the source of the tool.",
+			}
+		}
+
+		operation := map[string]any{
+			"operationId": toolName,
+			"summary":     fmt.Sprintf("Invoke the %s tool", toolName),
+			"description": tool.Manifest().Description,
+			"requestBody": map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": tool.GetParameters().JSONSchema()},
+				},
+			},
+			"responses": map[string]any{
+				"200": openAPIResponse("Result of the tool invocation, encoded as a JSON string.", "InvokeResult"),
+				"400": openAPIResponse("The parameters are invalid or the tool invocation failed.", "Error"),
+				"401": openAPIResponse("An auth token is missing or invalid.", "Error"),
+				"403": openAPIResponse("The tool invocation is forbidden by an authorization policy.", "Error"),
+				"404": openAPIResponse("The tool does not exist.", "Error"),
+			},
+		}
+		if security := securityRequirements(groups, clientAuth); len(security) > 0 {
+			operation["security"] = security
+		}
+		paths[fmt.Sprintf("%s/tool/%s/invoke", prefix, toolName)] = map[string]any{"post": operation}
+	}
+
+	components := map[string]any{
+		"schemas": map[string]any{
+			"InvokeResult": map[string]any{
+				"type":     "object",
+				"required": []string{"result"},
+				"properties": map[string]any{
+					"result": map[string]any{"type": "string", "description": "JSON encoded result of the tool invocation."},
+				},
+			},
+			"Error": map[string]any{
+				"type":     "object",
+				"required": []string{"status"},
+				"properties": map[string]any{
+					"status": map[string]any{"type": "string", "description": "HTTP status text."},
+					"error":  map[string]any{"type": "string", "description": "Description of the error."},
+				},
+			},
+		},
+	}
+	if len(securitySchemes) > 0 {
+		components["securitySchemes"] = securitySchemes
+	}
+	return map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{
+			"title":   title,
+			"version": s.version,
+		},
+		"paths":      paths,
+		"components": components,
+	}, nil
+}
+
+// openAPIResponse returns an OpenAPI response whose content is the schema of
+// the components.
+func openAPIResponse(description, schemaName string) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/" + schemaName},
+			},
+		},
+	}
+}
+
+// authHeaderName returns the header carrying the token of an auth service.
+func authHeaderName(authServiceName string) string {
+	return authServiceName + "_token"
+}
+
+// securityRequirements returns the alternative sets of security schemes
+// satisfying the tool: one auth service of each group, and the client
+// authorization if it is required.
+func securityRequirements(groups [][]string, clientAuth bool) []map[string][]string {
+	reqs := [][]string{{}}
+	for _, group := range groups {
+		var next [][]string
+		for _, req := range reqs {
+			for _, name := range group {
+				next = append(next, append(append([]string{}, req...), authHeaderName(name)))
+			}
+		}
+		reqs = next
+	}
+	if clientAuth {
+		for i := range reqs {
+			reqs[i] = append(reqs[i], clientAuthScheme)
+		}
+	}
+
+	var rtn []map[string][]string
+	seen := make(map[string]bool)
+	for _, req := range reqs {
+		if len(req) == 0 {
+			continue
+		}
+		slices.Sort(req)
+		req = slices.Compact(req)
+		key := strings.Join(req, ",")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		r := make(map[string][]string, len(req))
+		for _, name := range req {
+			r[name] = []string{}
+		}
+		rtn = append(rtn, r)
+	}
+	return rtn
+}
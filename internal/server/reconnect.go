@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// reconnectSources starts a background loop reconnecting every source that
+// could not be initialized.
+func (s *Server) reconnectSources(sourcesMap map[string]sources.Source) {
+	if s.reconnectCtx == nil {
+		return
+	}
+	for name, src := range sourcesMap {
+		if us, ok := src.(*sources.UnavailableSource); ok {
+			go s.reconnectSource(name, us)
+		}
+	}
+}
+
+// reconnectSource attempts to initialize the source with an exponential
+// backoff until it succeeds, the source is replaced by a reload, or the server
+// shuts down.
+func (s *Server) reconnectSource(name string, us *sources.UnavailableSource) {
+	ctx := s.reconnectCtx
+	retry := sources.SourceOptionsOf(us.ToConfig()).Retry
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry.Backoff(attempt)):
+		}
+		if !s.isCurrentSource(name, us) {
+			return
+		}
+
+		src, err := func() (sources.Source, error) {
+			childCtx, span := s.instrumentation.Tracer.Start(
+				ctx,
+				"toolbox/server/source/reconnect",
+				trace.WithNewRoot(),
+				trace.WithAttributes(attribute.String("source_type", us.SourceType())),
+				trace.WithAttributes(attribute.String("source_name", name)),
+			)
+			defer span.End()
+			return us.Reconnect(childCtx, s.instrumentation.Tracer)
+		}()
+		if err != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("unable to reconnect source %q (attempt %d): %s", name, attempt, err))
+			continue
+		}
+		if s.restoreSource(ctx, name, us, src) {
+			s.logger.InfoContext(ctx, fmt.Sprintf("Reconnected source %q", name))
+		}
+		return
+	}
+}
+
+// isCurrentSource returns true if the unavailable source is still the source
+// of the server with the given name.
+func (s *Server) isCurrentSource(name string, us *sources.UnavailableSource) bool {
+	cur, _ := s.ResourceMgr.GetSource(name)
+	curUs, ok := cur.(*sources.UnavailableSource)
+	return ok && curUs == us
+}
+
+// restoreSource replaces the unavailable source with the reconnected source,
+// and the unavailable tools of the source with initialized tools. It returns
+// false if the unavailable source was replaced in the meantime.
+func (s *Server) restoreSource(ctx context.Context, name string, us *sources.UnavailableSource, src sources.Source) bool {
+	s.resourcesMu.Lock()
+	defer s.resourcesMu.Unlock()
+	if !s.isCurrentSource(name, us) {
+		return false
+	}
+
+	sourcesMap := s.ResourceMgr.GetSourcesMap()
+	sourcesMap[name] = src
+
+	toolsMap := s.ResourceMgr.GetToolsMap()
+	for toolName, t := range toolsMap {
+		cfg, sourceName, ok := tools.UnavailableToolConfig(t)
+		if !ok || sourceName != name {
+			continue
+		}
+		nt, err := cfg.Initialize(sourcesMap)
+		if err != nil {
+			s.logger.ErrorContext(ctx, fmt.Sprintf("unable to initialize tool %q of reconnected source %q: %s", toolName, name, err))
+			continue
+		}
+		toolsMap[toolName] = nt
+	}
+
+	// toolsets hold the manifests of their tools, which may have changed
+	toolsetsMap := s.ResourceMgr.GetToolsetsMap()
+	for toolsetName, ts := range toolsetsMap {
+		nts, err := ts.ToConfig().Initialize(s.version, toolsMap)
+		if err != nil {
+			s.logger.ErrorContext(ctx, fmt.Sprintf("unable to initialize toolset %q after reconnecting source %q: %s", toolsetName, name, err))
+			continue
+		}
+		toolsetsMap[toolsetName] = nts
+	}
+
+	s.setResources(ctx, sourcesMap, s.ResourceMgr.GetAuthServiceMap(), s.ResourceMgr.GetEmbeddingModelMap(), toolsMap, toolsetsMap, s.ResourceMgr.GetPromptsMap(), s.ResourceMgr.GetPromptsetsMap(), s.ResourceMgr.GetResourcesMap())
+	return true
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// flakySourceConfig is the config of a source that fails to initialize until
+// it is up. If failures is set, it fails that many times instead.
+type flakySourceConfig struct {
+	up       *atomic.Bool
+	failures *atomic.Int32
+}
+
+type flakySource struct{}
+
+func (flakySource) SourceType() string             { return "mock-flaky" }
+func (flakySource) ToConfig() sources.SourceConfig { return nil }
+
+func (flakySourceConfig) SourceConfigType() string { return "mock-flaky" }
+
+func (c flakySourceConfig) Initialize(_ context.Context, _ trace.Tracer) (sources.Source, error) {
+	if c.failures != nil && c.failures.Add(-1) >= 0 {
+		return nil, fmt.Errorf("connection refused")
+	}
+	if c.up != nil && !c.up.Load() {
+		return nil, fmt.Errorf("connection refused")
+	}
+	return flakySource{}, nil
+}
+
+// flakyToolConfig is the config of a tool that requires a flakySource to be
+// initialized.
+type flakyToolConfig struct {
+	Name        string `yaml:"name"`
+	Source      string `yaml:"source"`
+	Description string `yaml:"description"`
+}
+
+func (flakyToolConfig) ToolConfigType() string { return "mock-flaky-tool" }
+
+func (c flakyToolConfig) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	if _, ok := srcs[c.Source].(flakySource); !ok {
+		return nil, fmt.Errorf("no source named %q configured", c.Source)
+	}
+	return MockTool{Name: c.Name, Description: c.Description}, nil
+}
+
+func newReconnectTestContext(t *testing.T) context.Context {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	return util.WithInstrumentation(ctx, instrumentation)
+}
+
+func TestLazySourceReconnect(t *testing.T) {
+	ctx := newReconnectTestContext(t)
+
+	up := &atomic.Bool{}
+	cfg := ServerConfig{
+		Version: fakeVersionString,
+		SourceConfigs: SourceConfigs{
+			"my-flaky": sources.OptionsSourceConfig{
+				SourceConfig: flakySourceConfig{up: up},
+				SourceOptions: sources.SourceOptions{
+					LazyInit: true,
+					Retry:    sources.RetryOptions{InitialBackoff: "10ms", MaxBackoff: "10ms"},
+				},
+			},
+		},
+		ToolConfigs: ToolConfigs{
+			"my-tool": flakyToolConfig{Name: "my-tool", Source: "my-flaky", Description: "a flaky tool"},
+		},
+	}
+	s, err := NewServer(ctx, cfg)
+	if err != nil {
+		t.Fatalf("unable to initialize server: %s", err)
+	}
+	defer func() { _ = s.Shutdown(ctx) }()
+
+	tool, ok := s.ResourceMgr.GetTool("my-tool")
+	if !ok {
+		t.Fatalf("tool of unavailable source is missing")
+	}
+	_, err = tool.Invoke(ctx, s.ResourceMgr, nil, "")
+	wantErr := `tool "my-tool" is unavailable: source "my-flaky" could not be initialized`
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("unexpected error: got %v, want %q", err, wantErr)
+	}
+	toolset, _ := s.ResourceMgr.GetToolset("")
+	if got := toolset.Manifest.ToolsManifest["my-tool"].Description; got != "a flaky tool" {
+		t.Fatalf("unexpected description of unavailable tool: %q", got)
+	}
+	if res := s.checkSources(ctx); res.Status != healthStatusUnavailable {
+		t.Fatalf("server is ready while its source is unavailable")
+	}
+
+	up.Store(true)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		tool, _ = s.ResourceMgr.GetTool("my-tool")
+		if _, _, unavailable := tools.UnavailableToolConfig(tool); !unavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tool was not restored after the source recovered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := tool.Invoke(ctx, s.ResourceMgr, nil, ""); err != nil {
+		t.Fatalf("unexpected error invoking restored tool: %s", err)
+	}
+	src, _ := s.ResourceMgr.GetSource("my-flaky")
+	if _, ok := src.(flakySource); !ok {
+		t.Fatalf("source was not restored: %T", src)
+	}
+	toolset, _ = s.ResourceMgr.GetToolset("")
+	if _, ok := toolset.Manifest.ToolsManifest["my-tool"]; !ok {
+		t.Fatalf("restored tool is missing from the default toolset")
+	}
+}
+
+func TestInitializeSourceRetry(t *testing.T) {
+	tcs := []struct {
+		name     string
+		failures int32
+		opts     sources.SourceOptions
+		wantErr  string
+	}{
+		{
+			name:     "succeeds after retries",
+			failures: 2,
+			opts:     sources.SourceOptions{Retry: sources.RetryOptions{MaxAttempts: 3, InitialBackoff: "1ms"}},
+		},
+		{
+			name:     "fails after retries",
+			failures: 3,
+			opts:     sources.SourceOptions{Retry: sources.RetryOptions{MaxAttempts: 3, InitialBackoff: "1ms"}},
+			wantErr:  `unable to initialize source "my-flaky": connection refused (after 3 attempts)`,
+		},
+		{
+			name:     "fails without retries",
+			failures: 1,
+			wantErr:  `unable to initialize source "my-flaky": connection refused`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newReconnectTestContext(t)
+			failures := &atomic.Int32{}
+			failures.Store(tc.failures)
+			var sc sources.SourceConfig = flakySourceConfig{failures: failures}
+			if !tc.opts.IsZero() {
+				sc = sources.OptionsSourceConfig{SourceConfig: sc, SourceOptions: tc.opts}
+			}
+			_, _, _, _, _, _, _, _, err := InitializeConfigs(ctx, ServerConfig{
+				Version:       fakeVersionString,
+				SourceConfigs: SourceConfigs{"my-flaky": sc},
+			})
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	r := sources.RetryOptions{InitialBackoff: "1s", MaxBackoff: "5s"}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := r.Backoff(i + 1); got != w {
+			t.Errorf("unexpected backoff of attempt %d: got %s, want %s", i+1, got, w)
+		}
+	}
+}
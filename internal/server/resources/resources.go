@@ -19,6 +19,7 @@ import (
 
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -34,6 +35,7 @@ type ResourceManager struct {
 	toolsets        map[string]tools.Toolset
 	prompts         map[string]prompts.Prompt
 	promptsets      map[string]prompts.Promptset
+	resources       map[string]mcpresources.Resource
 }
 
 func NewResourceManager(
@@ -42,7 +44,7 @@ func NewResourceManager(
 	embeddingModelsMap map[string]embeddingmodels.EmbeddingModel,
 	toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset,
 	promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset,
-
+	resourcesMap map[string]mcpresources.Resource,
 ) *ResourceManager {
 	resourceMgr := &ResourceManager{
 		mu:              sync.RWMutex{},
@@ -53,6 +55,7 @@ func NewResourceManager(
 		toolsets:        toolsetsMap,
 		prompts:         promptsMap,
 		promptsets:      promptsetsMap,
+		resources:       resourcesMap,
 	}
 
 	return resourceMgr
@@ -107,7 +110,14 @@ func (r *ResourceManager) GetPromptset(promptsetName string) (prompts.Promptset,
 	return promptset, ok
 }
 
-func (r *ResourceManager) SetResources(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset) {
+func (r *ResourceManager) GetResource(resourceName string) (mcpresources.Resource, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	resource, ok := r.resources[resourceName]
+	return resource, ok
+}
+
+func (r *ResourceManager) SetResources(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset, resourcesMap map[string]mcpresources.Resource) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.sources = sourcesMap
@@ -117,6 +127,17 @@ func (r *ResourceManager) SetResources(sourcesMap map[string]sources.Source, aut
 	r.toolsets = toolsetsMap
 	r.prompts = promptsMap
 	r.promptsets = promptsetsMap
+	r.resources = resourcesMap
+}
+
+func (r *ResourceManager) GetSourcesMap() map[string]sources.Source {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	copiedMap := make(map[string]sources.Source, len(r.sources))
+	for k, v := range r.sources {
+		copiedMap[k] = v
+	}
+	return copiedMap
 }
 
 func (r *ResourceManager) GetAuthServiceMap() map[string]auth.AuthService {
@@ -149,6 +170,16 @@ func (r *ResourceManager) GetToolsMap() map[string]tools.Tool {
 	return copiedMap
 }
 
+func (r *ResourceManager) GetToolsetsMap() map[string]tools.Toolset {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	copiedMap := make(map[string]tools.Toolset, len(r.toolsets))
+	for k, v := range r.toolsets {
+		copiedMap[k] = v
+	}
+	return copiedMap
+}
+
 func (r *ResourceManager) GetPromptsMap() map[string]prompts.Prompt {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -158,3 +189,23 @@ func (r *ResourceManager) GetPromptsMap() map[string]prompts.Prompt {
 	}
 	return copiedMap
 }
+
+func (r *ResourceManager) GetPromptsetsMap() map[string]prompts.Promptset {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	copiedMap := make(map[string]prompts.Promptset, len(r.promptsets))
+	for k, v := range r.promptsets {
+		copiedMap[k] = v
+	}
+	return copiedMap
+}
+
+func (r *ResourceManager) GetResourcesMap() map[string]mcpresources.Resource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	copiedMap := make(map[string]mcpresources.Resource, len(r.resources))
+	for k, v := range r.resources {
+		copiedMap[k] = v
+	}
+	return copiedMap
+}
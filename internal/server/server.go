@@ -20,18 +20,22 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httplog/v2"
+	"github.com/googleapis/genai-toolbox/internal/audit"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/mcpresources"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -39,6 +43,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -51,6 +56,32 @@ type Server struct {
 	logger          log.Logger
 	instrumentation *telemetry.Instrumentation
 	sseManager      *sseManager
+	httpSessions    *httpSessionManager
+	// stdioSessions holds the active stdio sessions, keyed by session id
+	stdioSessions sync.Map
+	// stdioToolset and stdioPromptset are the toolset and promptset served
+	// to stdio sessions
+	stdioToolset   string
+	stdioPromptset string
+	// mcpBatchConcurrency limits the number of messages of a JSON-RPC batch
+	// that are processed concurrently
+	mcpBatchConcurrency int
+	inflight            inflightRequests
+	// audit records the invocations of tools, it is nil if auditing is
+	// disabled
+	audit *audit.Logger
+	// metricsRegistration is the callback observing the gauges of the server
+	metricsRegistration metric.Registration
+	// optionalSources are the sources that do not affect readiness
+	optionalSources  map[string]struct{}
+	readinessTimeout time.Duration
+	// resourcesMu serializes the replacement of the resources by reloads and
+	// reconnected sources
+	resourcesMu sync.Mutex
+	// reconnectCtx is the context of the loops reconnecting unavailable
+	// sources, it is cancelled on shutdown
+	reconnectCtx    context.Context
+	cancelReconnect context.CancelFunc
 	ResourceMgr     *resources.ResourceManager
 }
 
@@ -62,6 +93,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	map[string]tools.Toolset,
 	map[string]prompts.Prompt,
 	map[string]prompts.Promptset,
+	map[string]mcpresources.Resource,
 	error,
 ) {
 	metadataStr := cfg.Version
@@ -90,14 +122,19 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 				trace.WithAttributes(attribute.String("source_name", name)),
 			)
 			defer span.End()
-			s, err := sc.Initialize(childCtx, instrumentation.Tracer)
+			s, err := initializeSource(childCtx, l, instrumentation.Tracer, name, sc)
 			if err != nil {
-				return nil, fmt.Errorf("unable to initialize source %q: %w", name, err)
+				err = fmt.Errorf("unable to initialize source %q: %w", name, err)
+				if !sources.SourceOptionsOf(sc).LazyInit {
+					return nil, err
+				}
+				l.WarnContext(ctx, fmt.Sprintf("%s; its tools are unavailable until the source is reconnected", err))
+				return sources.NewUnavailableSource(sc, err), nil
 			}
 			return s, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		sourcesMap[name] = s
 	}
@@ -125,7 +162,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return a, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		authServicesMap[name] = a
 	}
@@ -153,7 +190,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return em, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		embeddingModelsMap[name] = em
 	}
@@ -174,6 +211,9 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 				trace.WithAttributes(attribute.String("tool_name", name)),
 			)
 			defer span.End()
+			if us, ok := sourcesMap[tools.ConfigSourceName(tc)].(*sources.UnavailableSource); ok {
+				return tools.NewUnavailableTool(name, tc, sourcesMap, us), nil
+			}
 			t, err := tc.Initialize(sourcesMap)
 			if err != nil {
 				return nil, fmt.Errorf("unable to initialize tool %q: %w", name, err)
@@ -181,7 +221,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return t, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		toolsMap[name] = t
 	}
@@ -199,7 +239,12 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	if cfg.ToolsetConfigs == nil {
 		cfg.ToolsetConfigs = make(ToolsetConfigs)
 	}
-	cfg.ToolsetConfigs[""] = tools.ToolsetConfig{Name: "", ToolNames: allToolNames}
+	// the default toolset also contains all resources
+	allResourceNames := make([]string, 0, len(cfg.ResourceConfigs))
+	for name := range cfg.ResourceConfigs {
+		allResourceNames = append(allResourceNames, name)
+	}
+	cfg.ToolsetConfigs[""] = tools.ToolsetConfig{Name: "", ToolNames: allToolNames, ResourceNames: allResourceNames}
 
 	// initialize and validate the toolsets from configs
 	toolsetsMap := make(map[string]tools.Toolset)
@@ -218,7 +263,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return t, err
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		toolsetsMap[name] = t
 	}
@@ -250,7 +295,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return p, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		promptsMap[name] = p
 	}
@@ -287,7 +332,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return p, err
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		promptsetsMap[name] = p
 	}
@@ -301,7 +346,70 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d promptsets: %s", len(promptsetsMap), strings.Join(promptsetNames, ", ")))
 
-	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+	// initialize and validate the MCP resources from configs
+	resourcesMap := make(map[string]mcpresources.Resource)
+	for name, rc := range cfg.ResourceConfigs {
+		r, err := func() (mcpresources.Resource, error) {
+			_, span := instrumentation.Tracer.Start(
+				ctx,
+				"toolbox/server/resource/init",
+				trace.WithAttributes(attribute.String("resource_type", rc.ResourceConfigType())),
+				trace.WithAttributes(attribute.String("resource_name", name)),
+			)
+			defer span.End()
+			r, err := rc.Initialize(sourcesMap)
+			if err != nil {
+				return nil, fmt.Errorf("unable to initialize resource %q: %w", name, err)
+			}
+			return r, nil
+		}()
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		resourcesMap[name] = r
+	}
+	resourceNames := make([]string, 0, len(resourcesMap))
+	for name := range resourcesMap {
+		resourceNames = append(resourceNames, name)
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d resources: %s", len(resourcesMap), strings.Join(resourceNames, ", ")))
+
+	// check that the resources of each toolset exist
+	for name, t := range toolsetsMap {
+		for _, resourceName := range t.ResourceNames {
+			if _, ok := resourcesMap[resourceName]; !ok {
+				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize toolset %q: resource does not exist: %s", name, resourceName)
+			}
+		}
+	}
+
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, nil
+}
+
+// initializeSource initializes a source, retrying with an exponential backoff
+// as configured by its source options.
+func initializeSource(ctx context.Context, l log.Logger, tracer trace.Tracer, name string, sc sources.SourceConfig) (sources.Source, error) {
+	retry := sources.SourceOptionsOf(sc).Retry
+	attempts := retry.Attempts()
+	for attempt := 1; ; attempt++ {
+		s, err := sc.Initialize(ctx, tracer)
+		if err == nil {
+			return s, nil
+		}
+		if attempt >= attempts {
+			if attempts > 1 {
+				return nil, fmt.Errorf("%w (after %d attempts)", err, attempts)
+			}
+			return nil, err
+		}
+		backoff := retry.Backoff(attempt)
+		l.WarnContext(ctx, fmt.Sprintf("unable to initialize source %q (attempt %d of %d), retrying in %s: %s", name, attempt, attempts, backoff, err))
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+	}
 }
 
 func hostCheck(allowedHosts map[string]struct{}) func(http.Handler) http.Handler {
@@ -373,7 +481,7 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	httpLogger := httplog.NewLogger("httplog", httpOpts)
 	r.Use(httplog.RequestLogger(httpLogger))
 
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := InitializeConfigs(ctx, cfg)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := InitializeConfigs(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize configs: %w", err)
 	}
@@ -383,16 +491,53 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	if cfg.Stdio && slices.Contains(cfg.Audit.Sinks, "stdout") {
+		return nil, fmt.Errorf("the stdout audit sink cannot be used with --stdio since stdout carries the MCP protocol")
+	}
+	if (cfg.StdioToolset != "" || cfg.StdioPromptset != "") && !cfg.Stdio {
+		return nil, fmt.Errorf("a toolset or promptset can only be selected with --stdio, HTTP clients select them with the /mcp/{toolsetName}/{promptsetName} endpoint")
+	}
+	if _, ok := toolsetsMap[cfg.StdioToolset]; !ok {
+		return nil, fmt.Errorf("toolset %q does not exist", cfg.StdioToolset)
+	}
+	if _, ok := promptsetsMap[cfg.StdioPromptset]; !ok {
+		return nil, fmt.Errorf("promptset %q does not exist", cfg.StdioPromptset)
+	}
+	auditLogger, err := audit.New(ctx, cfg.Audit, l)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize audit log: %w", err)
+	}
+
+	optionalSources := make(map[string]struct{}, len(cfg.OptionalSources))
+	for _, name := range cfg.OptionalSources {
+		if _, ok := sourcesMap[name]; !ok {
+			l.WarnContext(ctx, fmt.Sprintf("optional source %q is not configured", name))
+		}
+		optionalSources[name] = struct{}{}
+	}
+
+	resourceManager := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
 
 	s := &Server{
-		version:         cfg.Version,
-		srv:             srv,
-		root:            r,
-		logger:          l,
-		instrumentation: instrumentation,
-		sseManager:      sseManager,
-		ResourceMgr:     resourceManager,
+		version:             cfg.Version,
+		srv:                 srv,
+		root:                r,
+		logger:              l,
+		instrumentation:     instrumentation,
+		sseManager:          sseManager,
+		httpSessions:        newHttpSessionManager(ctx),
+		stdioToolset:        cfg.StdioToolset,
+		stdioPromptset:      cfg.StdioPromptset,
+		mcpBatchConcurrency: cfg.McpBatchConcurrency,
+		audit:               auditLogger,
+		optionalSources:     optionalSources,
+		readinessTimeout:    cfg.ReadinessTimeout,
+		ResourceMgr:         resourceManager,
+	}
+
+	s.metricsRegistration, err = s.registerMetricsCallback()
+	if err != nil {
+		return nil, fmt.Errorf("unable to register metrics callback: %w", err)
 	}
 
 	// cors
@@ -440,14 +585,70 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 		}
 		r.Mount("/ui", webR)
 	}
+	if cfg.TelemetryPrometheus {
+		h := telemetry.PrometheusHandler()
+		if h == nil {
+			return nil, fmt.Errorf("prometheus exporter is not set up")
+		}
+		r.Handle("/metrics", h)
+	}
+	// liveness and readiness probes
+	r.Get("/healthz", healthzHandler)
+	r.Get("/readyz", readyzHandler(s))
 	// default endpoint for validating server is running
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("🧰 Hello, World! 🧰"))
 	})
 
+	s.reconnectCtx, s.cancelReconnect = context.WithCancel(ctx)
+	s.reconnectSources(sourcesMap)
+
 	return s, nil
 }
 
+// SetResources replaces the resources of the server, e.g. after a dynamic
+// reload. Connected MCP clients are notified if their toolset or promptset
+// changed.
+func (s *Server) SetResources(ctx context.Context, sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset, resourcesMap map[string]mcpresources.Resource) {
+	s.resourcesMu.Lock()
+	s.setResources(ctx, sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
+	s.resourcesMu.Unlock()
+	s.reconnectSources(sourcesMap)
+}
+
+// setResources replaces the resources of the server. The caller must hold
+// resourcesMu.
+func (s *Server) setResources(ctx context.Context, sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset, resourcesMap map[string]mcpresources.Resource) {
+	oldToolsets := s.ResourceMgr.GetToolsetsMap()
+	oldPromptsets := s.ResourceMgr.GetPromptsetsMap()
+	s.ResourceMgr.SetResources(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
+
+	changedToolsets := changedSets(oldToolsets, toolsetsMap, func(t tools.Toolset) any { return t.McpManifest })
+	changedPromptsets := changedSets(oldPromptsets, promptsetsMap, func(p prompts.Promptset) any { return p.McpManifest })
+	if len(changedToolsets) == 0 && len(changedPromptsets) == 0 {
+		return
+	}
+	s.notifyListChanged(ctx, changedToolsets, changedPromptsets)
+}
+
+// changedSets returns the names of the sets (toolsets or promptsets) that were
+// added, removed or whose MCP manifest changed.
+func changedSets[T any](oldSets, newSets map[string]T, manifest func(T) any) map[string]bool {
+	changed := make(map[string]bool)
+	for name, oldSet := range oldSets {
+		newSet, ok := newSets[name]
+		if !ok || !reflect.DeepEqual(manifest(oldSet), manifest(newSet)) {
+			changed[name] = true
+		}
+	}
+	for name := range newSets {
+		if _, ok := oldSets[name]; !ok {
+			changed[name] = true
+		}
+	}
+	return changed
+}
+
 // Listen starts a listener for the given Server instance.
 func (s *Server) Listen(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
@@ -474,6 +675,8 @@ func (s *Server) Serve(ctx context.Context) error {
 // ServeStdio starts a new stdio session for mcp.
 func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writer) error {
 	stdioServer := NewStdioSession(s, stdin, stdout)
+	stdioServer.toolsetName = s.stdioToolset
+	stdioServer.promptsetName = s.stdioPromptset
 	return stdioServer.Start(ctx)
 }
 
@@ -481,5 +684,17 @@ func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writ
 // connections. It uses http.Server.Shutdown() and has the same functionality.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.DebugContext(ctx, "shutting down the server.")
-	return s.srv.Shutdown(ctx)
+	if s.cancelReconnect != nil {
+		s.cancelReconnect()
+	}
+	err := s.srv.Shutdown(ctx)
+	if s.metricsRegistration != nil {
+		if unregErr := s.metricsRegistration.Unregister(); unregErr != nil {
+			s.logger.ErrorContext(ctx, fmt.Sprintf("unable to unregister metrics callback: %s", unregErr))
+		}
+	}
+	if closeErr := s.audit.Close(); closeErr != nil {
+		s.logger.ErrorContext(ctx, fmt.Sprintf("unable to close audit log: %s", closeErr))
+	}
+	return err
 }
@@ -49,7 +49,7 @@ func TestServe(t *testing.T) {
 		AllowedHosts: []string{"*"},
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "toolbox", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -165,7 +165,7 @@ func TestUpdateServer(t *testing.T) {
 			Prompts: []*prompts.Prompt{},
 		},
 	}
-	s.ResourceMgr.SetResources(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets)
+	s.ResourceMgr.SetResources(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets, nil)
 	if err != nil {
 		t.Errorf("error updating server: %s", err)
 	}
@@ -259,3 +259,58 @@ func TestNameValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestStdioToolsetValidation(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("error setting up logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation("0.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	testCases := []struct {
+		desc   string
+		cfg    server.ServerConfig
+		errStr string
+	}{
+		{
+			desc: "default toolset and promptset",
+			cfg:  server.ServerConfig{Stdio: true},
+		},
+		{
+			desc:   "toolset without stdio",
+			cfg:    server.ServerConfig{StdioToolset: "my-toolset"},
+			errStr: "a toolset or promptset can only be selected with --stdio",
+		},
+		{
+			desc:   "missing toolset",
+			cfg:    server.ServerConfig{Stdio: true, StdioToolset: "my-toolset"},
+			errStr: `toolset "my-toolset" does not exist`,
+		},
+		{
+			desc:   "missing promptset",
+			cfg:    server.ServerConfig{Stdio: true, StdioPromptset: "my-promptset"},
+			errStr: `promptset "my-promptset" does not exist`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Version = "0.0.0"
+			tc.cfg.Address, tc.cfg.Port = "127.0.0.1", 5000
+			s, err := server.NewServer(ctx, tc.cfg)
+			if tc.errStr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				_ = s.Shutdown(ctx)
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.errStr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.errStr)
+			}
+		})
+	}
+}
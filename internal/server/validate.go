@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// ValidateReferences checks that the resources referenced by the configs
+// exist, such as the source of a tool or the tools of a toolset, and that the
+// source of each tool is of a compatible type. Unlike
+// InitializeConfigs, it does not connect to the sources. All the invalid
+// references are reported in the error.
+func ValidateReferences(cfg ServerConfig) error {
+	var problems []string
+	for name, tc := range cfg.ToolConfigs {
+		if sourceName := tools.ConfigSourceName(tc); sourceName != "" {
+			sc, ok := cfg.SourceConfigs[sourceName]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("tool %q references source %q, which does not exist", name, sourceName))
+			} else if impl, ok := sources.Implementation(sc.SourceConfigType()); ok && !tools.IsCompatibleSource(tc.ToolConfigType(), impl) {
+				problems = append(problems, fmt.Sprintf("tool %q of type %q references source %q of type %q, which is not compatible", name, tc.ToolConfigType(), sourceName, sc.SourceConfigType()))
+			}
+		}
+		for _, authName := range tools.ConfigAuthRequired(tc) {
+			if _, ok := cfg.AuthServiceConfigs[authName]; !ok {
+				problems = append(problems, fmt.Sprintf("tool %q requires auth service %q, which does not exist", name, authName))
+			}
+		}
+		for _, p := range tools.ConfigParameters(tc) {
+			for _, as := range p.GetAuthServices() {
+				if _, ok := cfg.AuthServiceConfigs[as.Name]; !ok {
+					problems = append(problems, fmt.Sprintf("parameter %q of tool %q references auth service %q, which does not exist", p.GetName(), name, as.Name))
+				}
+			}
+			if modelName := p.GetEmbeddedBy(); modelName != "" {
+				if _, ok := cfg.EmbeddingModelConfigs[modelName]; !ok {
+					problems = append(problems, fmt.Sprintf("parameter %q of tool %q references embedding model %q, which does not exist", p.GetName(), name, modelName))
+				}
+			}
+		}
+	}
+	for name, tc := range cfg.ToolsetConfigs {
+		for _, toolName := range tc.ToolNames {
+			if _, ok := cfg.ToolConfigs[toolName]; !ok {
+				problems = append(problems, fmt.Sprintf("toolset %q references tool %q, which does not exist", name, toolName))
+			}
+		}
+		for _, resourceName := range tc.ResourceNames {
+			if _, ok := cfg.ResourceConfigs[resourceName]; !ok {
+				problems = append(problems, fmt.Sprintf("toolset %q references resource %q, which does not exist", name, resourceName))
+			}
+		}
+	}
+	for name, pc := range cfg.PromptsetConfigs {
+		for _, promptName := range pc.PromptNames {
+			if _, ok := cfg.PromptConfigs[promptName]; !ok {
+				problems = append(problems, fmt.Sprintf("promptset %q references prompt %q, which does not exist", name, promptName))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid references:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
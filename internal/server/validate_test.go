@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestValidateReferences(t *testing.T) {
+	newCfg := func() server.ServerConfig {
+		return server.ServerConfig{
+			SourceConfigs: server.SourceConfigs{
+				"my-pg": postgres.Config{Name: "my-pg", Type: postgres.SourceType},
+			},
+			ToolConfigs: server.ToolConfigs{
+				"my-tool": postgressql.Config{
+					Name:      "my-tool",
+					Type:      "postgres-sql",
+					Source:    "my-pg",
+					Statement: "SELECT 1",
+				},
+			},
+			ToolsetConfigs: server.ToolsetConfigs{
+				"my-toolset": tools.ToolsetConfig{Name: "my-toolset", ToolNames: []string{"my-tool"}},
+			},
+		}
+	}
+
+	tcs := []struct {
+		desc    string
+		modify  func(*server.ServerConfig)
+		wantErr []string
+	}{
+		{
+			desc:   "valid references",
+			modify: func(*server.ServerConfig) {},
+		},
+		{
+			desc: "missing source",
+			modify: func(cfg *server.ServerConfig) {
+				delete(cfg.SourceConfigs, "my-pg")
+			},
+			wantErr: []string{`tool "my-tool" references source "my-pg", which does not exist`},
+		},
+		{
+			desc: "missing tool and auth services",
+			modify: func(cfg *server.ServerConfig) {
+				cfg.ToolConfigs["my-tool"] = tools.OptionsToolConfig{ToolConfig: postgressql.Config{
+					Name:         "my-tool",
+					Type:         "postgres-sql",
+					Source:       "my-pg",
+					Statement:    "SELECT 1",
+					AuthRequired: []string{"my-auth"},
+					Parameters: parameters.Parameters{
+						parameters.NewStringParameterWithAuth("email", "user email", []parameters.ParamAuthService{{Name: "my-other-auth", Field: "email"}}),
+					},
+				}}
+				cfg.ToolsetConfigs["my-toolset"] = tools.ToolsetConfig{Name: "my-toolset", ToolNames: []string{"my-tool", "missing-tool"}}
+			},
+			wantErr: []string{
+				`parameter "email" of tool "my-tool" references auth service "my-other-auth", which does not exist`,
+				`tool "my-tool" requires auth service "my-auth", which does not exist`,
+				`toolset "my-toolset" references tool "missing-tool", which does not exist`,
+			},
+		},
+		{
+			desc: "incompatible source",
+			modify: func(cfg *server.ServerConfig) {
+				cfg.SourceConfigs["my-pg"] = mysql.Config{Name: "my-pg", Type: mysql.SourceType}
+			},
+			wantErr: []string{`tool "my-tool" of type "postgres-sql" references source "my-pg" of type "mysql", which is not compatible`},
+		},
+		{
+			desc: "missing resource",
+			modify: func(cfg *server.ServerConfig) {
+				cfg.ToolsetConfigs["my-toolset"] = tools.ToolsetConfig{Name: "my-toolset", ToolNames: []string{"my-tool"}, ResourceNames: []string{"my-resource"}}
+			},
+			wantErr: []string{`toolset "my-toolset" references resource "my-resource", which does not exist`},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newCfg()
+			tc.modify(&cfg)
+			err := server.ValidateReferences(cfg)
+			if len(tc.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
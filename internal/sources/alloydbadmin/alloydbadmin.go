@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
@@ -41,6 +42,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -111,7 +113,7 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 func (s *Source) GetDefaultProject() string {
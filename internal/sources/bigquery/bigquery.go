@@ -27,10 +27,12 @@ import (
 	bigqueryapi "cloud.google.com/go/bigquery"
 	dataplexapi "cloud.google.com/go/dataplex/apiv1"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -68,6 +70,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -308,7 +311,7 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 func (s *Source) BigQueryClient() *bigqueryapi.Client {
@@ -549,6 +552,36 @@ func (s *Source) RetrieveClientAndService(accessToken tools.AccessToken) (*bigqu
 	return bqClient, restService, nil
 }
 
+// jobProgressInterval is the interval at which the progress of running jobs is
+// reported to the client.
+const jobProgressInterval = 5 * time.Second
+
+// WaitForJob waits for a job to complete, and reports the time it has been
+// running as progress to the client in the meantime.
+func WaitForJob(ctx context.Context, job *bigqueryapi.Job) (*bigqueryapi.JobStatus, error) {
+	type result struct {
+		status *bigqueryapi.JobStatus
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		status, err := job.Wait(ctx)
+		done <- result{status, err}
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(jobProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case r := <-done:
+			return r.status, r.err
+		case <-ticker.C:
+			util.ReportProgress(ctx, time.Since(start).Seconds(), 0, fmt.Sprintf("job %s is still running", job.ID()))
+		}
+	}
+}
+
 func (s *Source) RunSQL(ctx context.Context, bqClient *bigqueryapi.Client, statement, statementType string, params []bigqueryapi.QueryParameter, connProps []*bigqueryapi.ConnectionProperty) (any, error) {
 	query := bqClient.Query(statement)
 	query.Location = bqClient.Location
@@ -566,13 +599,17 @@ func (s *Source) RunSQL(ctx context.Context, bqClient *bigqueryapi.Client, state
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	// job errors are returned when reading the results
+	if _, err := WaitForJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("unable to wait for query: %w", err)
+	}
 	it, err := job.Read(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read query results: %w", err)
 	}
 
-	var out []any
-	for s.MaxQueryResultRows <= 0 || len(out) < s.MaxQueryResultRows {
+	out := sqlutil.NewRows(ctx, sqlutil.ResultLimits{MaxRows: s.MaxQueryResultRows})
+	for {
 		var val []bigqueryapi.Value
 		err = it.Next(&val)
 		if err == iterator.Done {
@@ -586,11 +623,13 @@ func (s *Source) RunSQL(ctx context.Context, bqClient *bigqueryapi.Client, state
 		for i, field := range schema {
 			row.Add(field.Name, NormalizeValue(val[i]))
 		}
-		out = append(out, row)
+		if !out.Add(row) {
+			break
+		}
 	}
 	// If the query returned any rows, return them directly.
-	if len(out) > 0 {
-		return out, nil
+	if out.Len() > 0 {
+		return out.Result(), nil
 	}
 
 	// This handles the standard case for a SELECT query that successfully
@@ -20,9 +20,11 @@ import (
 
 	"cloud.google.com/go/bigtable"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 )
@@ -36,6 +38,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -51,6 +54,8 @@ type Config struct {
 	Type     string `yaml:"type" validate:"required"`
 	Project  string `yaml:"project" validate:"required"`
 	Instance string `yaml:"instance" validate:"required"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -82,7 +87,7 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 func (s *Source) BigtableClient() *bigtable.Client {
@@ -148,7 +153,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, configParam param
 		return nil, fmt.Errorf("unable to bind: %w", err)
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	var rowErr error
 	err = bs.Execute(ctx, func(resultRow bigtable.ResultRow) bool {
 		vMap := make(map[string]any)
@@ -163,9 +168,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, configParam param
 			vMap[c.Name] = columValue
 		}
 
-		out = append(out, vMap)
-
-		return true
+		return out.Add(vMap)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute client: %w", err)
@@ -174,7 +177,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, configParam param
 		return nil, fmt.Errorf("error processing row: %w", rowErr)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initBigtableClient(ctx context.Context, tracer trace.Tracer, name, project, instance string) (*bigtable.Client, error) {
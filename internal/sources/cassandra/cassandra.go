@@ -20,8 +20,10 @@ import (
 
 	gocql "github.com/apache/cassandra-gocql-driver/v2"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -31,6 +33,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -53,6 +56,8 @@ type Config struct {
 	CertPath               string   `yaml:"certPath"`
 	KeyPath                string   `yaml:"keyPath"`
 	EnableHostVerification bool     `yaml:"enableHostVerification"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 // Initialize implements sources.SourceConfig.
@@ -86,7 +91,7 @@ func (s *Source) CassandraSession() *gocql.Session {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 // SourceType implements sources.Source.
@@ -98,8 +103,8 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params parameters
 	sliceParams := params.AsSlice()
 	iter := s.CassandraSession().Query(statement, sliceParams...).IterContext(ctx)
 
-	// Create a slice to store the out
-	var out []map[string]interface{}
+	// Collect the rows until a result limit is reached
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 
 	// Scan results into a map and append to the slice
 	for {
@@ -107,13 +112,15 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params parameters
 		if !iter.MapScan(row) {
 			break // No more rows
 		}
-		out = append(out, row)
+		if !out.Add(row) {
+			break
+		}
 	}
 
 	if err := iter.Close(); err != nil {
 		return nil, fmt.Errorf("unable to parse rows: %w", err)
 	}
-	return out, nil
+	return out.Result(), nil
 }
 
 var _ sources.Source = &Source{}
@@ -21,10 +21,12 @@ import (
 	"net/url"
 	"time"
 
-	_ "github.com/ClickHouse/clickhouse-go/v2"
+	clickhousego "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -37,6 +39,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -57,6 +60,9 @@ type Config struct {
 	Password string `yaml:"password"`
 	Protocol string `yaml:"protocol"`
 	Secure   bool   `yaml:"secure"`
+	ReadOnly bool   `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -93,14 +99,36 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Pool)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
 }
 
 func (s *Source) ClickHousePool() *sql.DB {
 	return s.Pool
 }
 
+// RunSQLReadOnly runs the statement with the `readonly=1` setting, so that any
+// write or settings change is rejected by the server.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
+	ctx = clickhousego.Context(ctx, clickhousego.WithSettings(clickhousego.Settings{"readonly": 1}))
+	return s.runSQL(ctx, statement, params)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
+	if s.ReadOnly {
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	return s.runSQL(ctx, statement, params)
+}
+
+func (s *Source) runSQL(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
 	var sliceParams []any
 	if params != nil {
 		sliceParams = params.AsSlice()
@@ -128,7 +156,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params parameters
 		return nil, fmt.Errorf("unable to get column types: %w", err)
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	for results.Next() {
 		err := results.Scan(values...)
 		if err != nil {
@@ -153,14 +181,16 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params parameters
 				vMap[name] = rawValues[i]
 			}
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	if err := results.Err(); err != nil {
 		return nil, fmt.Errorf("errors encountered by results.Scan: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func validateConfig(protocol string) error {
@@ -23,10 +23,12 @@ import (
 
 	"cloud.google.com/go/cloudsqlconn/mysql/mysql"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -39,6 +41,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -59,6 +62,9 @@ type Config struct {
 	User     string         `yaml:"user"`
 	Password string         `yaml:"password"`
 	Database string         `yaml:"database" validate:"required"`
+	ReadOnly bool           `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -95,7 +101,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Pool)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
 }
 
 func (s *Source) MySQLPool() *sql.DB {
@@ -103,7 +117,37 @@ func (s *Source) MySQLPool() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
+	if s.ReadOnly {
+		// DDL statements implicitly commit the read-only transaction
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, statement); err != nil {
+			return nil, err
+		}
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	return runSQL(ctx, s.MySQLPool(), s.ResultLimits, statement, params)
+}
+
+// RunSQLReadOnly runs the statement in a `START TRANSACTION READ ONLY`
+// transaction, so that any write is rejected by the database.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params []any) (any, error) {
+	tx, err := s.MySQLPool().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin read-only transaction: %w", err)
+	}
+	// nothing can be written, so the transaction is always rolled back
+	defer func() { _ = tx.Rollback() }()
+	return runSQL(ctx, tx, s.ResultLimits, statement, params)
+}
+
+// querier is implemented by both the connection pool and transactions.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, limits sqlutil.ResultLimits, statement string, params []any) (any, error) {
+	// push the deadline down to the server for SELECT statements
+	statement = sqlutil.WithMaxExecutionTime(ctx, statement)
+	results, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -126,7 +170,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		return nil, fmt.Errorf("unable to get column types: %w", err)
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, limits)
 	for results.Next() {
 		err := results.Scan(values...)
 		if err != nil {
@@ -146,14 +190,16 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 			}
 			row.Add(name, convertedValue)
 		}
-		out = append(out, row)
+		if !out.Add(row) {
+			break
+		}
 	}
 
 	if err := results.Err(); err != nil {
 		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func getConnectionConfig(ctx context.Context, user, pass string) (string, string, bool, error) {
@@ -24,8 +24,10 @@ import (
 	"github.com/couchbase/gocb/v2"
 	tlsutil "github.com/couchbase/tools-common/http/tls"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -38,6 +40,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -64,6 +67,8 @@ type Config struct {
 	NoSSLVerify          bool   `yaml:"noSslVerify"`
 	Profile              string `yaml:"profile"`
 	QueryScanConsistency uint   `yaml:"queryScanConsistency"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -101,7 +106,7 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 func (s *Source) CouchbaseScope() *gocb.Scope {
@@ -112,7 +117,7 @@ func (s *Source) CouchbaseQueryScanConsistency() uint {
 	return s.QueryScanConsistency
 }
 
-func (s *Source) RunSQL(statement string, params parameters.ParamValues) (any, error) {
+func (s *Source) RunSQL(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
 	results, err := s.CouchbaseScope().Query(statement, &gocb.QueryOptions{
 		ScanConsistency: gocb.QueryScanConsistency(s.CouchbaseQueryScanConsistency()),
 		NamedParameters: params.AsMap(),
@@ -120,17 +125,20 @@ func (s *Source) RunSQL(statement string, params parameters.ParamValues) (any, e
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	defer results.Close()
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	for results.Next() {
 		var result json.RawMessage
 		err := results.Row(&result)
 		if err != nil {
 			return nil, fmt.Errorf("error processing row: %w", err)
 		}
-		out = append(out, result)
+		if !out.Add(result) {
+			break
+		}
 	}
-	return out, nil
+	return out.Result(), nil
 }
 
 func (r Config) createCouchbaseOptions() (gocb.ClusterOptions, error) {
@@ -24,7 +24,9 @@ import (
 	_ "github.com/nakagami/firebirdsql"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 const SourceType string = "firebird"
@@ -35,6 +37,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -53,6 +56,9 @@ type Config struct {
 	User     string `yaml:"user" validate:"required"`
 	Password string `yaml:"password" validate:"required"`
 	Database string `yaml:"database" validate:"required"`
+	ReadOnly bool   `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -89,7 +95,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Db)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
 }
 
 func (s *Source) FirebirdDB() *sql.DB {
@@ -97,7 +111,35 @@ func (s *Source) FirebirdDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	rows, err := s.FirebirdDB().QueryContext(ctx, statement, params...)
+	if s.ReadOnly {
+		// statements such as COMMIT end the read-only transaction
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, statement); err != nil {
+			return nil, err
+		}
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	return runSQL(ctx, s.FirebirdDB(), s.ResultLimits, statement, params)
+}
+
+// RunSQLReadOnly runs the statement in a read-only transaction, so that any
+// write is rejected by the database.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params []any) (any, error) {
+	tx, err := s.FirebirdDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin read-only transaction: %w", err)
+	}
+	// nothing can be written, so the transaction is always rolled back
+	defer func() { _ = tx.Rollback() }()
+	return runSQL(ctx, tx, s.ResultLimits, statement, params)
+}
+
+// querier is implemented by both the connection pool and transactions.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, limits sqlutil.ResultLimits, statement string, params []any) (any, error) {
+	rows, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -114,7 +156,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		scanArgs[i] = &values[i]
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, limits)
 	for rows.Next() {
 
 		err = rows.Scan(scanArgs...)
@@ -130,7 +172,9 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 				vMap[col] = values[i]
 			}
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	if err := rows.Err(); err != nil {
@@ -140,7 +184,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	// In most cases, DML/DDL statements like INSERT, UPDATE, CREATE, etc. might return no rows
 	// However, it is also possible that this was a query that was expected to return rows
 	// but returned none, a case that we cannot distinguish here.
-	return out, nil
+	return out.Result(), nil
 }
 
 func initFirebirdConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string) (*sql.DB, error) {
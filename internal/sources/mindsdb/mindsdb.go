@@ -22,8 +22,10 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -36,6 +38,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -55,6 +58,9 @@ type Config struct {
 	Password     string `yaml:"password"`
 	Database     string `yaml:"database" validate:"required"`
 	QueryTimeout string `yaml:"queryTimeout"`
+	ReadOnly     bool   `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -91,7 +97,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Pool)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
 }
 
 func (s *Source) MindsDBPool() *sql.DB {
@@ -103,6 +117,11 @@ func (s *Source) MySQLPool() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	if s.ReadOnly {
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, statement); err != nil {
+			return nil, err
+		}
+	}
 	// MindsDB now supports MySQL prepared statements natively
 	results, err := s.MindsDBPool().QueryContext(ctx, statement, params...)
 	if err != nil {
@@ -127,7 +146,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		return nil, fmt.Errorf("unable to get column types: %w", err)
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	for results.Next() {
 		err := results.Scan(values...)
 		if err != nil {
@@ -147,14 +166,16 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 				return nil, fmt.Errorf("errors encountered when converting values: %w", err)
 			}
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	if err := results.Err(); err != nil {
 		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initMindsDBConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, queryTimeout string) (*sql.DB, error) {
@@ -21,9 +21,11 @@ import (
 	"net/url"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	_ "github.com/microsoft/go-mssqldb"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -37,6 +39,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -57,6 +60,9 @@ type Config struct {
 	Password string `yaml:"password" validate:"required"`
 	Database string `yaml:"database" validate:"required"`
 	Encrypt  string `yaml:"encrypt"`
+	ReadOnly bool   `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -97,7 +103,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Db)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
 }
 
 func (s *Source) MSSQLDB() *sql.DB {
@@ -106,6 +120,11 @@ func (s *Source) MSSQLDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	if s.ReadOnly {
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectTSQL, statement); err != nil {
+			return nil, err
+		}
+	}
 	results, err := s.MSSQLDB().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -115,8 +134,8 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	cols, err := results.Columns()
 	// If Columns() errors, it might be a DDL/DML without an OUTPUT clause.
 	// We proceed, and results.Err() will catch actual query execution errors.
-	// 'out' will remain nil if cols is empty or err is not nil here.
-	var out []any
+	// 'out' will remain empty if cols is empty or err is not nil here.
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	if err == nil && len(cols) > 0 {
 		// create an array of values for each column, which can be re-used to scan each row
 		rawValues := make([]any, len(cols))
@@ -134,7 +153,9 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 			for i, name := range cols {
 				row.Add(name, rawValues[i])
 			}
-			out = append(out, row)
+			if !out.Add(row) {
+				break
+			}
 		}
 	}
 
@@ -144,7 +165,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		return nil, fmt.Errorf("errors encountered during query execution or row processing: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initMssqlConnection(
@@ -16,6 +16,7 @@ package mssql_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 func TestParseFromYamlMssql(t *testing.T) {
@@ -84,7 +86,7 @@ func TestParseFromYamlMssql(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			got, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			got, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -132,7 +134,7 @@ func TestFailParseFromYaml(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			_, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			_, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err == nil {
 				t.Fatalf("expect parsing to fail")
 			}
@@ -143,3 +145,16 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestRunSQLReadOnlySource(t *testing.T) {
+	s := &mssql.Source{Config: mssql.Config{Name: "my-mssql-instance", ReadOnly: true}}
+	for _, statement := range []string{
+		"DELETE FROM flights",
+		"SELECT 1 UPDATE flights SET id = 2",
+	} {
+		_, err := s.RunSQL(context.Background(), statement, nil)
+		if !errors.Is(err, sqlutil.ErrNotReadOnly) {
+			t.Fatalf("unexpected error for %q: got %v, want %v", statement, err, sqlutil.ErrNotReadOnly)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package mysql_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 func TestParseFromYamlCloudSQLMySQL(t *testing.T) {
@@ -85,6 +87,32 @@ func TestParseFromYamlCloudSQLMySQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with read only",
+			in: `
+			kind: sources
+			name: my-mysql-instance
+			type: mysql
+			host: 0.0.0.0
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			readOnly: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-mysql-instance": mysql.Config{
+					Name:     "my-mysql-instance",
+					Type:     mysql.SourceType,
+					Host:     "0.0.0.0",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					ReadOnly: true,
+				},
+			},
+		},
 		{
 			desc: "with query params",
 			in: `
@@ -120,7 +148,7 @@ func TestParseFromYamlCloudSQLMySQL(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
 			t.Parallel()
-			got, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			got, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -185,7 +213,7 @@ func TestFailParseFromYaml(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
 			t.Parallel()
-			_, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			_, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err == nil {
 				t.Fatalf("expect parsing to fail")
 			}
@@ -219,3 +247,13 @@ func TestFailInitialization(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestRunSQLReadOnlySource(t *testing.T) {
+	// DDL is rejected before a connection is used, since it would commit the
+	// read-only transaction
+	s := &mysql.Source{Config: mysql.Config{Name: "my-mysql-instance", ReadOnly: true}}
+	_, err := s.RunSQL(context.Background(), "DROP TABLE flights", nil)
+	if !errors.Is(err, sqlutil.ErrNotReadOnly) {
+		t.Fatalf("unexpected error: got %v, want %v", err, sqlutil.ErrNotReadOnly)
+	}
+}
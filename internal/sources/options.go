@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// SourceOptions are the options that can be set on any source. They are
+// enforced by the server instead of the source itself.
+type SourceOptions struct {
+	// LazyInit lets the server start if the source cannot be initialized. The
+	// tools of the source are unavailable until it is reconnected in the
+	// background.
+	LazyInit bool `yaml:"lazyInit,omitempty"`
+	// Retry configures the attempts to initialize the source.
+	Retry RetryOptions `yaml:"retry,omitempty"`
+}
+
+// IsZero returns true if no option is set.
+func (o SourceOptions) IsZero() bool {
+	return !o.LazyInit && o.Retry == RetryOptions{}
+}
+
+// RetryOptions configure the attempts to initialize a source, with an
+// exponential backoff between attempts.
+type RetryOptions struct {
+	// MaxAttempts is the number of attempts to initialize the source at
+	// startup, 1 if unset.
+	MaxAttempts int `yaml:"maxAttempts,omitempty"`
+	// InitialBackoff is the delay before the second attempt, such as "1s".
+	InitialBackoff string `yaml:"initialBackoff,omitempty"`
+	// MaxBackoff caps the delay between attempts, such as "30s".
+	MaxBackoff string `yaml:"maxBackoff,omitempty"`
+}
+
+// Validate returns an error if the options are invalid.
+func (r RetryOptions) Validate() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("invalid maxAttempts %d: must be at least 1", r.MaxAttempts)
+	}
+	for name, v := range map[string]string{"initialBackoff": r.InitialBackoff, "maxBackoff": r.MaxBackoff} {
+		if v == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			return fmt.Errorf("invalid %s %q: must be a positive duration such as \"1s\"", name, v)
+		}
+	}
+	return nil
+}
+
+// Attempts returns the number of attempts to initialize the source at startup.
+func (r RetryOptions) Attempts() int {
+	return max(r.MaxAttempts, 1)
+}
+
+// Backoff returns the delay after the given failed attempt, starting at 1. The
+// delay doubles after every attempt, up to the maximum backoff.
+func (r RetryOptions) Backoff(attempt int) time.Duration {
+	// the durations are validated when the config is parsed
+	initial, maximum := defaultInitialBackoff, defaultMaxBackoff
+	if d, err := time.ParseDuration(r.InitialBackoff); err == nil {
+		initial = d
+	}
+	if d, err := time.ParseDuration(r.MaxBackoff); err == nil {
+		maximum = d
+	}
+	backoff := initial
+	for i := 1; i < attempt && backoff < maximum; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maximum)
+}
+
+// OptionsSourceConfig wraps the config of a source that has source options.
+type OptionsSourceConfig struct {
+	SourceConfig  `yaml:",inline"`
+	SourceOptions `yaml:",inline"`
+}
+
+// SourceOptionsOf returns the source options configured on a source config.
+func SourceOptionsOf(cfg SourceConfig) SourceOptions {
+	if o, ok := cfg.(OptionsSourceConfig); ok {
+		return o.SourceOptions
+	}
+	return SourceOptions{}
+}
+
+var _ Source = &UnavailableSource{}
+var _ HealthChecker = &UnavailableSource{}
+
+// UnavailableSource takes the place of a lazily initialized source that could
+// not be initialized, until it is reconnected.
+type UnavailableSource struct {
+	cfg SourceConfig
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewUnavailableSource returns an UnavailableSource for the source config
+// whose initialization failed with err.
+func NewUnavailableSource(cfg SourceConfig, err error) *UnavailableSource {
+	return &UnavailableSource{cfg: cfg, err: err}
+}
+
+func (s *UnavailableSource) SourceType() string {
+	return s.cfg.SourceConfigType()
+}
+
+func (s *UnavailableSource) ToConfig() SourceConfig {
+	return secrets.Redact(s.cfg)
+}
+
+// Ping returns the error of the last attempt to initialize the source.
+func (s *UnavailableSource) Ping(_ context.Context) error {
+	return s.Err()
+}
+
+// Err returns the error of the last attempt to initialize the source.
+func (s *UnavailableSource) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Reconnect attempts to initialize the source once. The error of a failed
+// attempt is returned by Err.
+func (s *UnavailableSource) Reconnect(ctx context.Context, tracer trace.Tracer) (Source, error) {
+	cfg := s.cfg
+	if o, ok := cfg.(OptionsSourceConfig); ok {
+		cfg = o.SourceConfig
+	}
+	src, err := cfg.Initialize(ctx, tracer)
+	if err != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.err = err
+		return nil, err
+	}
+	return src, nil
+}
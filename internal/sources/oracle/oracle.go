@@ -13,8 +13,10 @@ import (
 	_ "github.com/godror/godror"   // OCI driver
 	_ "github.com/sijms/go-ora/v2" // Pure Go driver
 
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -27,6 +29,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -56,6 +59,9 @@ type Config struct {
 	Password         string `yaml:"password" validate:"required"`
 	UseOCI           bool   `yaml:"useOCI,omitempty"`
 	WalletLocation   string `yaml:"walletLocation,omitempty"`
+	ReadOnly         bool   `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (c Config) validate() error {
@@ -129,7 +135,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.DB)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
 }
 
 func (s *Source) OracleDB() *sql.DB {
@@ -137,7 +151,34 @@ func (s *Source) OracleDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	rows, err := s.OracleDB().QueryContext(ctx, statement, params...)
+	if s.ReadOnly {
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	return runSQL(ctx, s.OracleDB(), s.ResultLimits, statement, params)
+}
+
+// RunSQLReadOnly runs the statement in a `SET TRANSACTION READ ONLY`
+// transaction, so that any write is rejected by the database.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params []any) (any, error) {
+	tx, err := s.OracleDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	// nothing can be written, so the transaction is always rolled back
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+		return nil, fmt.Errorf("unable to begin read-only transaction: %w", err)
+	}
+	return runSQL(ctx, tx, s.ResultLimits, statement, params)
+}
+
+// querier is implemented by both the connection pool and transactions.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, limits sqlutil.ResultLimits, statement string, params []any) (any, error) {
+	rows, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -157,7 +198,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		return []any{}, nil
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, limits)
 	for rows.Next() {
 		values := make([]any, len(cols))
 		for i, colType := range colTypes {
@@ -227,14 +268,16 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 				return nil, fmt.Errorf("unexpected receiver type: %T", v)
 			}
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("errors encountered during query execution or row processing: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initOracleConnection(ctx context.Context, tracer trace.Tracer, config Config) (*sql.DB, error) {
@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"database/sql"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolStats are the statistics of the connection pool of a source.
+type PoolStats struct {
+	// InUse is the number of connections currently in use.
+	InUse int64
+	// Idle is the number of idle connections.
+	Idle int64
+	// Max is the maximum number of connections, 0 if unlimited.
+	Max int64
+}
+
+// PoolStatsProvider is implemented by sources that have a connection pool.
+type PoolStatsProvider interface {
+	PoolStats() PoolStats
+}
+
+// PgxPoolStats returns the statistics of a pgx connection pool.
+func PgxPoolStats(pool *pgxpool.Pool) PoolStats {
+	if pool == nil {
+		return PoolStats{}
+	}
+	s := pool.Stat()
+	return PoolStats{
+		InUse: int64(s.AcquiredConns()),
+		Idle:  int64(s.IdleConns()),
+		Max:   int64(s.MaxConns()),
+	}
+}
+
+// SQLDBStats returns the statistics of a database/sql connection pool.
+func SQLDBStats(db *sql.DB) PoolStats {
+	if db == nil {
+		return PoolStats{}
+	}
+	s := db.Stats()
+	return PoolStats{
+		InUse: int64(s.InUse),
+		Idle:  int64(s.Idle),
+		Max:   int64(s.MaxOpenConnections),
+	}
+}
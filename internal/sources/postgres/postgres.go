@@ -21,9 +21,12 @@ import (
 	"strings"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -37,6 +40,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -56,6 +60,9 @@ type Config struct {
 	Password    string            `yaml:"password" validate:"required"`
 	Database    string            `yaml:"database" validate:"required"`
 	QueryParams map[string]string `yaml:"queryParams"`
+	ReadOnly    bool              `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -92,7 +99,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.PgxPoolStats(s.Pool)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
 }
 
 func (s *Source) PostgresPool() *pgxpool.Pool {
@@ -100,14 +115,66 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.PostgresPool().Query(ctx, statement, params...)
+	if s.ReadOnly {
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	timeout, ok := sqlutil.StatementTimeout(ctx)
+	if !ok {
+		return runSQL(ctx, s.PostgresPool(), s.ResultLimits, statement, params)
+	}
+	// push the deadline down to the server, so that the statement is also
+	// cancelled on the server if the cancel request is lost. The timeout is
+	// set on the session rather than in a transaction, since statements such
+	// as VACUUM cannot run inside one.
+	conn, err := s.PostgresPool().Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acquire connection: %w", err)
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, fmt.Sprintf("SET statement_timeout = %d", timeout.Milliseconds())); err != nil {
+		return nil, fmt.Errorf("unable to set statement timeout: %w", err)
+	}
+	defer func() {
+		// never return a connection with the timeout to the pool
+		if _, err := conn.Exec(context.WithoutCancel(ctx), "RESET statement_timeout"); err != nil {
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+	return runSQL(ctx, conn, s.ResultLimits, statement, params)
+}
+
+// RunSQLReadOnly runs the statement in a read-only transaction, so that any
+// write is rejected by the database.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params []any) (any, error) {
+	tx, err := s.PostgresPool().BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin read-only transaction: %w", err)
+	}
+	// nothing can be written, so the transaction is always rolled back
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+	if timeout, ok := sqlutil.StatementTimeout(ctx); ok {
+		if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())); err != nil {
+			return nil, fmt.Errorf("unable to set statement timeout: %w", err)
+		}
+	}
+	return runSQL(ctx, tx, s.ResultLimits, statement, params)
+}
+
+// querier is implemented by the connection pool, connections and
+// transactions.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, limits sqlutil.ResultLimits, statement string, params []any) (any, error) {
+	results, err := q.Query(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
 	fields := results.FieldDescriptions()
-	var out []any
+	out := sqlutil.NewRows(ctx, limits)
 	for results.Next() {
 		values, err := results.Values()
 		if err != nil {
@@ -117,13 +184,15 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		for i, f := range fields {
 			row.Add(f.Name, values[i])
 		}
-		out = append(out, row)
+		if !out.Add(row) {
+			break
+		}
 	}
 	// this will catch actual query execution errors
 	if err := results.Err(); err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
-	return out, nil
+	return out.Result(), nil
 }
 
 func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string) (*pgxpool.Pool, error) {
@@ -19,7 +19,9 @@ import (
 	"fmt"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/snowflakedb/gosnowflake"
 	"go.opentelemetry.io/otel/trace"
@@ -34,6 +36,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -54,6 +57,9 @@ type Config struct {
 	Schema    string `yaml:"schema" validate:"required"`
 	Warehouse string `yaml:"warehouse"`
 	Role      string `yaml:"role"`
+	ReadOnly  bool   `yaml:"readOnly"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -90,7 +96,7 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 func (s *Source) SnowflakeDB() *sqlx.DB {
@@ -98,13 +104,18 @@ func (s *Source) SnowflakeDB() *sqlx.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	if s.ReadOnly {
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, statement); err != nil {
+			return nil, err
+		}
+	}
 	rows, err := s.DB.QueryxContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer rows.Close()
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	for rows.Next() {
 		cols, err := rows.Columns()
 		if err != nil {
@@ -125,14 +136,16 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		for i, col := range cols {
 			vMap[col] = values[i]
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initSnowflakeConnection(ctx context.Context, tracer trace.Tracer, name, account, user, password, database, schema, warehouse, role string) (*sqlx.DB, error) {
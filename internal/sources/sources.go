@@ -16,8 +16,8 @@ package sources
 
 import (
 	"context"
-
 	"fmt"
+	"reflect"
 
 	"github.com/goccy/go-yaml"
 	"go.opentelemetry.io/otel/attribute"
@@ -40,6 +40,22 @@ func Register(sourceType string, factory SourceConfigFactory) bool {
 	return true
 }
 
+var implementationRegistry = make(map[string]reflect.Type)
+
+// RegisterImplementation records the Go type T of the sources of a source
+// type, so that their compatibility with tools can be checked without
+// initializing them.
+func RegisterImplementation[T Source](sourceType string) {
+	implementationRegistry[sourceType] = reflect.TypeFor[T]()
+}
+
+// Implementation returns the Go type of the sources of a source type, as
+// registered with RegisterImplementation.
+func Implementation(sourceType string) (reflect.Type, bool) {
+	t, ok := implementationRegistry[sourceType]
+	return t, ok
+}
+
 // DecodeConfig decodes a source configuration using the registered factory for the given type.
 func DecodeConfig(ctx context.Context, sourceType string, name string, decoder *yaml.Decoder) (SourceConfig, error) {
 	factory, found := sourceRegistry[sourceType]
@@ -65,6 +81,12 @@ type Source interface {
 	ToConfig() SourceConfig
 }
 
+// HealthChecker is implemented by sources that can check the connectivity to
+// their database, e.g. by pinging it.
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+}
+
 // InitConnectionSpan adds a span for database pool connection initialization
 func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceType, sourceName string) (context.Context, trace.Span) {
 	ctx, span := tracer.Start(
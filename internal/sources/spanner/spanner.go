@@ -21,9 +21,11 @@ import (
 
 	"cloud.google.com/go/spanner"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/iterator"
 )
@@ -37,6 +39,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -54,6 +57,8 @@ type Config struct {
 	Instance string          `yaml:"instance" validate:"required"`
 	Dialect  sources.Dialect `yaml:"dialect" validate:"required"`
 	Database string          `yaml:"database" validate:"required"`
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -85,7 +90,7 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
 }
 
 func (s *Source) SpannerClient() *spanner.Client {
@@ -97,8 +102,8 @@ func (s *Source) DatabaseDialect() string {
 }
 
 // processRows iterates over the spanner.RowIterator and converts each row to a map[string]any.
-func processRows(iter *spanner.RowIterator) ([]any, error) {
-	var out []any
+func processRows(ctx context.Context, limits sqlutil.ResultLimits, iter *spanner.RowIterator) (any, error) {
+	out := sqlutil.NewRows(ctx, limits)
 	defer iter.Stop()
 
 	for {
@@ -132,13 +137,15 @@ func processRows(iter *spanner.RowIterator) ([]any, error) {
 				rowMap.Add(c, row.ColumnValue(i))
 			}
 		}
-		out = append(out, rowMap)
+		if !out.Add(rowMap) {
+			break
+		}
 	}
-	return out, nil
+	return out.Result(), nil
 }
 
 func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, params map[string]any) (any, error) {
-	var results []any
+	var results any
 	var err error
 	var opErr error
 	stmt := spanner.Statement{
@@ -150,11 +157,11 @@ func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, pa
 
 	if readOnly {
 		iter := s.SpannerClient().Single().Query(ctx, stmt)
-		results, opErr = processRows(iter)
+		results, opErr = processRows(ctx, s.ResultLimits, iter)
 	} else {
 		_, opErr = s.SpannerClient().ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
 			iter := txn.Query(ctx, stmt)
-			results, err = processRows(iter)
+			results, err = processRows(ctx, s.ResultLimits, iter)
 			if err != nil {
 				return err
 			}
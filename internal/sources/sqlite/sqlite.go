@@ -19,10 +19,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"go.opentelemetry.io/otel/trace"
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
 )
@@ -36,6 +39,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -50,6 +54,9 @@ type Config struct {
 	Name     string `yaml:"name" validate:"required"`
 	Type     string `yaml:"type" validate:"required"`
 	Database string `yaml:"database" validate:"required"` // Path to SQLite database file
+	ReadOnly bool   `yaml:"readOnly"`                     // Open the database in read-only mode
+
+	sqlutil.ResultLimits `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -57,7 +64,10 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	db, err := initSQLiteConnection(ctx, tracer, r.Name, r.Database)
+	// an in-memory database only exists on the connection that created it,
+	// so it cannot be opened again in read-only mode
+	memory := isMemory(r.Database)
+	db, err := initSQLiteConnection(ctx, tracer, r.Name, r.Database, r.ReadOnly && !memory)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
@@ -67,9 +77,25 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("unable to connect successfully: %w", err)
 	}
 
+	// read-only statements run on a separate `mode=ro` connection, since
+	// `PRAGMA query_only` alone can be turned off by the statement itself
+	var readOnlyDb *sql.DB
+	switch {
+	case memory:
+		// read-only statements run on the connection of the source
+	case r.ReadOnly:
+		readOnlyDb = db
+	default:
+		readOnlyDb, err = initSQLiteConnection(ctx, tracer, r.Name, r.Database, true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create read-only db connection: %w", err)
+		}
+	}
+
 	s := &Source{
-		Config: r,
-		Db:     db,
+		Config:     r,
+		Db:         db,
+		readOnlyDb: readOnlyDb,
 	}
 	return s, nil
 }
@@ -78,7 +104,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Db *sql.DB
+	Db         *sql.DB
+	readOnlyDb *sql.DB
 }
 
 func (s *Source) SourceType() string {
@@ -86,7 +113,15 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Db)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
 }
 
 func (s *Source) SQLiteDB() *sql.DB {
@@ -94,8 +129,48 @@ func (s *Source) SQLiteDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	if s.ReadOnly {
+		// `mode=ro` only applies to the main database, not to attached ones
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, statement); err != nil {
+			return nil, err
+		}
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	return runSQL(ctx, s.SQLiteDB(), s.ResultLimits, statement, params)
+}
+
+// RunSQLReadOnly runs the statement on a connection opened with `mode=ro`, so
+// that any write to the main database is rejected by the database. In-memory
+// databases only exist on the connection of the source, so the statement runs
+// on it instead. `PRAGMA query_only` is turned on for every statement as a
+// second layer, since a previous statement may have turned it off.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params []any) (any, error) {
+	db, memory := s.readOnlyDb, false
+	if db == nil {
+		db, memory = s.SQLiteDB(), true
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, fmt.Errorf("unable to enable query_only: %w", err)
+	}
+	if memory {
+		defer func() { _, _ = conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = OFF") }()
+	}
+	return runSQL(ctx, conn, s.ResultLimits, statement, params)
+}
+
+// querier is implemented by both the connection pool and connections.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, limits sqlutil.ResultLimits, statement string, params []any) (any, error) {
 	// Execute the SQL query with parameters
-	rows, err := s.SQLiteDB().QueryContext(ctx, statement, params...)
+	rows, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -117,7 +192,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	}
 
 	// Prepare the result slice
-	var out []any
+	out := sqlutil.NewRows(ctx, limits)
 	for rows.Next() {
 		if err := rows.Scan(values...); err != nil {
 			return nil, fmt.Errorf("unable to scan row: %w", err)
@@ -143,21 +218,27 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 			// Store the value in the map
 			row.Add(name, val)
 		}
-		out = append(out, row)
+		if !out.Add(row) {
+			break
+		}
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
-func initSQLiteConnection(ctx context.Context, tracer trace.Tracer, name, dbPath string) (*sql.DB, error) {
+func initSQLiteConnection(ctx context.Context, tracer trace.Tracer, name, dbPath string, readOnly bool) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
 
+	if readOnly {
+		dbPath = readOnlyURI(dbPath)
+	}
+
 	// Open database connection
 	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
@@ -170,3 +251,20 @@ func initSQLiteConnection(ctx context.Context, tracer trace.Tracer, name, dbPath
 
 	return db, nil
 }
+
+// readOnlyURI converts the database path into a URI filename that opens the
+// database with `mode=ro`.
+func readOnlyURI(dbPath string) string {
+	if !strings.HasPrefix(dbPath, "file:") {
+		return "file:" + dbPath + "?mode=ro"
+	}
+	if strings.Contains(dbPath, "?") {
+		return dbPath + "&mode=ro"
+	}
+	return dbPath + "?mode=ro"
+}
+
+// isMemory returns true if the database path refers to an in-memory database.
+func isMemory(dbPath string) bool {
+	return dbPath == ":memory:" || strings.HasPrefix(dbPath, "file::memory:") || strings.Contains(dbPath, "mode=memory")
+}
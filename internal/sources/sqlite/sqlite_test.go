@@ -16,6 +16,10 @@ package sqlite_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,6 +27,8 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -47,10 +53,47 @@ func TestParseFromYamlSQLite(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read-only example",
+			in: `
+            kind: sources
+            name: my-sqlite-db
+            type: sqlite
+            database: /path/to/database.db
+            readOnly: true
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-sqlite-db": sqlite.Config{
+					Name:     "my-sqlite-db",
+					Type:     sqlite.SourceType,
+					Database: "/path/to/database.db",
+					ReadOnly: true,
+				},
+			},
+		},
+		{
+			desc: "result limits example",
+			in: `
+            kind: sources
+            name: my-sqlite-db
+            type: sqlite
+            database: /path/to/database.db
+            maxRows: 100
+            maxResultBytes: 65536
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-sqlite-db": sqlite.Config{
+					Name:         "my-sqlite-db",
+					Type:         sqlite.SourceType,
+					Database:     "/path/to/database.db",
+					ResultLimits: sqlutil.ResultLimits{MaxRows: 100, MaxResultBytes: 65536},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			got, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			got, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -90,7 +133,7 @@ func TestFailParseFromYaml(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			_, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			_, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err == nil {
 				t.Fatalf("expect parsing to fail")
 			}
@@ -101,3 +144,134 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	tracer := noop.NewTracerProvider().Tracer("test")
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	src, err := sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: dbPath}.Initialize(ctx, tracer)
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	s := src.(*sqlite.Source)
+	if _, err := s.RunSQL(ctx, "CREATE TABLE flights (id INTEGER)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	if _, err := s.RunSQLReadOnly(ctx, "INSERT INTO flights VALUES (1)", nil); err == nil || !strings.Contains(err.Error(), "readonly") {
+		t.Fatalf("expect write to be rejected, got %v", err)
+	}
+	// the read-only mode cannot be turned off by the statement
+	if _, err := s.RunSQLReadOnly(ctx, "PRAGMA query_only = OFF; INSERT INTO flights VALUES (1)", nil); err == nil || !strings.Contains(err.Error(), "readonly") {
+		t.Fatalf("expect write to be rejected, got %v", err)
+	}
+	if _, err := s.RunSQLReadOnly(ctx, "SELECT * FROM flights", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// query_only is turned on as a second layer
+	got, err := s.RunSQLReadOnly(ctx, "PRAGMA query_only", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(fmt.Sprint(got), "query_only 1") {
+		t.Fatalf("expect query_only to be on, got %v", got)
+	}
+	// writes are still allowed outside of the read-only mode
+	if _, err := s.RunSQL(ctx, "INSERT INTO flights VALUES (1)", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	roSrc, err := sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: dbPath, ReadOnly: true}.Initialize(ctx, tracer)
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	ro := roSrc.(*sqlite.Source)
+	if _, err := ro.RunSQL(ctx, "INSERT INTO flights VALUES (2)", nil); !errors.Is(err, sqlutil.ErrNotReadOnly) {
+		t.Fatalf("expect write to be rejected, got %v", err)
+	}
+	// mode=ro does not apply to attached databases
+	attach := fmt.Sprintf("ATTACH '%s' AS other; CREATE TABLE other.t (id INTEGER)", filepath.Join(t.TempDir(), "other.db"))
+	if _, err := ro.RunSQL(ctx, attach, nil); !errors.Is(err, sqlutil.ErrNotReadOnly) {
+		t.Fatalf("expect attach to be rejected, got %v", err)
+	}
+	got, err = ro.RunSQL(ctx, "SELECT * FROM flights", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rows, ok := got.([]any); !ok || len(rows) != 1 {
+		t.Fatalf("unexpected result: %v", got)
+	}
+}
+
+func TestReadOnlyInMemory(t *testing.T) {
+	ctx := context.Background()
+	tracer := noop.NewTracerProvider().Tracer("test")
+
+	src, err := sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: ":memory:"}.Initialize(ctx, tracer)
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	s := src.(*sqlite.Source)
+	if _, err := s.RunSQL(ctx, "CREATE TABLE flights (id INTEGER)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	// read-only statements see the tables of the in-memory database
+	if _, err := s.RunSQLReadOnly(ctx, "SELECT * FROM flights", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := s.RunSQLReadOnly(ctx, "INSERT INTO flights VALUES (1)", nil); err == nil || !strings.Contains(err.Error(), "readonly") {
+		t.Fatalf("expect write to be rejected, got %v", err)
+	}
+	// the read-only mode is turned off after the statement
+	if _, err := s.RunSQL(ctx, "INSERT INTO flights VALUES (1)", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestResultLimits(t *testing.T) {
+	ctx := context.Background()
+	tracer := noop.NewTracerProvider().Tracer("test")
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	cfg := sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: dbPath}
+	cfg.MaxRows = 2
+	src, err := cfg.Initialize(ctx, tracer)
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	s := src.(*sqlite.Source)
+	if _, err := s.RunSQL(ctx, "CREATE TABLE flights (id INTEGER)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	if _, err := s.RunSQL(ctx, "INSERT INTO flights VALUES (1), (2), (3)", nil); err != nil {
+		t.Fatalf("unable to insert rows: %s", err)
+	}
+
+	got, err := s.RunSQL(ctx, "SELECT * FROM flights WHERE id < 3", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rows, ok := got.([]any); !ok || len(rows) != 2 {
+		t.Fatalf("expect result within the limit to be returned as is, got %v", got)
+	}
+
+	got, err = s.RunSQL(ctx, "SELECT * FROM flights", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, ok := got.(sqlutil.TruncatedResult)
+	if !ok || !res.Truncated || res.RowCount != 2 || len(res.Rows) != 2 {
+		t.Fatalf("expect truncated result with 2 rows, got %v", got)
+	}
+
+	// the limits of the tool apply if they are stricter
+	got, err = s.RunSQLReadOnly(sqlutil.WithResultLimits(ctx, sqlutil.ResultLimits{MaxRows: 1}), "SELECT * FROM flights", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res, ok := got.(sqlutil.TruncatedResult); !ok || res.RowCount != 1 {
+		t.Fatalf("expect truncated result with 1 row, got %v", got)
+	}
+}
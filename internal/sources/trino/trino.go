@@ -17,15 +17,21 @@ package trino
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	trinogo "github.com/trinodb/trino-go-client/trino"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -39,6 +45,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -65,6 +72,8 @@ type Config struct {
 	SSLCertPath            string `yaml:"sslCertPath"`
 	SSLCert                string `yaml:"sslCert"`
 	DisableSslVerification bool   `yaml:"disableSslVerification"`
+	ReadOnly               bool   `yaml:"readOnly"`
+	sqlutil.ResultLimits   `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -101,14 +110,51 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	return sources.SQLDBStats(s.Pool)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
 }
 
 func (s *Source) TrinoDB() *sql.DB {
 	return s.Pool
 }
 
+// RunSQLReadOnly runs the statement in a `START TRANSACTION READ ONLY`
+// transaction, so that any write is rejected by Trino. The Trino driver does
+// not support transactions, so the id of the transaction is recorded by the
+// HTTP client of the source and passed to the statement as a header.
+func (s *Source) RunSQLReadOnly(ctx context.Context, statement string, params []any) (any, error) {
+	tx := &transaction{}
+	txCtx := context.WithValue(ctx, transactionKey{}, tx)
+	if _, err := s.TrinoDB().ExecContext(txCtx, "START TRANSACTION READ ONLY", sql.Named(transactionHeader, "NONE")); err != nil {
+		return nil, fmt.Errorf("unable to begin read-only transaction: %w", err)
+	}
+	id := tx.ID()
+	if id == "" {
+		return nil, fmt.Errorf("unable to begin read-only transaction: no transaction id returned")
+	}
+	// nothing can be written, so the transaction is always rolled back
+	defer func() {
+		_, _ = s.TrinoDB().ExecContext(context.WithoutCancel(ctx), "ROLLBACK", sql.Named(transactionHeader, id))
+	}()
+	// copy the parameters, so that the caller's slice is never written to
+	return s.runSQL(ctx, statement, append(slices.Clip(params), sql.Named(transactionHeader, id)))
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	if s.ReadOnly {
+		return s.RunSQLReadOnly(ctx, statement, params)
+	}
+	return s.runSQL(ctx, statement, params)
+}
+
+func (s *Source) runSQL(ctx context.Context, statement string, params []any) (any, error) {
 	results, err := s.TrinoDB().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -127,7 +173,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		values[i] = &rawValues[i]
 	}
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	for results.Next() {
 		err := results.Scan(values...)
 		if err != nil {
@@ -148,14 +194,16 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 				vMap[name] = val
 			}
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	if err := results.Err(); err != nil {
 		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initTrinoConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, password, catalog, schema, queryTimeout, accessToken string, kerberosEnabled, sslEnabled bool, sslCertPath, sslCert string, disableSslVerification bool) (*sql.DB, error) {
@@ -174,18 +222,33 @@ func initTrinoConnectionPool(ctx context.Context, tracer trace.Tracer, name, hos
 		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
 	}
 
+	// A custom client is always used, so that the transactions of read-only
+	// statements can be recorded. The driver ignores the SSL certificate of the
+	// DSN for custom clients, so it is configured here.
+	tr := http.DefaultTransport.(*http.Transport).Clone()
 	if disableSslVerification {
 		logger.WarnContext(ctx, "SSL verification is disabled for trino source %s. This is an insecure setting and should not be used in production.\n", name)
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	} else if sslCert != "" || sslCertPath != "" {
+		cert := []byte(sslCert)
+		if sslCertPath != "" {
+			cert, err = os.ReadFile(sslCertPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read SSL certificate: %w", err)
+			}
 		}
-		client := &http.Client{Transport: tr}
-		clientName := fmt.Sprintf("insecure_trino_client_%s", name)
-		if err := trinogo.RegisterCustomClient(clientName, client); err != nil {
-			return nil, fmt.Errorf("failed to register custom client: %w", err)
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(cert) {
+			return nil, fmt.Errorf("failed to parse SSL certificate: no PEM certificate found")
 		}
-		dsn = fmt.Sprintf("%s&custom_client=%s", dsn, clientName)
+		tr.TLSClientConfig = &tls.Config{RootCAs: certPool}
+	}
+	client := &http.Client{Transport: transactionTransport{base: tr}}
+	clientName := fmt.Sprintf("trino_client_%s", name)
+	if err := trinogo.RegisterCustomClient(clientName, client); err != nil {
+		return nil, fmt.Errorf("failed to register custom client: %w", err)
 	}
+	dsn = fmt.Sprintf("%s&custom_client=%s", dsn, clientName)
 
 	db, err := sql.Open("trino", dsn)
 	if err != nil {
@@ -200,6 +263,47 @@ func initTrinoConnectionPool(ctx context.Context, tracer trace.Tracer, name, hos
 	return db, nil
 }
 
+const (
+	transactionHeader        = "X-Trino-Transaction-Id"
+	startedTransactionHeader = "X-Trino-Started-Transaction-Id"
+)
+
+type transactionKey struct{}
+
+// transaction records the id of the transaction started by a statement whose
+// context holds it.
+type transaction struct {
+	mu sync.Mutex
+	id string
+}
+
+func (t *transaction) ID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.id
+}
+
+// transactionTransport records the id of started transactions, which Trino
+// returns as a response header.
+type transactionTransport struct {
+	base http.RoundTripper
+}
+
+func (t transactionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if tx, ok := req.Context().Value(transactionKey{}).(*transaction); ok {
+		if id := resp.Header.Get(startedTransactionHeader); id != "" {
+			tx.mu.Lock()
+			tx.id = id
+			tx.mu.Unlock()
+		}
+	}
+	return resp, nil
+}
+
 func buildTrinoDSN(host, port, user, password, catalog, schema, queryTimeout, accessToken string, kerberosEnabled, sslEnabled bool, sslCertPath, sslCert string) (string, error) {
 	// Build query parameters
 	query := url.Values{}
@@ -16,12 +16,20 @@ package trino
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestBuildTrinoDSN(t *testing.T) {
@@ -269,7 +277,7 @@ func TestParseFromYamlTrino(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			got, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			got, _, _, _, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -279,3 +287,75 @@ func TestParseFromYamlTrino(t *testing.T) {
 		})
 	}
 }
+
+func TestRunSQLReadOnly(t *testing.T) {
+	var mu sync.Mutex
+	var statements, transactionIds []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := map[string]any{"id": "query", "stats": map[string]any{"state": "FINISHED"}}
+		// the results of a query are fetched from its next uri
+		if r.Method == http.MethodGet {
+			res["columns"] = []any{map[string]any{"name": "a", "type": "integer", "typeSignature": map[string]any{"rawType": "integer", "arguments": []any{}}}}
+			res["data"] = []any{[]any{1}}
+			_ = json.NewEncoder(w).Encode(res)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		statements = append(statements, string(body))
+		transactionIds = append(transactionIds, r.Header.Get("X-Trino-Transaction-Id"))
+		mu.Unlock()
+		switch string(body) {
+		case "START TRANSACTION READ ONLY":
+			w.Header().Set("X-Trino-Started-Transaction-Id", "tx-1")
+		case "SELECT 1 AS a":
+			res["nextUri"] = server.URL + "/v1/statement/query/1"
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer server.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pool, err := initTrinoConnectionPool(ctx, noop.NewTracerProvider().Tracer("test"), "test-trino", u.Hostname(), u.Port(), "user", "", "catalog", "schema", "", "", false, false, "", "", false)
+	if err != nil {
+		t.Fatalf("unable to create pool: %s", err)
+	}
+	s := &Source{Pool: pool}
+
+	got, err := s.RunSQLReadOnly(ctx, "SELECT 1 AS a", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rows, ok := got.([]any); !ok || len(rows) != 1 {
+		t.Fatalf("unexpected result: %v", got)
+	}
+	wantStatements := []string{"START TRANSACTION READ ONLY", "SELECT 1 AS a", "ROLLBACK"}
+	if diff := cmp.Diff(wantStatements, statements); diff != "" {
+		t.Fatalf("unexpected statements: diff %v", diff)
+	}
+	// the statement and the rollback run within the started transaction
+	wantIds := []string{"NONE", "tx-1", "tx-1"}
+	if diff := cmp.Diff(wantIds, transactionIds); diff != "" {
+		t.Fatalf("unexpected transaction ids: diff %v", diff)
+	}
+}
+
+func TestInitTrinoConnectionPoolInvalidCert(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = initTrinoConnectionPool(ctx, noop.NewTracerProvider().Tracer("test"), "test-trino", "localhost", "8443", "user", "", "catalog", "schema", "", "", false, true, "", "not a certificate", false)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse SSL certificate") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
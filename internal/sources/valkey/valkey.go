@@ -19,6 +19,7 @@ import (
 	"log"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/valkey-io/valkey-go"
 	"go.opentelemetry.io/otel/trace"
@@ -33,6 +34,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -119,7 +121,11 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Client.Do(ctx, s.Client.B().Ping().Build()).Error()
 }
 
 func (s *Source) ValkeyClient() valkey.Client {
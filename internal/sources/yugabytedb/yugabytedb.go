@@ -19,7 +19,9 @@ import (
 	"fmt"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 	"github.com/yugabyte/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -33,6 +35,7 @@ func init() {
 	if !sources.Register(SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
+	sources.RegisterImplementation[*Source](SourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -56,6 +59,7 @@ type Config struct {
 	YBServersRefreshInterval        string `yaml:"ybServersRefreshInterval"`
 	FallBackToTopologyKeysOnly      string `yaml:"fallbackToTopologyKeysOnly"`
 	FailedHostReconnectDelaySeconds string `yaml:"failedHostReconnectDelaySecs"`
+	sqlutil.ResultLimits            `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -92,7 +96,21 @@ func (s *Source) SourceType() string {
 }
 
 func (s *Source) ToConfig() sources.SourceConfig {
-	return s.Config
+	return secrets.Redact(s.Config)
+}
+
+func (s *Source) PoolStats() sources.PoolStats {
+	// the yugabyte fork of pgx has its own pool type
+	stat := s.Pool.Stat()
+	return sources.PoolStats{
+		InUse: int64(stat.AcquiredConns()),
+		Idle:  int64(stat.IdleConns()),
+		Max:   int64(stat.MaxConns()),
+	}
+}
+
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
 }
 
 func (s *Source) YugabyteDBPool() *pgxpool.Pool {
@@ -104,10 +122,11 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	defer results.Close()
 
 	fields := results.FieldDescriptions()
 
-	var out []any
+	out := sqlutil.NewRows(ctx, s.ResultLimits)
 	for results.Next() {
 		v, err := results.Values()
 		if err != nil {
@@ -117,7 +136,9 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		for i, f := range fields {
 			vMap[f.Name] = v[i]
 		}
-		out = append(out, vMap)
+		if !out.Add(vMap) {
+			break
+		}
 	}
 
 	// this will catch actual query execution errors
@@ -125,7 +146,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
 
-	return out, nil
+	return out.Result(), nil
 }
 
 func initYugabyteDBConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, loadBalance, topologyKeys, refreshInterval, explicitFallback, failedHostTTL string) (*pgxpool.Pool, error) {
@@ -31,6 +31,14 @@ const (
 	toolInvokeCountName = "toolbox.server.tool.invoke.count"
 	mcpSseCountName     = "toolbox.server.mcp.sse.count"
 	mcpPostCountName    = "toolbox.server.mcp.post.count"
+
+	toolInvokeDurationName = "toolbox.server.tool.invoke.duration"
+	toolInvokeActiveName   = "toolbox.server.tool.invoke.active"
+	toolResultRowsName     = "toolbox.server.tool.result.rows"
+	toolResultSizeName     = "toolbox.server.tool.result.size"
+	mcpSessionsName        = "toolbox.server.mcp.sessions"
+	sourcePoolConnsName    = "toolbox.source.pool.connections"
+	sourcePoolMaxConnsName = "toolbox.source.pool.max_connections"
 )
 
 // Instrumentation defines the telemetry instrumentation for toolbox
@@ -42,6 +50,31 @@ type Instrumentation struct {
 	ToolInvoke metric.Int64Counter
 	McpSse     metric.Int64Counter
 	McpPost    metric.Int64Counter
+
+	// ToolInvokeDuration is the latency of tool invocations.
+	ToolInvokeDuration metric.Float64Histogram
+	// ToolInvokeActive is the number of tool invocations in progress.
+	ToolInvokeActive metric.Int64UpDownCounter
+	// ToolResultRows is the number of rows returned by tool invocations.
+	ToolResultRows metric.Int64Histogram
+	// ToolResultSize is the size of the JSON encoded results of tool
+	// invocations.
+	ToolResultSize metric.Int64Histogram
+	// McpSessions is the number of active MCP sessions, observed through a
+	// callback registered by the server.
+	McpSessions metric.Int64ObservableGauge
+	// SourcePoolConnections is the number of connections of the connection
+	// pools of sources, observed through a callback registered by the server.
+	SourcePoolConnections metric.Int64ObservableGauge
+	// SourcePoolMaxConnections is the maximum number of connections of the
+	// connection pools of sources.
+	SourcePoolMaxConnections metric.Int64ObservableGauge
+}
+
+// RegisterCallback registers a callback that observes the given observable
+// instruments on every collection.
+func (i *Instrumentation) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	return i.meter.RegisterCallback(f, instruments...)
 }
 
 func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, error) {
@@ -96,14 +129,87 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		return nil, fmt.Errorf("unable to create %s metric: %w", mcpPostCountName, err)
 	}
 
+	toolInvokeDuration, err := meter.Float64Histogram(
+		toolInvokeDurationName,
+		metric.WithDescription("Duration of tool invocations."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeDurationName, err)
+	}
+
+	toolInvokeActive, err := meter.Int64UpDownCounter(
+		toolInvokeActiveName,
+		metric.WithDescription("Number of tool invocations in progress."),
+		metric.WithUnit("{invocation}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeActiveName, err)
+	}
+
+	toolResultRows, err := meter.Int64Histogram(
+		toolResultRowsName,
+		metric.WithDescription("Number of rows returned by tool invocations."),
+		metric.WithUnit("{row}"),
+		metric.WithExplicitBucketBoundaries(0, 1, 10, 100, 1000, 10000, 100000),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolResultRowsName, err)
+	}
+
+	toolResultSize, err := meter.Int64Histogram(
+		toolResultSizeName,
+		metric.WithDescription("Size of the results of tool invocations."),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(1<<10, 1<<13, 1<<16, 1<<18, 1<<20, 1<<22, 1<<24),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolResultSizeName, err)
+	}
+
+	mcpSessions, err := meter.Int64ObservableGauge(
+		mcpSessionsName,
+		metric.WithDescription("Number of active MCP sessions."),
+		metric.WithUnit("{session}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", mcpSessionsName, err)
+	}
+
+	sourcePoolConns, err := meter.Int64ObservableGauge(
+		sourcePoolConnsName,
+		metric.WithDescription("Number of connections of the connection pool of a source."),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", sourcePoolConnsName, err)
+	}
+
+	sourcePoolMaxConns, err := meter.Int64ObservableGauge(
+		sourcePoolMaxConnsName,
+		metric.WithDescription("Maximum number of connections of the connection pool of a source."),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", sourcePoolMaxConnsName, err)
+	}
+
 	instrumentation := &Instrumentation{
-		Tracer:     tracer,
-		meter:      meter,
-		ToolsetGet: toolsetGet,
-		ToolGet:    toolGet,
-		ToolInvoke: toolInvoke,
-		McpSse:     mcpSse,
-		McpPost:    mcpPost,
+		Tracer:                   tracer,
+		meter:                    meter,
+		ToolsetGet:               toolsetGet,
+		ToolGet:                  toolGet,
+		ToolInvoke:               toolInvoke,
+		McpSse:                   mcpSse,
+		McpPost:                  mcpPost,
+		ToolInvokeDuration:       toolInvokeDuration,
+		ToolInvokeActive:         toolInvokeActive,
+		ToolResultRows:           toolResultRows,
+		ToolResultSize:           toolResultSize,
+		McpSessions:              mcpSessions,
+		SourcePoolConnections:    sourcePoolConns,
+		SourcePoolMaxConnections: sourcePoolMaxConns,
 	}
 	return instrumentation, nil
 }
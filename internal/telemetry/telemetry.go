@@ -18,22 +18,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 
 	mexporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/metric"
 	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/propagators/autoprop"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/log/global"
+	logsdk "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
+var (
+	prometheusMu      sync.Mutex
+	prometheusHandler http.Handler
+)
+
+// PrometheusHandler returns the handler serving the metrics in the Prometheus
+// exposition format, or nil if the Prometheus exporter is not set up. Like the
+// meter provider, the exporter is global to the process.
+func PrometheusHandler() http.Handler {
+	prometheusMu.Lock()
+	defer prometheusMu.Unlock()
+	return prometheusHandler
+}
+
+func setPrometheusHandler(h http.Handler) {
+	prometheusMu.Lock()
+	defer prometheusMu.Unlock()
+	prometheusHandler = h
+}
+
 // setupOTelSDK bootstraps the OpenTelemetry pipeline.
 // If it does not return an error, make sure to call shutdown for proper cleanup.
-func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemetryGCP bool, telemetryServiceName string) (shutdown func(context.Context) error, err error) {
+func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemetryGCP bool, telemetryServiceName string, telemetryPrometheus bool) (shutdown func(context.Context) error, err error) {
 	var shutdownFuncs []func(context.Context) error
 
 	// shutdown calls cleanup functions registered via shutdownFuncs.
@@ -72,7 +101,23 @@ func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemet
 	shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
 	otel.SetTracerProvider(tracerProvider)
 
-	meterProvider, err := newMeterProvider(ctx, res, telemetryOTLP, telemetryGCP)
+	var prometheusReader metric.Reader
+	if telemetryPrometheus {
+		var handler http.Handler
+		prometheusReader, handler, err = newPrometheusExporter()
+		if err != nil {
+			errMsg := fmt.Errorf("unable to set up prometheus exporter: %w", err)
+			handleErr(errMsg)
+			return
+		}
+		setPrometheusHandler(handler)
+		shutdownFuncs = append(shutdownFuncs, func(context.Context) error {
+			setPrometheusHandler(nil)
+			return nil
+		})
+	}
+
+	meterProvider, err := newMeterProvider(ctx, res, telemetryOTLP, telemetryGCP, prometheusReader)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to set up meter provider: %w", err)
 		handleErr(errMsg)
@@ -81,6 +126,15 @@ func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemet
 	shutdownFuncs = append(shutdownFuncs, meterProvider.Shutdown)
 	otel.SetMeterProvider(meterProvider)
 
+	loggerProvider, err := newLoggerProvider(ctx, res, telemetryOTLP)
+	if err != nil {
+		errMsg := fmt.Errorf("unable to set up logger provider: %w", err)
+		handleErr(errMsg)
+		return
+	}
+	shutdownFuncs = append(shutdownFuncs, loggerProvider.Shutdown)
+	global.SetLoggerProvider(loggerProvider)
+
 	return shutdown, nil
 }
 
@@ -135,8 +189,11 @@ func newTracerProvider(ctx context.Context, r *resource.Resource, telemetryOTLP
 
 // newMeterProvider creates MeterProvider.
 // MeterProvider is a factory for Meters, and is responsible for creating metrics.
-func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string, telemetryGCP bool) (*metric.MeterProvider, error) {
+func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string, telemetryGCP bool, prometheusReader metric.Reader) (*metric.MeterProvider, error) {
 	metricOpts := []metric.Option{}
+	if prometheusReader != nil {
+		metricOpts = append(metricOpts, metric.WithReader(prometheusReader))
+	}
 	if telemetryOTLP != "" {
 		// otlpmetrichttp provides an OTLP metrics exporter using HTTP with protobuf payloads.
 		// By default, the telemetry is sent to https://localhost:4318/v1/metrics.
@@ -157,3 +214,38 @@ func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP s
 	meterProvider := metric.NewMeterProvider(metricOpts...)
 	return meterProvider, nil
 }
+
+// newPrometheusExporter creates a reader that exposes the metrics in the
+// Prometheus exposition format, together with the Go runtime and process
+// metrics, through the returned handler.
+func newPrometheusExporter() (metric.Reader, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, err
+	}
+	return exporter, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
+
+// newLoggerProvider creates LoggerProvider.
+// LoggerProvider is a factory for Loggers, and is responsible for emitting log
+// records such as audit events.
+func newLoggerProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string) (*logsdk.LoggerProvider, error) {
+	logOpts := []logsdk.LoggerProviderOption{logsdk.WithResource(r)}
+	if telemetryOTLP != "" {
+		// otlploghttp provides an OTLP logs exporter using HTTP with protobuf payloads.
+		// By default, the telemetry is sent to https://localhost:4318/v1/logs.
+		otlpExporter, err := otlploghttp.New(ctx, otlploghttp.WithEndpoint(telemetryOTLP))
+		if err != nil {
+			return nil, err
+		}
+		logOpts = append(logOpts, logsdk.WithProcessor(logsdk.NewBatchProcessor(otlpExporter)))
+	}
+
+	loggerProvider := logsdk.NewLoggerProvider(logOpts...)
+	return loggerProvider, nil
+}
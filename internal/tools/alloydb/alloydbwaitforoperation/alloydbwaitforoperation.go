@@ -16,6 +16,7 @@ package alloydbwaitforoperation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
@@ -76,6 +78,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -234,7 +237,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("missing 'operation' parameter")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	ctx, cancel := context.WithTimeoutCause(ctx, 30*time.Minute, errors.New("timed out waiting for operation"))
 	defer cancel()
 
 	delay := t.Delay
@@ -246,7 +249,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	for retries < maxRetries {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("timed out waiting for operation: %w", ctx.Err())
+			return nil, fmt.Errorf("stopped waiting for operation: %w", context.Cause(ctx))
 		default:
 		}
 
@@ -257,7 +260,12 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 			return op, nil
 		}
 
-		time.Sleep(delay)
+		util.ReportProgress(ctx, float64(retries+1), float64(maxRetries), fmt.Sprintf("operation %s is still in progress", operation))
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for operation: %w", context.Cause(ctx))
+		case <-time.After(delay):
+		}
 		delay = time.Duration(float64(delay) * multiplier)
 		if delay > maxDelay {
 			delay = maxDelay
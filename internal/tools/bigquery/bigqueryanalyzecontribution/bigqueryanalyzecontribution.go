@@ -37,6 +37,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -284,7 +285,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("failed to start create model job: %w", err)
 	}
 
-	status, err := createModelJob.Wait(ctx)
+	status, err := bigqueryds.WaitForJob(ctx, createModelJob)
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait for create model job: %w", err)
 	}
@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 	bigqueryrestapi "google.golang.org/api/bigquery/v2"
 )
@@ -68,11 +70,71 @@ func BQTypeStringFromToolType(toolType string) (string, error) {
 		return "FLOAT64", nil
 	case "boolean":
 		return "BOOL", nil
+	case parameters.TypeDate:
+		return "DATE", nil
+	case parameters.TypeTime:
+		return "TIME", nil
+	case parameters.TypeDateTime:
+		return "TIMESTAMP", nil
+	case parameters.TypeDuration:
+		return "INTERVAL", nil
 	default:
 		return "", fmt.Errorf("unsupported tool parameter type for BigQuery: %s", toolType)
 	}
 }
 
+// BQValueFromToolValue converts the value of a temporal tool parameter, or a
+// slice of them, to the Go type the BigQuery client binds as the BigQuery type
+// of the parameter. Other values are returned unchanged.
+func BQValueFromToolValue(toolType string, value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		switch toolType {
+		case parameters.TypeDate:
+			return civil.DateOf(v)
+		case parameters.TypeTime:
+			return civil.TimeOf(v)
+		}
+	case time.Duration:
+		return bigqueryapi.IntervalValueFromDuration(v)
+	case []time.Time:
+		switch toolType {
+		case parameters.TypeDate:
+			dates := make([]civil.Date, len(v))
+			for i, t := range v {
+				dates[i] = civil.DateOf(t)
+			}
+			return dates
+		case parameters.TypeTime:
+			times := make([]civil.Time, len(v))
+			for i, t := range v {
+				times[i] = civil.TimeOf(t)
+			}
+			return times
+		}
+	case []time.Duration:
+		intervals := make([]*bigqueryapi.IntervalValue, len(v))
+		for i, d := range v {
+			intervals[i] = bigqueryapi.IntervalValueFromDuration(d)
+		}
+		return intervals
+	}
+	return value
+}
+
+// BQValueString formats a parameter value for the REST API, which is used for
+// dry runs.
+func BQValueString(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999-07:00")
+	case civil.Time:
+		return bigqueryapi.CivilTimeString(v)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
 // InitializeDatasetParameters generates project and dataset tool parameters based on allowedDatasets.
 func InitializeDatasetParameters(
 	allowedDatasets []string,
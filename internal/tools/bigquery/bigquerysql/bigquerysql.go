@@ -37,6 +37,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -135,6 +136,10 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 			if err != nil {
 				return nil, fmt.Errorf("unable to convert parameter `%s` from []any to typed slice: %w", name, err)
 			}
+			value = bqutil.BQValueFromToolValue(itemType, value)
+		} else {
+			// Dates, times and durations are bound as their BigQuery types.
+			value = bqutil.BQValueFromToolValue(p.GetType(), value)
 		}
 
 		// Determine if the parameter is named or positional for the high-level client.
@@ -170,10 +175,19 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 			arrayValues := make([]*bigqueryrestapi.QueryParameterValue, sliceVal.Len())
 			for i := 0; i < sliceVal.Len(); i++ {
 				arrayValues[i] = &bigqueryrestapi.QueryParameterValue{
-					Value: fmt.Sprintf("%v", sliceVal.Index(i).Interface()),
+					Value: bqutil.BQValueString(sliceVal.Index(i).Interface()),
 				}
 			}
 			lowLevelParam.ParameterValue.ArrayValues = arrayValues
+		} else if vector, ok := value.([]float64); ok && p.GetEmbeddedBy() != "" {
+			// Embedded parameters are bound as ARRAY<FLOAT64>.
+			lowLevelParam.ParameterType.Type = "ARRAY"
+			lowLevelParam.ParameterType.ArrayType = &bigqueryrestapi.QueryParameterType{Type: "FLOAT64"}
+			arrayValues := make([]*bigqueryrestapi.QueryParameterValue, len(vector))
+			for i, f := range vector {
+				arrayValues[i] = &bigqueryrestapi.QueryParameterValue{Value: fmt.Sprintf("%v", f)}
+			}
+			lowLevelParam.ParameterValue.ArrayValues = arrayValues
 		} else {
 			// Handle scalar types based on their defined type.
 			bqType, err := bqutil.BQTypeStringFromToolType(p.GetType())
@@ -181,7 +195,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 				return nil, err
 			}
 			lowLevelParam.ParameterType.Type = bqType
-			lowLevelParam.ParameterValue.Value = fmt.Sprintf("%v", value)
+			lowLevelParam.ParameterValue.Value = bqutil.BQValueString(value)
 		}
 		lowLevelParams = append(lowLevelParams, lowLevelParam)
 	}
@@ -218,7 +232,7 @@ func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any)
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsFloat64Array)
 }
 
 func (t Tool) Manifest() tools.Manifest {
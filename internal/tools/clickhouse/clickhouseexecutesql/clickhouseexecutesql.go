@@ -31,6 +31,7 @@ func init() {
 	if !tools.Register(executeSQLType, newExecuteSQLConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", executeSQLType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](executeSQLType)
 }
 
 func newExecuteSQLConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -43,6 +44,7 @@ func newExecuteSQLConfig(ctx context.Context, name string, decoder *yaml.Decoder
 
 type compatibleSource interface {
 	RunSQL(context.Context, string, parameters.ParamValues) (any, error)
+	RunSQLReadOnly(context.Context, string, parameters.ParamValues) (any, error)
 }
 
 type Config struct {
@@ -51,6 +53,7 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	ReadOnly     bool     `yaml:"readOnly"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -98,6 +101,10 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if !ok {
 		return nil, fmt.Errorf("unable to cast sql parameter %s", paramsMap["sql"])
 	}
+
+	if t.ReadOnly {
+		return source.RunSQLReadOnly(ctx, sql, nil)
+	}
 	return source.RunSQL(ctx, sql, nil)
 }
 
@@ -31,6 +31,7 @@ func init() {
 	if !tools.Register(listDatabasesType, newListDatabasesConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", listDatabasesType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](listDatabasesType)
 }
 
 func newListDatabasesConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 const listTablesType string = "clickhouse-list-tables"
@@ -32,6 +33,7 @@ func init() {
 	if !tools.Register(listTablesType, newListTablesConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", listTablesType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](listTablesType)
 }
 
 func newListTablesConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -109,7 +111,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, err
 	}
 
-	res, ok := out.([]any)
+	res, ok := sqlutil.ResultRows(out)
 	if !ok {
 		return nil, fmt.Errorf("unable to convert result to list")
 	}
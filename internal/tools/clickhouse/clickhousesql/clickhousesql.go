@@ -31,6 +31,7 @@ func init() {
 	if !tools.Register(sqlType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", sqlType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](sqlType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -113,7 +114,7 @@ func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any)
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsFloat32Array)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -103,7 +103,7 @@ func TestParseFromYaml(t *testing.T) {
 		tc := tc
 		t.Run(tc.desc, func(t *testing.T) {
 			t.Parallel()
-			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			_, _, _, got, _, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -332,7 +332,7 @@ func TestInvoke(t *testing.T) {
 		{Name: "query", Value: "How many accounts who have region in Prague are eligible for loans?"},
 	}
 
-	resourceMgr := resources.NewResourceManager(srcs, nil, nil, nil, nil, nil, nil)
+	resourceMgr := resources.NewResourceManager(srcs, nil, nil, nil, nil, nil, nil, nil)
 
 	// Invoke the tool
 	result, err := tool.Invoke(ctx, resourceMgr, params, "") // No accessToken needed for ADC client
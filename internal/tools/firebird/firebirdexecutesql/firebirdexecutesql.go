@@ -25,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 const resourceType string = "firebird-execute-sql"
@@ -33,6 +34,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -46,6 +48,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	FirebirdDB() *sql.DB
 	RunSQL(context.Context, string, []any) (any, error)
+	RunSQLReadOnly(context.Context, string, []any) (any, error)
 }
 
 type Config struct {
@@ -54,6 +57,7 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	ReadOnly     bool     `yaml:"readOnly"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -108,6 +112,14 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("error getting logger: %s", err)
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("executing `%s` tool query: %s", resourceType, sql))
+
+	if t.ReadOnly {
+		// statements such as COMMIT end the read-only transaction
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, sql); err != nil {
+			return nil, err
+		}
+		return source.RunSQLReadOnly(ctx, sql, nil)
+	}
 	return source.RunSQL(ctx, sql, nil)
 }
 
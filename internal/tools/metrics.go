@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// SourceOf returns the name and type of the source the tool is configured
+// with, or empty strings if the tool does not use a source.
+func SourceOf(t Tool, sp SourceProvider) (string, string) {
+	sourceName := ConfigSourceName(t.ToConfig())
+	if sourceName == "" {
+		return "", ""
+	}
+	if s, ok := sp.GetSource(sourceName); ok {
+		return sourceName, s.SourceType()
+	}
+	return sourceName, ""
+}
+
+// ConfigSourceName returns the name of the source of a tool config, or an
+// empty string if the tool does not use a source.
+func ConfigSourceName(cfg ToolConfig) string {
+	if o, ok := cfg.(OptionsToolConfig); ok {
+		cfg = o.ToolConfig
+	}
+	return configStringField(cfg, "source")
+}
+
+// configStringField returns the value of the string field of a tool config
+// with the given YAML name.
+func configStringField(cfg ToolConfig, field string) string {
+	if v, ok := configField(cfg, field); ok && v.Kind() == reflect.String {
+		return v.String()
+	}
+	return ""
+}
+
+// configField returns the value of the field of a tool config with the given
+// YAML name.
+func configField(cfg ToolConfig, field string) (reflect.Value, bool) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == field {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// InvocationMetrics records the metrics of a tool invocation. A nil
+// InvocationMetrics is valid and records nothing.
+type InvocationMetrics struct {
+	instrumentation *telemetry.Instrumentation
+	attrs           []attribute.KeyValue
+	start           time.Time
+}
+
+// StartInvocationMetrics starts measuring the invocation of a tool. It returns
+// nil if the context has no instrumentation.
+func StartInvocationMetrics(ctx context.Context, toolName string, t Tool, sp SourceProvider) *InvocationMetrics {
+	instrumentation, err := util.InstrumentationFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	sourceName, sourceType := SourceOf(t, sp)
+	m := &InvocationMetrics{
+		instrumentation: instrumentation,
+		attrs: []attribute.KeyValue{
+			attribute.String("toolbox.name", toolName),
+			attribute.String("toolbox.source.name", sourceName),
+			attribute.String("toolbox.source.type", sourceType),
+		},
+		start: time.Now(),
+	}
+	instrumentation.ToolInvokeActive.Add(ctx, 1, metric.WithAttributes(m.attrs...))
+	return m
+}
+
+// Done records the duration of the invocation, which returned err.
+func (m *InvocationMetrics) Done(ctx context.Context, err error) {
+	if m == nil {
+		return
+	}
+	m.instrumentation.ToolInvokeActive.Add(ctx, -1, metric.WithAttributes(m.attrs...))
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	m.instrumentation.ToolInvokeDuration.Record(
+		ctx,
+		time.Since(m.start).Seconds(),
+		metric.WithAttributes(m.attrs...),
+		metric.WithAttributes(attribute.String("toolbox.operation.status", status)),
+	)
+}
+
+// RecordResult records the number of rows of the result, if it has rows, and
+// the size in bytes of its encoding in the response.
+func (m *InvocationMetrics) RecordResult(ctx context.Context, res any, size int) {
+	if m == nil {
+		return
+	}
+	if rows, ok := sqlutil.ResultRows(res); ok {
+		m.instrumentation.ToolResultRows.Record(ctx, int64(len(rows)), metric.WithAttributes(m.attrs...))
+	}
+	m.instrumentation.ToolResultSize.Record(ctx, int64(size), metric.WithAttributes(m.attrs...))
+}
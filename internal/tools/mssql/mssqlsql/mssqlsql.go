@@ -19,8 +19,10 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
+	"github.com/golang-sql/civil"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -33,6 +35,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -116,7 +119,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	// if arg name is contained in the statement.
 	for _, p := range t.Parameters {
 		name := p.GetName()
-		value := paramsMap[name]
+		value := mssqlValue(p.GetType(), paramsMap[name])
 		if strings.Contains(newStatement, "@"+name) {
 			namedArgs = append(namedArgs, sql.Named(name, value))
 		} else {
@@ -126,12 +129,33 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	return source.RunSQL(ctx, newStatement, namedArgs)
 }
 
+// mssqlValue converts the value of a temporal parameter to a value the SQL
+// Server driver binds correctly. Dates and times are bound as DATE and TIME,
+// since a time of day is a time.Time in the year 0, which DATETIME cannot
+// hold, and durations as seconds, e.g. for `DATEADD(second, @d, ...)`, since a
+// time.Duration is bound as an integer number of nanoseconds. Other values are
+// returned unchanged.
+func mssqlValue(paramType string, value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		switch paramType {
+		case parameters.TypeDate:
+			return civil.DateOf(v)
+		case parameters.TypeTime:
+			return civil.TimeOf(v)
+		}
+	case time.Duration:
+		return v.Seconds()
+	}
+	return value
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
 	return parameters.ParseParams(t.AllParams, data, claims)
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsJSONArray)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -15,10 +15,16 @@
 package mssqlsql_test
 
 import (
+	"context"
+	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/golang-sql/civil"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlsql"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -77,7 +83,7 @@ func TestParseFromYamlMssql(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
 			// Parse contents
-			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			_, _, _, got, _, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -156,7 +162,7 @@ func TestParseFromYamlWithTemplateMssql(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
 			// Parse contents
-			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			_, _, _, got, _, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -166,3 +172,69 @@ func TestParseFromYamlWithTemplateMssql(t *testing.T) {
 		})
 	}
 }
+
+// fakeSource records the arguments of the statements it runs.
+type fakeSource struct {
+	args []any
+}
+
+func (s *fakeSource) SourceType() string { return "mssql" }
+
+func (s *fakeSource) ToConfig() sources.SourceConfig { return nil }
+
+func (s *fakeSource) MSSQLDB() *sql.DB { return nil }
+
+func (s *fakeSource) RunSQL(_ context.Context, _ string, args []any) (any, error) {
+	s.args = args
+	return nil, nil
+}
+
+type fakeSourceProvider map[string]sources.Source
+
+func (p fakeSourceProvider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvokeTemporalParams(t *testing.T) {
+	tool, err := mssqlsql.Config{
+		Name:        "example_tool",
+		Type:        "mssql-sql",
+		Source:      "my-mssql-instance",
+		Description: "some description",
+		Statement:   "SELECT * FROM flights WHERE day = @p1 AND departure_time >= @p2 AND departure < DATEADD(second, @window, @since)",
+		Parameters: parameters.Parameters{
+			parameters.NewDateParameter("day", "a date"),
+			parameters.NewTimeParameter("departure_time", "a time"),
+			parameters.NewDateTimeParameter("since", "a datetime"),
+			parameters.NewDurationParameter("window", "a duration"),
+		},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{
+		"day":            "2024-03-10",
+		"departure_time": "13:45:30",
+		"since":          "2024-03-10T13:45:00Z",
+		"window":         "1h30m",
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	src := &fakeSource{}
+	if _, err := tool.Invoke(context.Background(), fakeSourceProvider{"my-mssql-instance": src}, params, ""); err != nil {
+		t.Fatalf("unable to invoke tool: %s", err)
+	}
+	// times are not in the year 0, which SQL Server cannot hold, and durations
+	// are in seconds
+	want := []any{
+		civil.Date{Year: 2024, Month: time.March, Day: 10},
+		civil.Time{Hour: 13, Minute: 45, Second: 30},
+		sql.Named("since", time.Date(2024, time.March, 10, 13, 45, 0, 0, time.UTC)),
+		sql.Named("window", 5400.0),
+	}
+	if diff := cmp.Diff(want, src.args, cmpopts.IgnoreUnexported(sql.NamedArg{})); diff != "" {
+		t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+	}
+}
@@ -18,6 +18,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"reflect"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
 // ConvertToType handles casting mysql returns to the right type
@@ -41,3 +44,24 @@ func ConvertToType(t *sql.ColumnType, v any) (any, error) {
 		return v, nil
 	}
 }
+
+// ConvertParamValue converts the value of a temporal tool parameter to a value
+// the mysql driver binds correctly. The driver sends a time.Time as a DATETIME
+// in its own timezone, so dates and times are sent as DATE and TIME literals
+// instead, and a time.Duration as an integer number of nanoseconds, so
+// durations are sent as seconds, e.g. for `INTERVAL ? SECOND`. Other values
+// are returned unchanged.
+func ConvertParamValue(paramType string, value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		switch paramType {
+		case parameters.TypeDate:
+			return v.Format(time.DateOnly)
+		case parameters.TypeTime:
+			return v.Format("15:04:05.999999")
+		}
+	case time.Duration:
+		return v.Seconds()
+	}
+	return value
+}
@@ -18,11 +18,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
@@ -32,6 +34,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -60,6 +63,7 @@ type Config struct {
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.OutputColumnsDescriber = Config{}
 
 func (cfg Config) ToolConfigType() string {
 	return resourceType
@@ -83,6 +87,69 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	return t, nil
 }
 
+// columnTypes are the parameter types of the columns that can be derived, by
+// their MySQL type name. Other values, such as DECIMAL and DATETIME, are
+// returned as strings.
+var columnTypes = map[string]string{
+	"TINYINT":            "integer",
+	"SMALLINT":           "integer",
+	"MEDIUMINT":          "integer",
+	"INT":                "integer",
+	"BIGINT":             "integer",
+	"UNSIGNED TINYINT":   "integer",
+	"UNSIGNED SMALLINT":  "integer",
+	"UNSIGNED MEDIUMINT": "integer",
+	"UNSIGNED INT":       "integer",
+	"UNSIGNED BIGINT":    "integer",
+	"YEAR":               "integer",
+	"FLOAT":              "float",
+	"DOUBLE":             "float",
+	"DECIMAL":            "string",
+	"CHAR":               "string",
+	"VARCHAR":            "string",
+	"TEXT":               "string",
+	"ENUM":               "string",
+	"SET":                "string",
+	"DATE":               "string",
+	"DATETIME":           "string",
+	"TIMESTAMP":          "string",
+	"TIME":               "string",
+}
+
+// DescribeOutputColumns derives the output columns from the column metadata
+// of the statement, which is run as a subquery that returns no rows.
+func (cfg Config) DescribeOutputColumns(ctx context.Context, srcs map[string]sources.Source) (parameters.Parameters, error) {
+	if len(cfg.TemplateParameters) > 0 {
+		return nil, fmt.Errorf("the columns of a statement with template parameters cannot be derived")
+	}
+	source, ok := srcs[cfg.Source].(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("source %q is not available or not compatible", cfg.Source)
+	}
+	statement := strings.TrimRight(strings.TrimSpace(cfg.Statement), ";")
+	// the values of the parameters do not change the columns
+	args := make([]any, len(cfg.Parameters))
+	rows, err := source.MySQLPool().QueryContext(ctx, fmt.Sprintf("SELECT * FROM (%s) AS t LIMIT 0", statement), args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe statement: %w", err)
+	}
+	defer rows.Close()
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get column types: %w", err)
+	}
+	columns := make(parameters.Parameters, 0, len(colTypes))
+	for _, ct := range colTypes {
+		nullable, ok := ct.Nullable()
+		c, err := tools.NewOutputColumn(ct.Name(), columnTypes[ct.DatabaseTypeName()], ct.DatabaseTypeName(), nullable || !ok)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
 // validate interface
 var _ tools.Tool = Tool{}
 
@@ -111,6 +178,9 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	sliceParams := newParams.AsSlice()
+	for i, p := range t.Parameters {
+		sliceParams[i] = mysqlcommon.ConvertParamValue(p.GetType(), sliceParams[i])
+	}
 	return source.RunSQL(ctx, newStatement, sliceParams)
 }
 
@@ -119,7 +189,7 @@ func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any)
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsJSONArray)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -15,10 +15,14 @@
 package mysqlsql_test
 
 import (
+	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlsql"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -77,7 +81,7 @@ func TestParseFromYamlMySQL(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
 			// Parse contents
-			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			_, _, _, got, _, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -156,7 +160,7 @@ func TestParseFromYamlWithTemplateParamsMySQL(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
 			// Parse contents
-			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			_, _, _, got, _, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -166,3 +170,66 @@ func TestParseFromYamlWithTemplateParamsMySQL(t *testing.T) {
 		})
 	}
 }
+
+// fakeSource records the arguments of the statements it runs.
+type fakeSource struct {
+	args []any
+}
+
+func (s *fakeSource) SourceType() string { return "mysql" }
+
+func (s *fakeSource) ToConfig() sources.SourceConfig { return nil }
+
+func (s *fakeSource) MySQLPool() *sql.DB { return nil }
+
+func (s *fakeSource) RunSQL(_ context.Context, _ string, args []any) (any, error) {
+	s.args = args
+	return nil, nil
+}
+
+type fakeSourceProvider map[string]sources.Source
+
+func (p fakeSourceProvider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvokeTemporalParams(t *testing.T) {
+	date := parameters.NewDateParameter("date", "a date")
+	date.Timezone = "America/New_York"
+	tool, err := mysqlsql.Config{
+		Name:        "example_tool",
+		Type:        "mysql-sql",
+		Source:      "my-mysql-instance",
+		Description: "some description",
+		Statement:   "SELECT * FROM flights WHERE day = ? AND departure >= ? AND departure < ? + INTERVAL ? SECOND",
+		Parameters: parameters.Parameters{
+			date,
+			parameters.NewTimeParameter("time", "a time"),
+			parameters.NewDateTimeParameter("datetime", "a datetime"),
+			parameters.NewDurationParameter("duration", "a duration"),
+		},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{
+		"date":     "2024-03-10",
+		"time":     "13:45:30.5",
+		"datetime": "2024-03-10T13:45:00Z",
+		"duration": "1h30m",
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	src := &fakeSource{}
+	if _, err := tool.Invoke(context.Background(), fakeSourceProvider{"my-mysql-instance": src}, params, ""); err != nil {
+		t.Fatalf("unable to invoke tool: %s", err)
+	}
+	// dates are not shifted to the timezone of the driver, and durations are
+	// in seconds
+	want := []any{"2024-03-10", "13:45:30.5", time.Date(2024, time.March, 10, 13, 45, 0, 0, time.UTC), 5400.0}
+	if diff := cmp.Diff(want, src.args); diff != "" {
+		t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+	}
+}
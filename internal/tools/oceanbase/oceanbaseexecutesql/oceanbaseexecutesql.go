@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
 )
 
 const resourceType string = "oceanbase-execute-sql"
@@ -32,11 +33,13 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 type compatibleSource interface {
 	OceanBasePool() *sql.DB
 	RunSQL(context.Context, string, []any) (any, error)
+	RunSQLReadOnly(context.Context, string, []any) (any, error)
 }
 
 type Config struct {
@@ -45,6 +48,7 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	ReadOnly     bool     `yaml:"readOnly"`
 }
 
 // validate interface
@@ -100,6 +104,14 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
+
+	if t.ReadOnly {
+		// DDL statements implicitly commit the read-only transaction
+		if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, sqlStr); err != nil {
+			return nil, err
+		}
+		return source.RunSQLReadOnly(ctx, sqlStr, nil)
+	}
 	return source.RunSQL(ctx, sqlStr, nil)
 }
 
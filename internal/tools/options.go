@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+)
+
+// ToolOptions are the options that can be set on any tool. They are enforced
+// by the server instead of the tool itself.
+type ToolOptions struct {
+	// Policies are the authorization policies of the tool.
+	Policies []Policy `yaml:"policies,omitempty"`
+	// ResultLimits limit the number of rows and bytes returned by SQL based
+	// tools.
+	sqlutil.ResultLimits `yaml:",inline"`
+	// InvocationTimeout is the maximum duration of an invocation, such as
+	// "30s". It has its own key, since some tools have a `timeout` field.
+	InvocationTimeout string `yaml:"invocationTimeout,omitempty"`
+	// OutputSchema describes the rows returned by the tool, which are then
+	// also returned as structured content.
+	OutputSchema *OutputSchema `yaml:"outputSchema,omitempty"`
+}
+
+// IsZero returns true if no option is set.
+func (o ToolOptions) IsZero() bool {
+	return len(o.Policies) == 0 && o.ResultLimits == sqlutil.ResultLimits{} && o.InvocationTimeout == "" && o.OutputSchema == nil
+}
+
+// outputSchemaAuto is the value of `outputSchema` that derives the columns
+// from the source.
+const outputSchemaAuto = "auto"
+
+// OutputSchema describes the columns of the rows returned by a tool, either
+// as a list of columns or derived from the source with "auto".
+type OutputSchema struct {
+	Columns parameters.Parameters
+	Auto    bool
+}
+
+func (s *OutputSchema) UnmarshalYAML(ctx context.Context, unmarshal func(any) error) error {
+	var str string
+	if err := unmarshal(&str); err == nil {
+		if str != outputSchemaAuto {
+			return fmt.Errorf("outputSchema must be a list of columns or %q, got %q", outputSchemaAuto, str)
+		}
+		s.Auto = true
+		return nil
+	}
+	return s.Columns.UnmarshalYAML(ctx, unmarshal)
+}
+
+// OutputColumnsDescriber is implemented by the configs of tools that can
+// derive the columns of their result from the column metadata of the source,
+// for `outputSchema: auto`.
+type OutputColumnsDescriber interface {
+	DescribeOutputColumns(ctx context.Context, srcs map[string]sources.Source) (parameters.Parameters, error)
+}
+
+// NewOutputColumn returns a column of the output schema derived from the
+// column metadata of a source, described by its database type.
+func NewOutputColumn(name, paramType, dbType string, nullable bool) (parameters.Parameter, error) {
+	desc := fmt.Sprintf("The %s column.", strings.ToLower(dbType))
+	switch paramType {
+	case "string":
+		return parameters.NewStringParameterWithRequired(name, desc, !nullable), nil
+	case "integer":
+		return parameters.NewIntParameterWithRequired(name, desc, !nullable), nil
+	case "float":
+		return parameters.NewFloatParameterWithRequired(name, desc, !nullable), nil
+	case "boolean":
+		return parameters.NewBooleanParameterWithRequired(name, desc, !nullable), nil
+	default:
+		return nil, fmt.Errorf("column %q has type %s, which cannot be derived, list the columns in outputSchema instead", name, strings.ToLower(dbType))
+	}
+}
+
+// describeTimeout bounds deriving the output columns from the source.
+const describeTimeout = 30 * time.Second
+
+// outputColumns returns the columns of the output schema of the tool.
+func (cfg OptionsToolConfig) outputColumns(srcs map[string]sources.Source) (parameters.Parameters, error) {
+	if cfg.OutputSchema == nil {
+		return nil, nil
+	}
+	if !cfg.OutputSchema.Auto {
+		return cfg.OutputSchema.Columns, nil
+	}
+	d, ok := cfg.ToolConfig.(OutputColumnsDescriber)
+	if !ok {
+		return nil, fmt.Errorf("tool type %q cannot derive its output schema, list the columns in outputSchema instead", cfg.ToolConfigType())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+	columns, err := d.DescribeOutputColumns(ctx, srcs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive the output schema: %w", err)
+	}
+	return columns, nil
+}
+
+// OptionsToolConfig wraps the config of a tool that has tool options.
+type OptionsToolConfig struct {
+	ToolConfig  `yaml:",inline"`
+	ToolOptions `yaml:",inline"`
+}
+
+// Initialize initializes the wrapped tool and attaches the options to it.
+func (cfg OptionsToolConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	t, err := cfg.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := cfg.outputColumns(srcs)
+	if err != nil {
+		return nil, err
+	}
+	return optionsTool{Tool: t, cfg: cfg, outputSchema: GetMcpOutputSchema(columns)}, nil
+}
+
+// optionsTool is a tool with tool options.
+type optionsTool struct {
+	Tool
+	cfg          OptionsToolConfig
+	outputSchema *parameters.McpToolsSchema
+}
+
+func (t optionsTool) McpManifest() McpManifest {
+	m := t.Tool.McpManifest()
+	if t.outputSchema != nil {
+		m.OutputSchema = t.outputSchema
+	}
+	return m
+}
+
+func (t optionsTool) Invoke(ctx context.Context, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, error) {
+	if t.cfg.ResultLimits != (sqlutil.ResultLimits{}) {
+		ctx = sqlutil.WithResultLimits(ctx, t.cfg.ResultLimits)
+	}
+	if t.cfg.InvocationTimeout == "" {
+		return t.Tool.Invoke(ctx, resourceMgr, params, accessToken)
+	}
+	// the timeout is validated when the config is parsed
+	timeout, _ := time.ParseDuration(t.cfg.InvocationTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	res, err := t.Tool.Invoke(ctx, resourceMgr, params, accessToken)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("tool invocation exceeded its timeout of %s: %w", t.cfg.InvocationTimeout, err)
+	}
+	return res, err
+}
+
+func (t optionsTool) ToConfig() ToolConfig {
+	return t.cfg
+}
+
+// ToolOptionsOf returns the tool options configured on a tool.
+func ToolOptionsOf(t Tool) ToolOptions {
+	if ot, ok := t.(optionsTool); ok {
+		return ot.cfg.ToolOptions
+	}
+	return ToolOptions{}
+}
@@ -21,6 +21,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -34,6 +35,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	OracleDB() *sql.DB
 	RunSQL(context.Context, string, []any) (any, error)
+	RunSQLReadOnly(context.Context, string, []any) (any, error)
 }
 
 type Config struct {
@@ -42,6 +44,7 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	ReadOnly     bool     `yaml:"readOnly"`
 }
 
 // validate interface
@@ -95,6 +98,10 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("error getting logger: %s", err)
 	}
 	logger.DebugContext(ctx, "executing `%s` tool query: %s", resourceType, sqlParam)
+
+	if t.ReadOnly {
+		return source.RunSQLReadOnly(ctx, sqlParam, nil)
+	}
 	return source.RunSQL(ctx, sqlParam, nil)
 }
 
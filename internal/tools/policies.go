@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// Policy is an authorization rule that is matched against the claims of the
+// tokens verified by the auth services. Exactly one of Equals, EndsWith or
+// Contains must be set.
+type Policy struct {
+	// AuthService restricts the policy to the claims of a single auth
+	// service. If empty, the claims of any verified auth service can satisfy
+	// the policy.
+	AuthService string `yaml:"authService,omitempty"`
+	// Claim is the name of the claim the policy is evaluated on.
+	Claim string `yaml:"claim"`
+	// Equals requires the claim to be equal to the value.
+	Equals string `yaml:"equals,omitempty"`
+	// EndsWith requires the claim to be a string ending with the value.
+	EndsWith string `yaml:"endsWith,omitempty"`
+	// Contains requires the claim to be a list containing the value. A string
+	// claim is treated as a list with a single element.
+	Contains string `yaml:"contains,omitempty"`
+}
+
+// Validate checks that the policy is well-formed.
+func (p Policy) Validate() error {
+	if p.Claim == "" {
+		return fmt.Errorf("policy is missing the 'claim' field")
+	}
+	set := 0
+	for _, v := range []string{p.Equals, p.EndsWith, p.Contains} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("policy on claim %q must set exactly one of 'equals', 'endsWith' or 'contains'", p.Claim)
+	}
+	return nil
+}
+
+// String returns a human readable description of the policy, used as the
+// reason when the policy denies a request.
+func (p Policy) String() string {
+	var cond string
+	switch {
+	case p.Equals != "":
+		cond = fmt.Sprintf("claim %q must equal %q", p.Claim, p.Equals)
+	case p.EndsWith != "":
+		cond = fmt.Sprintf("claim %q must end with %q", p.Claim, p.EndsWith)
+	default:
+		cond = fmt.Sprintf("claim %q must contain %q", p.Claim, p.Contains)
+	}
+	if p.AuthService != "" {
+		cond = fmt.Sprintf("%s for auth service %q", cond, p.AuthService)
+	}
+	return cond
+}
+
+// matches returns true if the claims of a single auth service satisfy the
+// policy.
+func (p Policy) matches(claims map[string]any) bool {
+	value, ok := claims[p.Claim]
+	if !ok {
+		return false
+	}
+	switch {
+	case p.Equals != "":
+		return claimEquals(value, p.Equals)
+	case p.EndsWith != "":
+		s, ok := value.(string)
+		return ok && strings.HasSuffix(s, p.EndsWith)
+	default:
+		switch v := value.(type) {
+		case []any:
+			return slices.ContainsFunc(v, func(e any) bool { return claimEquals(e, p.Contains) })
+		case []string:
+			return slices.Contains(v, p.Contains)
+		default:
+			return claimEquals(v, p.Contains)
+		}
+	}
+}
+
+// claimEquals compares a scalar claim with a configured value. Lists and
+// objects never match.
+func claimEquals(claim any, value string) bool {
+	switch v := claim.(type) {
+	case string:
+		return v == value
+	case bool, float64, int, int64:
+		return fmt.Sprint(v) == value
+	default:
+		return false
+	}
+}
+
+// Evaluate checks the policy against claimsFromAuth, which maps the name of
+// each verified auth service to its claims.
+func (p Policy) Evaluate(claimsFromAuth map[string]map[string]any) bool {
+	if p.AuthService != "" {
+		claims, ok := claimsFromAuth[p.AuthService]
+		return ok && p.matches(claims)
+	}
+	// iterate in a stable order so that evaluation is deterministic
+	names := make([]string, 0, len(claimsFromAuth))
+	for name := range claimsFromAuth {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p.matches(claimsFromAuth[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluatePolicies checks that every policy is satisfied by claimsFromAuth.
+// The returned error wraps util.ErrForbidden and describes the first policy
+// denying the request.
+func EvaluatePolicies(policies []Policy, claimsFromAuth map[string]map[string]any) error {
+	for _, p := range policies {
+		if !p.Evaluate(claimsFromAuth) {
+			return fmt.Errorf("denied by policy: %s: %w", p, util.ErrForbidden)
+		}
+	}
+	return nil
+}
+
+// CheckPolicies evaluates the policies of a tool, followed by the policies of
+// the toolset the tool is invoked through.
+func CheckPolicies(t Tool, toolset Toolset, claimsFromAuth map[string]map[string]any) error {
+	if err := EvaluatePolicies(ToolOptionsOf(t).Policies, claimsFromAuth); err != nil {
+		return err
+	}
+	return EvaluatePolicies(toolset.Policies, claimsFromAuth)
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		policy tools.Policy
+		err    string
+	}{
+		{
+			desc:   "valid",
+			policy: tools.Policy{Claim: "email", EndsWith: "@corp.com"},
+		},
+		{
+			desc:   "missing claim",
+			policy: tools.Policy{Equals: "example.com"},
+			err:    "policy is missing the 'claim' field",
+		},
+		{
+			desc:   "missing condition",
+			policy: tools.Policy{Claim: "hd"},
+			err:    `policy on claim "hd" must set exactly one of 'equals', 'endsWith' or 'contains'`,
+		},
+		{
+			desc:   "multiple conditions",
+			policy: tools.Policy{Claim: "hd", Equals: "example.com", EndsWith: ".com"},
+			err:    `policy on claim "hd" must set exactly one of 'equals', 'endsWith' or 'contains'`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicies(t *testing.T) {
+	claimsFromAuth := map[string]map[string]any{
+		"my-google-auth": {
+			"email":          "alice@corp.com",
+			"hd":             "example.com",
+			"email_verified": true,
+		},
+		"my-oidc": {
+			"email":  "alice@other.com",
+			"groups": []any{"dev", "dba"},
+			"role":   "admin",
+		},
+	}
+	tcs := []struct {
+		desc     string
+		policies []tools.Policy
+		err      string
+	}{
+		{
+			desc: "no policies",
+		},
+		{
+			desc: "all policies satisfied",
+			policies: []tools.Policy{
+				{Claim: "email", EndsWith: "@corp.com"},
+				{Claim: "groups", Contains: "dba"},
+				{Claim: "hd", Equals: "example.com"},
+				{Claim: "email_verified", Equals: "true"},
+				{Claim: "role", Contains: "admin"},
+			},
+		},
+		{
+			desc:     "satisfied by the configured auth service",
+			policies: []tools.Policy{{AuthService: "my-oidc", Claim: "email", Equals: "alice@other.com"}},
+		},
+		{
+			desc:     "not satisfied by the configured auth service",
+			policies: []tools.Policy{{AuthService: "my-oidc", Claim: "email", EndsWith: "@corp.com"}},
+			err:      `denied by policy: claim "email" must end with "@corp.com" for auth service "my-oidc": forbidden`,
+		},
+		{
+			desc:     "auth service not verified",
+			policies: []tools.Policy{{AuthService: "other-auth", Claim: "email", EndsWith: "@corp.com"}},
+			err:      `denied by policy: claim "email" must end with "@corp.com" for auth service "other-auth": forbidden`,
+		},
+		{
+			desc: "one policy not satisfied",
+			policies: []tools.Policy{
+				{Claim: "email", EndsWith: "@corp.com"},
+				{Claim: "groups", Contains: "admin"},
+			},
+			err: `denied by policy: claim "groups" must contain "admin": forbidden`,
+		},
+		{
+			desc:     "missing claim",
+			policies: []tools.Policy{{Claim: "department", Equals: "eng"}},
+			err:      `denied by policy: claim "department" must equal "eng": forbidden`,
+		},
+		{
+			desc:     "equals does not match lists",
+			policies: []tools.Policy{{Claim: "groups", Equals: "dba"}},
+			err:      `denied by policy: claim "groups" must equal "dba": forbidden`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.EvaluatePolicies(tc.policies, claimsFromAuth)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+			if !errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("expect error to wrap util.ErrForbidden")
+			}
+		})
+	}
+
+	t.Run("no claims", func(t *testing.T) {
+		err := tools.EvaluatePolicies([]tools.Policy{{Claim: "hd", Equals: "example.com"}}, nil)
+		if !errors.Is(err, util.ErrForbidden) {
+			t.Fatalf("expect policy to deny the request, got %v", err)
+		}
+	})
+}
+
+func TestCheckPolicies(t *testing.T) {
+	claims := map[string]map[string]any{"my-auth": {"hd": "example.com"}}
+	// the same tool is part of both toolsets
+	corp := tools.Toolset{ToolsetConfig: tools.ToolsetConfig{ToolNames: []string{"my-tool"}, Policies: []tools.Policy{{Claim: "hd", Equals: "example.com"}}}}
+	dba := tools.Toolset{ToolsetConfig: tools.ToolsetConfig{ToolNames: []string{"my-tool"}, Policies: []tools.Policy{{Claim: "groups", Contains: "dba"}}}}
+	tcs := []struct {
+		desc    string
+		toolset tools.Toolset
+		wantErr bool
+	}{
+		{desc: "toolset policy satisfied", toolset: corp},
+		{desc: "toolset policy denied", toolset: dba, wantErr: true},
+		{desc: "toolset without policies", toolset: tools.Toolset{ToolsetConfig: tools.ToolsetConfig{ToolNames: []string{"my-tool"}}}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// the tool has no policies of its own
+			err := tools.CheckPolicies(nil, tc.toolset, claims)
+			if tc.wantErr != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -32,6 +33,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -60,6 +62,7 @@ type Config struct {
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.OutputColumnsDescriber = Config{}
 
 func (cfg Config) ToolConfigType() string {
 	return resourceType
@@ -83,6 +86,62 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	return t, nil
 }
 
+// columnTypes are the parameter types of the columns that can be derived,
+// by the OID of their Postgres type.
+var columnTypes = map[uint32]string{
+	pgtype.BoolOID:        "boolean",
+	pgtype.Int2OID:        "integer",
+	pgtype.Int4OID:        "integer",
+	pgtype.Int8OID:        "integer",
+	pgtype.OIDOID:         "integer",
+	pgtype.Float4OID:      "float",
+	pgtype.Float8OID:      "float",
+	pgtype.NumericOID:     "float",
+	pgtype.TextOID:        "string",
+	pgtype.VarcharOID:     "string",
+	pgtype.BPCharOID:      "string",
+	pgtype.NameOID:        "string",
+	pgtype.DateOID:        "string",
+	pgtype.TimestampOID:   "string",
+	pgtype.TimestamptzOID: "string",
+}
+
+// DescribeOutputColumns derives the output columns by preparing the statement,
+// without running it. Postgres does not describe the nullability of result
+// columns, so every column is nullable.
+func (cfg Config) DescribeOutputColumns(ctx context.Context, srcs map[string]sources.Source) (parameters.Parameters, error) {
+	if len(cfg.TemplateParameters) > 0 {
+		return nil, fmt.Errorf("the columns of a statement with template parameters cannot be derived")
+	}
+	source, ok := srcs[cfg.Source].(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("source %q is not available or not compatible", cfg.Source)
+	}
+	conn, err := source.PostgresPool().Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acquire connection: %w", err)
+	}
+	defer conn.Release()
+	desc, err := conn.Conn().Prepare(ctx, "", cfg.Statement)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prepare statement: %w", err)
+	}
+	typeMap := conn.Conn().TypeMap()
+	columns := make(parameters.Parameters, 0, len(desc.Fields))
+	for _, f := range desc.Fields {
+		dbType := fmt.Sprint(f.DataTypeOID)
+		if t, ok := typeMap.TypeForOID(f.DataTypeOID); ok {
+			dbType = t.Name
+		}
+		c, err := tools.NewOutputColumn(f.Name, columnTypes[f.DataTypeOID], dbType, true)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
 // validate interface
 var _ tools.Tool = Tool{}
 
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// ConfigAuthRequired returns the auth services required to invoke the tool of
+// a tool config.
+func ConfigAuthRequired(cfg ToolConfig) []string {
+	if o, ok := cfg.(OptionsToolConfig); ok {
+		cfg = o.ToolConfig
+	}
+	v, ok := configField(cfg, "authRequired")
+	if !ok {
+		return nil
+	}
+	authRequired, _ := v.Interface().([]string)
+	return authRequired
+}
+
+// ConfigParameters returns the parameters of a tool config, including its
+// template parameters.
+func ConfigParameters(cfg ToolConfig) parameters.Parameters {
+	if o, ok := cfg.(OptionsToolConfig); ok {
+		cfg = o.ToolConfig
+	}
+	var params parameters.Parameters
+	for _, field := range []string{"parameters", "templateParameters"} {
+		if v, ok := configField(cfg, field); ok {
+			if p, ok := v.Interface().(parameters.Parameters); ok {
+				params = append(params, p...)
+			}
+		}
+	}
+	return params
+}
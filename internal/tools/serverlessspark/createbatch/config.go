@@ -21,6 +21,7 @@ import (
 
 	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -38,6 +39,12 @@ type compatibleSource interface {
 	CreateBatch(context.Context, *dataprocpb.Batch) (map[string]any, error)
 }
 
+// RegisterCompatibleSource records the sources compatible with a create batch
+// tool type.
+func RegisterCompatibleSource(resourceType string) {
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
+}
+
 // Config is a common config that can be used with any type of create batch tool. However, each tool
 // will still need its own config type, embedding this Config, so it can provide a type-specific
 // Initialize implementation.
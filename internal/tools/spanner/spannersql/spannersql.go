@@ -17,8 +17,11 @@ package spannersql
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strings"
+	"time"
 
+	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
@@ -33,6 +36,7 @@ func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
+	tools.RegisterCompatibleSource[compatibleSource](resourceType)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -143,6 +147,9 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 			if err != nil {
 				return nil, fmt.Errorf("unable to convert parameter `%s` from []any to typed slice: %w", name, err)
 			}
+			value = spannerValue(itemType, value)
+		default:
+			value = spannerValue(p.GetType(), value)
 		}
 		newParams[i] = parameters.ParamValue{Name: name, Value: value}
 	}
@@ -154,12 +161,56 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	return source.RunSQL(ctx, t.ReadOnly, newStatement, mapParams)
 }
 
+// timeLayout is the layout of the "HH:MM:SS" strings bound for "time"
+// parameters.
+const timeLayout = "15:04:05.999999999"
+
+// spannerValue converts dates, times and durations, or slices of them, to the
+// Go types Spanner binds as DATE, STRING and INTERVAL. Other values are
+// returned unchanged.
+func spannerValue(paramType string, value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		switch paramType {
+		case parameters.TypeDate:
+			return civil.DateOf(v)
+		case parameters.TypeTime:
+			// Spanner has no TIME type, and rejects the year 0 of a time of day
+			return v.Format(timeLayout)
+		}
+	case []time.Time:
+		switch paramType {
+		case parameters.TypeDate:
+			dates := make([]civil.Date, len(v))
+			for i, t := range v {
+				dates[i] = civil.DateOf(t)
+			}
+			return dates
+		case parameters.TypeTime:
+			times := make([]string, len(v))
+			for i, t := range v {
+				times[i] = t.Format(timeLayout)
+			}
+			return times
+		}
+	case time.Duration:
+		return spanner.Interval{Nanos: big.NewInt(int64(v))}
+	case []time.Duration:
+		intervals := make([]spanner.Interval, len(v))
+		for i, d := range v {
+			intervals[i] = spanner.Interval{Nanos: big.NewInt(int64(d))}
+		}
+		return intervals
+	}
+	return value
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (parameters.ParamValues, error) {
 	return parameters.ParseParams(t.AllParams, data, claims)
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsFloat32Array)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -17,6 +17,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -48,6 +49,27 @@ func Register(resourceType string, factory ToolConfigFactory) bool {
 	return true
 }
 
+var compatibleSourceRegistry = make(map[string]reflect.Type)
+
+// RegisterCompatibleSource records the interface T that the sources of a tool
+// type must implement, so that tools referencing an incompatible source can be
+// found without initializing the sources. It is typically called from the same
+// init() function as Register.
+func RegisterCompatibleSource[T any](resourceType string) {
+	compatibleSourceRegistry[resourceType] = reflect.TypeFor[T]()
+}
+
+// IsCompatibleSource returns false if sources of the given Go type cannot be
+// used by tools of the given type. Tool types that did not register their
+// compatible sources are assumed to be compatible with any source.
+func IsCompatibleSource(resourceType string, sourceType reflect.Type) bool {
+	iface, ok := compatibleSourceRegistry[resourceType]
+	if !ok {
+		return true
+	}
+	return sourceType.Implements(iface)
+}
+
 // DecodeConfig looks up the registered factory for the given type and uses it
 // to decode the tool configuration.
 func DecodeConfig(ctx context.Context, resourceType string, name string, decoder *yaml.Decoder) (ToolConfig, error) {
@@ -121,7 +143,10 @@ type McpManifest struct {
 	Annotations *ToolAnnotations `json:"annotations,omitempty"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	InputSchema parameters.McpToolsSchema `json:"inputSchema,omitempty"`
-	Metadata    map[string]any            `json:"_meta,omitempty"`
+	// An optional JSON Schema object defining the structure of the tool's
+	// output returned in the structuredContent field of a tool call result.
+	OutputSchema *parameters.McpToolsSchema `json:"outputSchema,omitempty"`
+	Metadata     map[string]any             `json:"_meta,omitempty"`
 }
 
 func GetMcpManifest(name, desc string, authInvoke []string, params parameters.Parameters, annotations *ToolAnnotations) McpManifest {
@@ -147,6 +172,57 @@ func GetMcpManifest(name, desc string, authInvoke []string, params parameters.Pa
 	return mcpManifest
 }
 
+// StructuredResultKey is the property that tool results are returned under
+// within structured content.
+const StructuredResultKey = "result"
+
+// GetMcpOutputSchema returns the output schema for a tool that returns a list
+// of rows, with each row's columns described by the provided parameters.
+// Every row has all the columns, which may be null unless they are configured
+// with `required: true`. Returns nil if no columns are provided.
+func GetMcpOutputSchema(columns parameters.Parameters) *parameters.McpToolsSchema {
+	if len(columns) == 0 {
+		return nil
+	}
+	rowSchema, _ := columns.McpManifest()
+	rowSchema.Required = make([]string, 0, len(columns))
+	for _, c := range columns {
+		name := c.GetName()
+		if _, ok := rowSchema.Properties[name]; !ok {
+			continue
+		}
+		if !parameters.IsExplicitlyRequired(c) {
+			p := rowSchema.Properties[name]
+			p.Nullable = true
+			rowSchema.Properties[name] = p
+		}
+		rowSchema.Required = append(rowSchema.Required, name)
+	}
+	return &parameters.McpToolsSchema{
+		Type: "object",
+		Properties: map[string]parameters.ParameterMcpManifest{
+			StructuredResultKey: {
+				Type:        "array",
+				Description: "The rows returned by the tool.",
+				Items: &parameters.ParameterMcpManifest{
+					Type:       "object",
+					Properties: rowSchema.Properties,
+					Required:   rowSchema.Required,
+				},
+			},
+			"truncated": {
+				Type:        "boolean",
+				Description: "Set if the rows were truncated because the result exceeded a limit.",
+			},
+			"rowCount": {
+				Type:        "integer",
+				Description: "The number of rows returned when the rows were truncated.",
+			},
+		},
+		Required: []string{StructuredResultKey},
+	}
+}
+
 // Helper function that returns if a tool invocation request is authorized
 func IsAuthorized(authRequiredSources []string, verifiedAuthServices []string) bool {
 	if len(authRequiredSources) == 0 {
@@ -120,3 +120,60 @@ func TestGetMcpManifestMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMcpOutputSchema(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		columns parameters.Parameters
+		want    *parameters.McpToolsSchema
+	}{
+		{
+			desc:    "no columns",
+			columns: nil,
+			want:    nil,
+		},
+		{
+			desc: "with columns",
+			columns: parameters.Parameters{
+				parameters.NewStringParameter("name", "the name"),
+				parameters.NewIntParameterWithRequired("age", "the age", false),
+				parameters.NewIntParameterWithRequired("id", "the id", true),
+			},
+			want: &parameters.McpToolsSchema{
+				Type: "object",
+				Properties: map[string]parameters.ParameterMcpManifest{
+					tools.StructuredResultKey: {
+						Type:        "array",
+						Description: "The rows returned by the tool.",
+						Items: &parameters.ParameterMcpManifest{
+							Type: "object",
+							Properties: map[string]parameters.ParameterMcpManifest{
+								"name": {Type: "string", Description: "the name", Nullable: true},
+								"age":  {Type: "integer", Description: "the age", Nullable: true},
+								"id":   {Type: "integer", Description: "the id"},
+							},
+							Required: []string{"name", "age", "id"},
+						},
+					},
+					"truncated": {
+						Type:        "boolean",
+						Description: "Set if the rows were truncated because the result exceeded a limit.",
+					},
+					"rowCount": {
+						Type:        "integer",
+						Description: "The number of rows returned when the rows were truncated.",
+					},
+				},
+				Required: []string{tools.StructuredResultKey},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.GetMcpOutputSchema(tc.columns)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected output schema (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
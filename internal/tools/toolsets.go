@@ -17,11 +17,18 @@ package tools
 import (
 	"fmt"
 	"regexp"
+	"slices"
 )
 
 type ToolsetConfig struct {
 	Name      string   `yaml:"name"`
 	ToolNames []string `yaml:",inline"`
+	// Policies are evaluated for every invocation of the tools and every read
+	// of the resources of the toolset, whichever toolset it goes through.
+	Policies []Policy `yaml:"policies,omitempty"`
+	// ResourceNames are the MCP resources that can be listed and read through
+	// the toolset.
+	ResourceNames []string `yaml:"resources,omitempty"`
 }
 
 type Toolset struct {
@@ -35,6 +42,17 @@ func (t Toolset) ToConfig() ToolsetConfig {
 	return t.ToolsetConfig
 }
 
+// ContainsTool returns true if the tool is part of the toolset.
+func (t Toolset) ContainsTool(toolName string) bool {
+	_, ok := t.Manifest.ToolsManifest[toolName]
+	return ok
+}
+
+// ContainsResource returns true if the MCP resource is part of the toolset.
+func (t Toolset) ContainsResource(resourceName string) bool {
+	return slices.Contains(t.ResourceNames, resourceName)
+}
+
 type ToolsetManifest struct {
 	ServerVersion string              `json:"serverVersion"`
 	ToolsManifest map[string]Manifest `json:"tools"`
@@ -44,7 +62,7 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	// finish toolset setup
 	// Check each declared tool name exists
 	var toolset Toolset
-	toolset.Name = t.Name
+	toolset.ToolsetConfig = t
 	if !IsValidName(toolset.Name) {
 		return toolset, fmt.Errorf("invalid toolset name: %s", toolset.Name)
 	}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// NewUnavailableTool returns a tool whose source could not be initialized.
+// The tool is listed as usual, but fails to be invoked until it is replaced by
+// the initialized tool once the source is reconnected. If the tool cannot be
+// initialized without its source, it is listed without parameters.
+func NewUnavailableTool(name string, cfg ToolConfig, srcs map[string]sources.Source, source *sources.UnavailableSource) Tool {
+	t, err := cfg.Initialize(srcs)
+	if err != nil {
+		inner := cfg
+		if o, ok := cfg.(OptionsToolConfig); ok {
+			inner = o.ToolConfig
+		}
+		desc := configStringField(inner, "description")
+		t = placeholderTool{
+			cfg: cfg,
+			manifest: Manifest{
+				Description:  desc,
+				Parameters:   []parameters.ParameterManifest{},
+				AuthRequired: []string{},
+			},
+			mcpManifest: GetMcpManifest(name, desc, nil, parameters.Parameters{}, nil),
+		}
+	}
+	return unavailableTool{Tool: t, name: name, cfg: cfg, source: source}
+}
+
+// UnavailableToolConfig returns the config and the source name of a tool
+// created by NewUnavailableTool.
+func UnavailableToolConfig(t Tool) (ToolConfig, string, bool) {
+	ut, ok := t.(unavailableTool)
+	if !ok {
+		return nil, "", false
+	}
+	return ut.cfg, ConfigSourceName(ut.cfg), true
+}
+
+// unavailableTool is a tool whose source could not be initialized.
+type unavailableTool struct {
+	Tool
+	name   string
+	cfg    ToolConfig
+	source *sources.UnavailableSource
+}
+
+func (t unavailableTool) Invoke(_ context.Context, _ SourceProvider, _ parameters.ParamValues, _ AccessToken) (any, error) {
+	return nil, fmt.Errorf("tool %q is unavailable: source %q could not be initialized: %w", t.name, ConfigSourceName(t.cfg), t.source.Err())
+}
+
+func (t unavailableTool) ToConfig() ToolConfig {
+	return t.cfg
+}
+
+// placeholderTool stands in for a tool that cannot be initialized without its
+// source.
+type placeholderTool struct {
+	cfg         ToolConfig
+	manifest    Manifest
+	mcpManifest McpManifest
+}
+
+func (t placeholderTool) Invoke(_ context.Context, _ SourceProvider, _ parameters.ParamValues, _ AccessToken) (any, error) {
+	return nil, fmt.Errorf("tool is unavailable")
+}
+
+func (t placeholderTool) ParseParams(_ map[string]any, _ map[string]map[string]any) (parameters.ParamValues, error) {
+	return parameters.ParamValues{}, nil
+}
+
+func (t placeholderTool) EmbedParams(_ context.Context, params parameters.ParamValues, _ map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	return params, nil
+}
+
+func (t placeholderTool) Manifest() Manifest {
+	return t.manifest
+}
+
+func (t placeholderTool) McpManifest() McpManifest {
+	return t.mcpManifest
+}
+
+func (t placeholderTool) Authorized(_ []string) bool {
+	return true
+}
+
+func (t placeholderTool) RequiresClientAuthorization(_ SourceProvider) (bool, error) {
+	return false, nil
+}
+
+func (t placeholderTool) ToConfig() ToolConfig {
+	return t.cfg
+}
+
+func (t placeholderTool) GetAuthTokenHeaderName(_ SourceProvider) (string, error) {
+	return "Authorization", nil
+}
+
+func (t placeholderTool) GetParameters() parameters.Parameters {
+	return parameters.Parameters{}
+}
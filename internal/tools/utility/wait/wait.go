@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
@@ -94,7 +95,22 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, fmt.Errorf("invalid duration format: %w", err)
 	}
 
-	time.Sleep(totalDuration)
+	// report progress every second until the wait is over
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	timer := time.NewTimer(totalDuration)
+	defer timer.Stop()
+	start := time.Now()
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait was interrupted: %w", context.Cause(ctx))
+		case <-ticker.C:
+			util.ReportProgress(ctx, time.Since(start).Seconds(), totalDuration.Seconds(), "")
+		case <-timer.C:
+			done = true
+		}
+	}
 
 	return fmt.Sprintf("Wait for %v completed successfully.", totalDuration), nil
 }
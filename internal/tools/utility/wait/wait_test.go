@@ -15,11 +15,14 @@
 package wait_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 
 	wait "github.com/googleapis/genai-toolbox/internal/tools/utility/wait"
 )
@@ -58,7 +61,7 @@ func TestParseFromYamlWait(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			_, _, _, got, _, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
 			if err != nil {
 				t.Fatalf("unable to unmarshal: %s", err)
 			}
@@ -68,3 +71,19 @@ func TestParseFromYamlWait(t *testing.T) {
 		})
 	}
 }
+
+func TestInvokeWaitCancelled(t *testing.T) {
+	tool, err := wait.Config{Name: "example_tool", Type: "wait"}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cause := errors.New("request cancelled by client")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	params := parameters.ParamValues{{Name: "duration", Value: "1h"}}
+	_, err = tool.Invoke(ctx, nil, params, "")
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected the cancellation cause, got %v", err)
+	}
+}
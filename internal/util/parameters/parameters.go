@@ -19,11 +19,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/mail"
+	"net/url"
 	"reflect"
 	"regexp"
 	"slices"
 	"strings"
 	"text/template"
+	"time"
+	"unicode/utf8"
 
 	embeddingmodels "github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/util"
@@ -36,6 +40,12 @@ const (
 	TypeBool   = "boolean"
 	TypeArray  = "array"
 	TypeMap    = "map"
+	TypeObject = "object"
+
+	TypeDate     = "date"
+	TypeTime     = "time"
+	TypeDateTime = "datetime"
+	TypeDuration = "duration"
 )
 
 // delimiters for string parameter escaping
@@ -388,6 +398,12 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
 		}
+		if err := a.validateEnumDescriptions(); err != nil {
+			return nil, err
+		}
+		if err := a.validateConstraints(); err != nil {
+			return nil, err
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -399,6 +415,9 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
 		}
+		if err := a.validateEnumDescriptions(); err != nil {
+			return nil, err
+		}
 		if a.GetEmbeddedBy() != "" {
 			return nil, fmt.Errorf("parameter type %q cannot specify 'embeddedBy'", paramType)
 		}
@@ -413,6 +432,9 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
 		}
+		if err := a.validateEnumDescriptions(); err != nil {
+			return nil, err
+		}
 		if a.GetEmbeddedBy() != "" {
 			return nil, fmt.Errorf("parameter type %q cannot specify 'embeddedBy'", paramType)
 		}
@@ -464,6 +486,57 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 			a.AuthSources = nil
 		}
 		return a, nil
+	case TypeDate, TypeTime, TypeDateTime:
+		a := &DateTimeParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.GetEmbeddedBy() != "" {
+			return nil, fmt.Errorf("parameter type %q cannot specify 'embeddedBy'", paramType)
+		}
+		if _, err := a.location(); err != nil {
+			return nil, err
+		}
+		if err := a.validateDefault(); err != nil {
+			return nil, err
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeDuration:
+		a := &DurationParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.GetEmbeddedBy() != "" {
+			return nil, fmt.Errorf("parameter type %q cannot specify 'embeddedBy'", paramType)
+		}
+		if err := a.validateDefault(); err != nil {
+			return nil, err
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case TypeObject:
+		a := &ObjectParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
+		}
+		if a.GetEmbeddedBy() != "" {
+			return nil, fmt.Errorf("parameter type %q cannot specify 'embeddedBy'", paramType)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
 	}
 	return nil, fmt.Errorf("%q is not valid type for a parameter", paramType)
 }
@@ -514,25 +587,57 @@ func (ps Parameters) McpManifest() (McpToolsSchema, map[string][]string) {
 
 // ParameterManifest represents parameters when served as part of a ToolManifest.
 type ParameterManifest struct {
-	Name                 string             `json:"name"`
-	Type                 string             `json:"type"`
-	Required             bool               `json:"required"`
-	Description          string             `json:"description"`
-	AuthServices         []string           `json:"authSources"`
-	Items                *ParameterManifest `json:"items,omitempty"`
-	Default              any                `json:"default,omitempty"`
-	AdditionalProperties any                `json:"additionalProperties,omitempty"`
-	EmbeddedBy           string             `json:"embeddedBy,omitempty"`
-	ValueFromParam       string             `json:"valueFromParam,omitempty"`
+	Name                 string              `json:"name"`
+	Type                 string              `json:"type"`
+	Required             bool                `json:"required"`
+	Description          string              `json:"description"`
+	AuthServices         []string            `json:"authSources"`
+	Items                *ParameterManifest  `json:"items,omitempty"`
+	Default              any                 `json:"default,omitempty"`
+	AdditionalProperties any                 `json:"additionalProperties,omitempty"`
+	Properties           []ParameterManifest `json:"properties,omitempty"`
+	EmbeddedBy           string              `json:"embeddedBy,omitempty"`
+	ValueFromParam       string              `json:"valueFromParam,omitempty"`
 }
 
 // ParameterMcpManifest represents properties when served as part of a ToolMcpManifest.
 type ParameterMcpManifest struct {
-	Type                 string                `json:"type"`
-	Description          string                `json:"description"`
-	Items                *ParameterMcpManifest `json:"items,omitempty"`
-	Default              any                   `json:"default,omitempty"`
-	AdditionalProperties any                   `json:"additionalProperties,omitempty"`
+	Type                 string                          `json:"type"`
+	Description          string                          `json:"description"`
+	Items                *ParameterMcpManifest           `json:"items,omitempty"`
+	Properties           map[string]ParameterMcpManifest `json:"properties,omitempty"`
+	Required             []string                        `json:"required,omitempty"`
+	Default              any                             `json:"default,omitempty"`
+	AdditionalProperties any                             `json:"additionalProperties,omitempty"`
+	Enum                 []any                           `json:"enum,omitempty"`
+	OneOf                []ParameterEnumValue            `json:"oneOf,omitempty"`
+	Format               string                          `json:"format,omitempty"`
+	Pattern              string                          `json:"pattern,omitempty"`
+	MinLength            *int                            `json:"minLength,omitempty"`
+	MaxLength            *int                            `json:"maxLength,omitempty"`
+	MinItems             *int                            `json:"minItems,omitempty"`
+	MaxItems             *int                            `json:"maxItems,omitempty"`
+	// Nullable allows null in addition to Type.
+	Nullable bool `json:"-"`
+}
+
+// MarshalJSON marshals the manifest, with the type `[Type, "null"]` if it is
+// nullable.
+func (m ParameterMcpManifest) MarshalJSON() ([]byte, error) {
+	type manifest ParameterMcpManifest
+	if !m.Nullable {
+		return json.Marshal(manifest(m))
+	}
+	return json.Marshal(struct {
+		manifest
+		Type []string `json:"type"`
+	}{manifest: manifest(m), Type: []string{m.Type, "null"}})
+}
+
+// ParameterEnumValue describes one of the allowed values of a parameter.
+type ParameterEnumValue struct {
+	Const       any    `json:"const"`
+	Description string `json:"description"`
 }
 
 // CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
@@ -547,6 +652,8 @@ type CommonParameter struct {
 	AuthSources    []ParamAuthService `yaml:"authSources"` // Deprecated: Kept for compatibility.
 	EmbeddedBy     string             `yaml:"embeddedBy"`
 	ValueFromParam string             `yaml:"valueFromParam"`
+	// EnumDescriptions describes each of the AllowedValues, in the same order.
+	EnumDescriptions []string `yaml:"enumDescriptions"`
 }
 
 // GetName returns the name specified for the Parameter.
@@ -568,6 +675,18 @@ func (p *CommonParameter) GetRequired() bool {
 	return *p.Required
 }
 
+// common returns the CommonParameter of the Parameter.
+func (p *CommonParameter) common() *CommonParameter {
+	return p
+}
+
+// IsExplicitlyRequired returns true if the parameter is configured with
+// `required: true`, rather than being required by default.
+func IsExplicitlyRequired(p Parameter) bool {
+	c, ok := p.(interface{ common() *CommonParameter })
+	return ok && c.common().Required != nil && *c.common().Required
+}
+
 // GetAllowedValues returns the allowed values for the Parameter.
 func (p *CommonParameter) GetAllowedValues() []any {
 	return p.AllowedValues
@@ -632,10 +751,45 @@ func MatchStringOrRegex(input, target any) bool {
 // McpManifest returns the MCP manifest for the Parameter.
 func (p *CommonParameter) McpManifest() (ParameterMcpManifest, []string) {
 	authServiceNames := getAuthServiceNames(p.AuthServices)
-	return ParameterMcpManifest{
+	m := ParameterMcpManifest{
 		Type:        p.Type,
 		Description: p.Desc,
-	}, authServiceNames
+	}
+	p.addEnum(&m)
+	return m, authServiceNames
+}
+
+// addEnum lists the allowed values in the MCP manifest, with their
+// descriptions if any. Allowed values that are regular expressions cannot be
+// listed.
+func (p *CommonParameter) addEnum(m *ParameterMcpManifest) {
+	enum, ok := literalValues(p.AllowedValues)
+	if !ok {
+		return
+	}
+	m.Enum = enum
+	if len(p.EnumDescriptions) == 0 {
+		return
+	}
+	m.OneOf = make([]ParameterEnumValue, len(enum))
+	for i, v := range enum {
+		m.OneOf[i] = ParameterEnumValue{Const: v, Description: p.EnumDescriptions[i]}
+	}
+}
+
+// validateEnumDescriptions checks that there is one description for each
+// allowed value.
+func (p *CommonParameter) validateEnumDescriptions() error {
+	if len(p.EnumDescriptions) == 0 {
+		return nil
+	}
+	if len(p.EnumDescriptions) != len(p.AllowedValues) {
+		return fmt.Errorf("parameter %q has %d enumDescriptions for %d allowedValues", p.Name, len(p.EnumDescriptions), len(p.AllowedValues))
+	}
+	if _, ok := literalValues(p.AllowedValues); !ok {
+		return fmt.Errorf("parameter %q cannot specify enumDescriptions for regular expression allowedValues", p.Name)
+	}
+	return nil
 }
 
 // getAuthServiceNames retrieves the list of auth services names
@@ -759,6 +913,64 @@ type StringParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *string `yaml:"default"`
 	Escape          *string `yaml:"escape"`
+	Pattern         string  `yaml:"pattern"`
+	MinLength       *int    `yaml:"minLength"`
+	MaxLength       *int    `yaml:"maxLength"`
+	Format          string  `yaml:"format"`
+}
+
+// stringFormats maps the supported string formats to their validation.
+var stringFormats = map[string]func(string) bool{
+	"email": func(s string) bool {
+		a, err := mail.ParseAddress(s)
+		return err == nil && a.Address == s
+	},
+	"uuid": uuidRegexp.MatchString,
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateConstraints checks the pattern, lengths and format of the parameter.
+func (p *StringParameter) validateConstraints() error {
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("parameter %q has an invalid pattern: %w", p.Name, err)
+		}
+	}
+	if err := validateLengthRange(p.Name, "Length", p.MinLength, p.MaxLength); err != nil {
+		return err
+	}
+	if _, ok := stringFormats[p.Format]; p.Format != "" && !ok {
+		return fmt.Errorf("parameter %q has an unsupported format %q: must be one of \"email\", \"uuid\", \"date\", \"date-time\", \"uri\"", p.Name, p.Format)
+	}
+	return nil
+}
+
+// validateLengthRange checks that the minimum and maximum of a length, such as
+// "Length" or "Items", are positive and in order.
+func validateLengthRange(name, length string, minV, maxV *int) error {
+	if minV != nil && *minV < 0 {
+		return fmt.Errorf("parameter %q has a negative min%s", name, length)
+	}
+	if maxV != nil && *maxV < 0 {
+		return fmt.Errorf("parameter %q has a negative max%s", name, length)
+	}
+	if minV != nil && maxV != nil && *minV > *maxV {
+		return fmt.Errorf("parameter %q has a min%s greater than its max%s", name, length, length)
+	}
+	return nil
 }
 
 // Parse casts the value "v" as a "string".
@@ -773,6 +985,31 @@ func (p *StringParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(newV) {
 		return nil, fmt.Errorf("%s is an excluded value", newV)
 	}
+	if p.Pattern != "" {
+		matched, err := regexp.MatchString(p.Pattern, newV)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p.Pattern, err)
+		}
+		if !matched {
+			return nil, fmt.Errorf("%s does not match the pattern %q", newV, p.Pattern)
+		}
+	}
+	length := utf8.RuneCountInString(newV)
+	if p.MinLength != nil && length < *p.MinLength {
+		return nil, fmt.Errorf("%s is shorter than the minimum length of %d", newV, *p.MinLength)
+	}
+	if p.MaxLength != nil && length > *p.MaxLength {
+		return nil, fmt.Errorf("%s is longer than the maximum length of %d", newV, *p.MaxLength)
+	}
+	if p.Format != "" {
+		isValid, ok := stringFormats[p.Format]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a supported format", p.Format)
+		}
+		if !isValid(newV) {
+			return nil, fmt.Errorf("%s is not a valid %s", newV, p.Format)
+		}
+	}
 	if p.Escape != nil {
 		return applyEscape(*p.Escape, newV)
 	}
@@ -820,6 +1057,17 @@ func (p *StringParameter) Manifest() ParameterManifest {
 	}
 }
 
+// McpManifest returns the MCP manifest for the StringParameter, including its
+// constraints.
+func (p *StringParameter) McpManifest() (ParameterMcpManifest, []string) {
+	m, authServiceNames := p.CommonParameter.McpManifest()
+	m.Pattern = p.Pattern
+	m.MinLength = p.MinLength
+	m.MaxLength = p.MaxLength
+	m.Format = p.Format
+	return m, authServiceNames
+}
+
 // NewIntParameter is a convenience function for initializing a IntParameter.
 func NewIntParameter(name string, desc string) *IntParameter {
 	return &IntParameter{
@@ -1140,10 +1388,12 @@ func (p *FloatParameter) Manifest() ParameterManifest {
 // json schema only allow numeric types of 'integer' and 'number'.
 func (p *FloatParameter) McpManifest() (ParameterMcpManifest, []string) {
 	authServiceNames := getAuthServiceNames(p.AuthServices)
-	return ParameterMcpManifest{
+	m := ParameterMcpManifest{
 		Type:        "number",
 		Description: p.Desc,
-	}, authServiceNames
+	}
+	p.addEnum(&m)
+	return m, authServiceNames
 }
 
 // NewBooleanParameter is a convenience function for initializing a BooleanParameter.
@@ -1359,6 +1609,8 @@ type ArrayParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *[]any    `yaml:"default"`
 	Items           Parameter `yaml:"items"`
+	MinItems        *int      `yaml:"minItems"`
+	MaxItems        *int      `yaml:"maxItems"`
 }
 
 func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
@@ -1366,12 +1618,19 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 		CommonParameter `yaml:",inline"`
 		Default         *[]any                  `yaml:"default"`
 		Items           util.DelayedUnmarshaler `yaml:"items"`
+		MinItems        *int                    `yaml:"minItems"`
+		MaxItems        *int                    `yaml:"maxItems"`
 	}
 	if err := unmarshal(&rawItem); err != nil {
 		return err
 	}
+	if err := validateLengthRange(rawItem.Name, "Items", rawItem.MinItems, rawItem.MaxItems); err != nil {
+		return err
+	}
 	p.CommonParameter = rawItem.CommonParameter
 	p.Default = rawItem.Default
+	p.MinItems = rawItem.MinItems
+	p.MaxItems = rawItem.MaxItems
 	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
 	if err != nil {
 		return fmt.Errorf("unable to parse 'items' field: %w", err)
@@ -1421,6 +1680,12 @@ func (p *ArrayParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(arrVal) {
 		return nil, fmt.Errorf("%s is an excluded value", arrVal)
 	}
+	if p.MinItems != nil && len(arrVal) < *p.MinItems {
+		return nil, fmt.Errorf("array has %d items, fewer than the minimum of %d", len(arrVal), *p.MinItems)
+	}
+	if p.MaxItems != nil && len(arrVal) > *p.MaxItems {
+		return nil, fmt.Errorf("array has %d items, more than the maximum of %d", len(arrVal), *p.MaxItems)
+	}
 	rtn := make([]any, 0, len(arrVal))
 	for idx, val := range arrVal {
 		val, err := p.Items.Parse(val)
@@ -1475,6 +1740,8 @@ func (p *ArrayParameter) McpManifest() (ParameterMcpManifest, []string) {
 		Type:        p.Type,
 		Description: p.Desc,
 		Items:       &items,
+		MinItems:    p.MinItems,
+		MaxItems:    p.MaxItems,
 	}, authServiceNames
 }
 
@@ -1745,3 +2012,203 @@ func (p *MapParameter) McpManifest() (ParameterMcpManifest, []string) {
 		AdditionalProperties: additionalProperties,
 	}, authServiceNames
 }
+
+// NewObjectParameter is a convenience function for initializing an ObjectParameter.
+func NewObjectParameter(name string, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithRequired is a convenience function for initializing an ObjectParameter.
+func NewObjectParameterWithRequired(name string, desc string, required bool, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithDefault is a convenience function for initializing an ObjectParameter with default value.
+func NewObjectParameterWithDefault(name string, defaultV map[string]any, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default:    &defaultV,
+		Properties: properties,
+	}
+}
+
+var _ Parameter = &ObjectParameter{}
+
+// ObjectParameter is a parameter representing an object with typed
+// properties. Properties are required unless they have a default value or
+// set `required: false`, and unknown properties are rejected.
+type ObjectParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *map[string]any `yaml:"default"`
+	Properties      Parameters      `yaml:"properties"`
+}
+
+// UnmarshalYAML handles parsing the ObjectParameter and its properties from
+// YAML input.
+func (p *ObjectParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var rawItem struct {
+		CommonParameter `yaml:",inline"`
+		Default         *map[string]any `yaml:"default"`
+		Properties      Parameters      `yaml:"properties"`
+	}
+	if err := unmarshal(&rawItem); err != nil {
+		return err
+	}
+	if len(rawItem.Properties) == 0 {
+		return fmt.Errorf("object parameter %q must specify 'properties'", rawItem.Name)
+	}
+	if err := CheckDuplicateParameters(rawItem.Properties); err != nil {
+		return fmt.Errorf("unable to parse 'properties' field: %w", err)
+	}
+	for _, prop := range rawItem.Properties {
+		if len(prop.GetAuthServices()) != 0 {
+			return fmt.Errorf("nested properties should not have auth services")
+		}
+		if prop.GetValueFromParam() != "" {
+			return fmt.Errorf("nested properties should not have 'valueFromParam'")
+		}
+		if prop.GetEmbeddedBy() != "" {
+			return fmt.Errorf("nested properties should not have 'embeddedBy'")
+		}
+	}
+	p.CommonParameter = rawItem.CommonParameter
+	p.Default = rawItem.Default
+	p.Properties = rawItem.Properties
+	return nil
+}
+
+func (p *ObjectParameter) IsAllowedValues(v map[string]any) bool {
+	a := p.GetAllowedValues()
+	if len(a) == 0 {
+		return true
+	}
+	for _, av := range a {
+		if reflect.DeepEqual(v, av) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ObjectParameter) IsExcludedValues(v map[string]any) bool {
+	a := p.GetExcludedValues()
+	if len(a) == 0 {
+		return false
+	}
+	for _, av := range a {
+		if reflect.DeepEqual(v, av) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse validates an incoming object, and parses each of its properties.
+// Missing properties are set to their default value, if any.
+func (p *ObjectParameter) Parse(v any) (any, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if !p.IsAllowedValues(m) {
+		return nil, fmt.Errorf("%s is not an allowed value", m)
+	}
+	if p.IsExcludedValues(m) {
+		return nil, fmt.Errorf("%s is an excluded value", m)
+	}
+	for key := range m {
+		if !slices.ContainsFunc(p.Properties, func(prop Parameter) bool { return prop.GetName() == key }) {
+			return nil, fmt.Errorf("unknown property %q", key)
+		}
+	}
+	rtn := make(map[string]any, len(p.Properties))
+	for _, prop := range p.Properties {
+		name := prop.GetName()
+		val, ok := m[name]
+		if !ok {
+			val = prop.GetDefault()
+			if CheckParamRequired(prop.GetRequired(), val) {
+				return nil, fmt.Errorf("property %q is required", name)
+			}
+			if val == nil {
+				continue
+			}
+		}
+		if val != nil {
+			parsedVal, err := prop.Parse(val)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+			}
+			val = parsedVal
+		}
+		rtn[name] = val
+	}
+	return rtn, nil
+}
+
+func (p *ObjectParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *ObjectParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+func (p *ObjectParameter) GetProperties() Parameters {
+	return p.Properties
+}
+
+// Manifest returns the manifest for the ObjectParameter.
+func (p *ObjectParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Properties:   p.Properties.Manifest(),
+		Default:      p.GetDefault(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the ObjectParameter, with the
+// manifests of its properties.
+func (p *ObjectParameter) McpManifest() (ParameterMcpManifest, []string) {
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	properties, _ := p.Properties.McpManifest()
+	return ParameterMcpManifest{
+		Type:                 "object",
+		Description:          p.Desc,
+		Properties:           properties.Properties,
+		Required:             properties.Required,
+		AdditionalProperties: false,
+	}, authServiceNames
+}
@@ -22,6 +22,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
@@ -34,6 +35,8 @@ func TestParametersMarshal(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
+	minLength, maxLength := 2, 8
+	relativeDefault := "now-7d"
 	tcs := []struct {
 		name string
 		in   []map[string]any
@@ -353,6 +356,147 @@ func TestParametersMarshal(t *testing.T) {
 				parameters.NewMapParameter("my_generic_map", "this param is a generic map", ""),
 			},
 		},
+		{
+			name: "string with constraints",
+			in: []map[string]any{
+				{
+					"name":             "my_string",
+					"type":             "string",
+					"description":      "this param is a string",
+					"pattern":          "^[a-z]+$",
+					"minLength":        2,
+					"maxLength":        8,
+					"format":           "email",
+					"allowedValues":    []any{"a", "b"},
+					"enumDescriptions": []string{"first", "second"},
+				},
+			},
+			want: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{
+						Name:             "my_string",
+						Type:             "string",
+						Desc:             "this param is a string",
+						AllowedValues:    []any{"a", "b"},
+						EnumDescriptions: []string{"first", "second"},
+					},
+					Pattern:   "^[a-z]+$",
+					MinLength: &minLength,
+					MaxLength: &maxLength,
+					Format:    "email",
+				},
+			},
+		},
+		{
+			name: "array with item limits",
+			in: []map[string]any{
+				{
+					"name":        "my_array",
+					"type":        "array",
+					"description": "this param is an array of strings",
+					"minItems":    2,
+					"maxItems":    8,
+					"items": map[string]string{
+						"name":        "my_string",
+						"type":        "string",
+						"description": "string item",
+					},
+				},
+			},
+			want: parameters.Parameters{
+				&parameters.ArrayParameter{
+					CommonParameter: parameters.CommonParameter{
+						Name: "my_array",
+						Type: "array",
+						Desc: "this param is an array of strings",
+					},
+					Items:    parameters.NewStringParameter("my_string", "string item"),
+					MinItems: &minLength,
+					MaxItems: &maxLength,
+				},
+			},
+		},
+		{
+			name: "object",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "city",
+							"type":        "string",
+							"description": "a city",
+						},
+						{
+							"name":        "zip",
+							"type":        "integer",
+							"description": "a zip code",
+							"required":    false,
+						},
+					},
+				},
+			},
+			want: parameters.Parameters{
+				parameters.NewObjectParameter("my_object", "this param is an object", parameters.Parameters{
+					parameters.NewStringParameter("city", "a city"),
+					parameters.NewIntParameterWithRequired("zip", "a zip code", false),
+				}),
+			},
+		},
+		{
+			name: "date with formats and timezone",
+			in: []map[string]any{
+				{
+					"name":        "my_date",
+					"type":        "date",
+					"description": "this param is a date",
+					"formats":     []string{"02/01/2006"},
+					"timezone":    "Europe/Paris",
+					"default":     "now-7d",
+				},
+			},
+			want: parameters.Parameters{
+				&parameters.DateTimeParameter{
+					CommonParameter: parameters.CommonParameter{
+						Name: "my_date",
+						Type: "date",
+						Desc: "this param is a date",
+					},
+					Default:  &relativeDefault,
+					Formats:  []string{"02/01/2006"},
+					Timezone: "Europe/Paris",
+				},
+			},
+		},
+		{
+			name: "datetime",
+			in: []map[string]any{
+				{
+					"name":        "my_datetime",
+					"type":        "datetime",
+					"description": "this param is a datetime",
+				},
+			},
+			want: parameters.Parameters{
+				parameters.NewDateTimeParameter("my_datetime", "this param is a datetime"),
+			},
+		},
+		{
+			name: "duration",
+			in: []map[string]any{
+				{
+					"name":        "my_duration",
+					"type":        "duration",
+					"description": "this param is a duration",
+					"default":     "1h",
+				},
+			},
+			want: parameters.Parameters{
+				parameters.NewDurationParameterWithDefault("my_duration", "1h", "this param is a duration"),
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -695,6 +839,10 @@ func TestAuthParametersMarshal(t *testing.T) {
 func TestParametersParse(t *testing.T) {
 	intValue := 2
 	floatValue := 1.5
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("unable to load timezone: %s", err)
+	}
 	tcs := []struct {
 		name   string
 		params parameters.Parameters
@@ -1326,6 +1474,385 @@ func TestParametersParse(t *testing.T) {
 			},
 			want: parameters.ParamValues{parameters.ParamValue{Name: "my_map", Value: map[string]any{"key1": "val2"}}},
 		},
+		{
+			name: "string matches pattern",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					Pattern:         "^[A-Z]{2}$",
+				},
+			},
+			in: map[string]any{
+				"my_string": "CY",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_string", Value: "CY"}},
+		},
+		{
+			name: "string does not match pattern",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					Pattern:         "^[A-Z]{2}$",
+				},
+			},
+			in: map[string]any{
+				"my_string": "cy",
+			},
+		},
+		{
+			name: "string within length",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					MinLength:       &intValue,
+					MaxLength:       &intValue,
+				},
+			},
+			in: map[string]any{
+				"my_string": "éé",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_string", Value: "éé"}},
+		},
+		{
+			name: "string too short",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					MinLength:       &intValue,
+				},
+			},
+			in: map[string]any{
+				"my_string": "a",
+			},
+		},
+		{
+			name: "string too long",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					MaxLength:       &intValue,
+				},
+			},
+			in: map[string]any{
+				"my_string": "abc",
+			},
+		},
+		{
+			name: "string with valid formats",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "email", Type: "string", Desc: "an email"},
+					Format:          "email",
+				},
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "uuid", Type: "string", Desc: "a uuid"},
+					Format:          "uuid",
+				},
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "date", Type: "string", Desc: "a date"},
+					Format:          "date",
+				},
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "date_time", Type: "string", Desc: "a date-time"},
+					Format:          "date-time",
+				},
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "uri", Type: "string", Desc: "a uri"},
+					Format:          "uri",
+				},
+			},
+			in: map[string]any{
+				"email":     "jane@example.com",
+				"uuid":      "123e4567-e89b-12d3-a456-426614174000",
+				"date":      "2024-02-29",
+				"date_time": "2024-02-29T13:45:00.5+01:00",
+				"uri":       "https://example.com/a?b=c",
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "email", Value: "jane@example.com"},
+				parameters.ParamValue{Name: "uuid", Value: "123e4567-e89b-12d3-a456-426614174000"},
+				parameters.ParamValue{Name: "date", Value: "2024-02-29"},
+				parameters.ParamValue{Name: "date_time", Value: "2024-02-29T13:45:00.5+01:00"},
+				parameters.ParamValue{Name: "uri", Value: "https://example.com/a?b=c"},
+			},
+		},
+		{
+			name: "string with invalid email",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "email", Type: "string", Desc: "an email"},
+					Format:          "email",
+				},
+			},
+			in: map[string]any{
+				"email": "Jane <jane@example.com>",
+			},
+		},
+		{
+			name: "string with invalid uuid",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "uuid", Type: "string", Desc: "a uuid"},
+					Format:          "uuid",
+				},
+			},
+			in: map[string]any{
+				"uuid": "123e4567-e89b-12d3-a456",
+			},
+		},
+		{
+			name: "string with invalid date",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "date", Type: "string", Desc: "a date"},
+					Format:          "date",
+				},
+			},
+			in: map[string]any{
+				"date": "2023-02-29",
+			},
+		},
+		{
+			name: "string with invalid date-time",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "date_time", Type: "string", Desc: "a date-time"},
+					Format:          "date-time",
+				},
+			},
+			in: map[string]any{
+				"date_time": "2024-02-29 13:45:00",
+			},
+		},
+		{
+			name: "string with invalid uri",
+			params: parameters.Parameters{
+				&parameters.StringParameter{
+					CommonParameter: parameters.CommonParameter{Name: "uri", Type: "string", Desc: "a uri"},
+					Format:          "uri",
+				},
+			},
+			in: map[string]any{
+				"uri": "example.com",
+			},
+		},
+		{
+			name: "array within item limits",
+			params: parameters.Parameters{
+				&parameters.ArrayParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+					Items:           parameters.NewIntParameter("my_int", "int item"),
+					MinItems:        &intValue,
+					MaxItems:        &intValue,
+				},
+			},
+			in: map[string]any{
+				"my_array": []any{1, 2},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_array", Value: []any{1, 2}}},
+		},
+		{
+			name: "array with too few items",
+			params: parameters.Parameters{
+				&parameters.ArrayParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+					Items:           parameters.NewIntParameter("my_int", "int item"),
+					MinItems:        &intValue,
+				},
+			},
+			in: map[string]any{
+				"my_array": []any{1},
+			},
+		},
+		{
+			name: "array with too many items",
+			params: parameters.Parameters{
+				&parameters.ArrayParameter{
+					CommonParameter: parameters.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+					Items:           parameters.NewIntParameter("my_int", "int item"),
+					MaxItems:        &intValue,
+				},
+			},
+			in: map[string]any{
+				"my_array": []any{1, 2, 3},
+			},
+		},
+		{
+			name: "object",
+			params: parameters.Parameters{
+				parameters.NewObjectParameter("my_object", "this param is an object", parameters.Parameters{
+					parameters.NewStringParameter("city", "a city"),
+					parameters.NewIntParameterWithDefault("zip", 1000, "a zip code"),
+					parameters.NewFloatParameterWithRequired("ratio", "a ratio", false),
+					parameters.NewObjectParameter("location", "a location", parameters.Parameters{
+						parameters.NewFloatParameter("lat", "a latitude"),
+					}),
+				}),
+			},
+			in: map[string]any{
+				"my_object": map[string]any{"city": "Paris", "location": map[string]any{"lat": 48.8}},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_object", Value: map[string]any{
+				"city":     "Paris",
+				"zip":      1000,
+				"location": map[string]any{"lat": 48.8},
+			}}},
+		},
+		{
+			name: "object missing required property",
+			params: parameters.Parameters{
+				parameters.NewObjectParameter("my_object", "this param is an object", parameters.Parameters{
+					parameters.NewStringParameter("city", "a city"),
+				}),
+			},
+			in: map[string]any{
+				"my_object": map[string]any{},
+			},
+		},
+		{
+			name: "object with unknown property",
+			params: parameters.Parameters{
+				parameters.NewObjectParameter("my_object", "this param is an object", parameters.Parameters{
+					parameters.NewStringParameter("city", "a city"),
+				}),
+			},
+			in: map[string]any{
+				"my_object": map[string]any{"city": "Paris", "country": "France"},
+			},
+		},
+		{
+			name: "object with invalid nested property",
+			params: parameters.Parameters{
+				parameters.NewObjectParameter("my_object", "this param is an object", parameters.Parameters{
+					parameters.NewObjectParameter("location", "a location", parameters.Parameters{
+						parameters.NewFloatParameter("lat", "a latitude"),
+					}),
+				}),
+			},
+			in: map[string]any{
+				"my_object": map[string]any{"location": map[string]any{"lat": "north"}},
+			},
+		},
+		{
+			name: "not object",
+			params: parameters.Parameters{
+				parameters.NewObjectParameter("my_object", "this param is an object", parameters.Parameters{
+					parameters.NewStringParameter("city", "a city"),
+				}),
+			},
+			in: map[string]any{
+				"my_object": "Paris",
+			},
+		},
+		{
+			name: "date",
+			params: parameters.Parameters{
+				parameters.NewDateParameter("rfc3339", "a date"),
+				parameters.NewDateParameter("date_only", "a date"),
+				&parameters.DateTimeParameter{
+					CommonParameter: parameters.CommonParameter{Name: "custom", Type: "date", Desc: "a date"},
+					Formats:         []string{"02/01/2006"},
+				},
+			},
+			in: map[string]any{
+				"rfc3339":   "2024-03-10T23:30:00-05:00",
+				"date_only": "2024-03-10",
+				"custom":    "10/03/2024",
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "rfc3339", Value: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)},
+				parameters.ParamValue{Name: "date_only", Value: time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+				parameters.ParamValue{Name: "custom", Value: time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "invalid date",
+			params: parameters.Parameters{
+				parameters.NewDateParameter("my_date", "a date"),
+			},
+			in: map[string]any{
+				"my_date": "10/03/2024",
+			},
+		},
+		{
+			name: "time",
+			params: parameters.Parameters{
+				parameters.NewTimeParameter("seconds", "a time"),
+				parameters.NewTimeParameter("minutes", "a time"),
+				parameters.NewTimeParameter("offset", "a time"),
+			},
+			in: map[string]any{
+				"seconds": "13:45:30.5",
+				"minutes": "13:45",
+				"offset":  "01:45:00+02:00",
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "seconds", Value: time.Date(0, time.January, 1, 13, 45, 30, 500000000, time.UTC)},
+				parameters.ParamValue{Name: "minutes", Value: time.Date(0, time.January, 1, 13, 45, 0, 0, time.UTC)},
+				parameters.ParamValue{Name: "offset", Value: time.Date(0, time.January, 1, 23, 45, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "datetime",
+			params: parameters.Parameters{
+				parameters.NewDateTimeParameter("rfc3339", "a datetime"),
+				parameters.NewDateTimeParameter("no_timezone", "a datetime"),
+				&parameters.DateTimeParameter{
+					CommonParameter: parameters.CommonParameter{Name: "paris", Type: "datetime", Desc: "a datetime"},
+					Timezone:        "Europe/Paris",
+				},
+				parameters.NewDateTimeParameterWithDefault("default", "2024-03-10 08:00:00", "a datetime"),
+			},
+			in: map[string]any{
+				"rfc3339":     "2024-03-10T13:45:00+02:00",
+				"no_timezone": "2024-03-10T13:45:00",
+				"paris":       "2024-03-10T13:45:00",
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "rfc3339", Value: time.Date(2024, time.March, 10, 11, 45, 0, 0, time.UTC)},
+				parameters.ParamValue{Name: "no_timezone", Value: time.Date(2024, time.March, 10, 13, 45, 0, 0, time.UTC)},
+				parameters.ParamValue{Name: "paris", Value: time.Date(2024, time.March, 10, 13, 45, 0, 0, paris)},
+				parameters.ParamValue{Name: "default", Value: time.Date(2024, time.March, 10, 8, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "not datetime",
+			params: parameters.Parameters{
+				parameters.NewDateTimeParameter("my_datetime", "a datetime"),
+			},
+			in: map[string]any{
+				"my_datetime": 1710078300,
+			},
+		},
+		{
+			name: "duration",
+			params: parameters.Parameters{
+				parameters.NewDurationParameter("go", "a duration"),
+				parameters.NewDurationParameter("days", "a duration"),
+				parameters.NewDurationParameter("iso", "a duration"),
+				parameters.NewDurationParameterWithDefault("default", "-1.5h", "a duration"),
+			},
+			in: map[string]any{
+				"go":   "1h30m",
+				"days": "1w2d",
+				"iso":  "P1DT2H30M",
+			},
+			want: parameters.ParamValues{
+				parameters.ParamValue{Name: "go", Value: 90 * time.Minute},
+				parameters.ParamValue{Name: "days", Value: 9 * 24 * time.Hour},
+				parameters.ParamValue{Name: "iso", Value: 26*time.Hour + 30*time.Minute},
+				parameters.ParamValue{Name: "default", Value: -90 * time.Minute},
+			},
+		},
+		{
+			name: "invalid duration",
+			params: parameters.Parameters{
+				parameters.NewDurationParameter("my_duration", "a duration"),
+			},
+			in: map[string]any{
+				"my_duration": "P1M",
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1364,6 +1891,69 @@ func TestParametersParse(t *testing.T) {
 	}
 }
 
+func TestRelativeTimeParameters(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("unable to load timezone: %s", err)
+	}
+	tcs := []struct {
+		name  string
+		param parameters.Parameter
+		in    string
+		want  time.Time
+	}{
+		{
+			name:  "now",
+			param: parameters.NewDateTimeParameter("my_datetime", "a datetime"),
+			in:    "now",
+			want:  time.Now().UTC(),
+		},
+		{
+			name:  "now minus days",
+			param: parameters.NewDateTimeParameter("my_datetime", "a datetime"),
+			in:    "now-7d",
+			want:  time.Now().UTC().Add(-7 * 24 * time.Hour),
+		},
+		{
+			name: "now plus duration in timezone",
+			param: &parameters.DateTimeParameter{
+				CommonParameter: parameters.CommonParameter{Name: "my_datetime", Type: "datetime", Desc: "a datetime"},
+				Timezone:        "Europe/Paris",
+			},
+			in:   "NOW + 1h30m",
+			want: time.Now().In(paris).Add(90 * time.Minute),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.param.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			gotTime, ok := got.(time.Time)
+			if !ok {
+				t.Fatalf("expected time.Time, got %T", got)
+			}
+			if gotTime.Location().String() != tc.want.Location().String() {
+				t.Fatalf("unexpected timezone: got %s, want %s", gotTime.Location(), tc.want.Location())
+			}
+			if diff := gotTime.Sub(tc.want); diff < -time.Minute || diff > time.Minute {
+				t.Fatalf("unexpected time: got %s, want %s", gotTime, tc.want)
+			}
+		})
+	}
+
+	// relative dates are truncated to the day
+	got, err := parameters.NewDateParameter("my_date", "a date").Parse("now-1d")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	y, m, d := time.Now().UTC().Add(-24 * time.Hour).Date()
+	if want := time.Date(y, m, d, 0, 0, 0, 0, time.UTC); !got.(time.Time).Equal(want) {
+		t.Fatalf("unexpected date: got %s, want %s", got, want)
+	}
+}
+
 func TestAuthParametersParse(t *testing.T) {
 	authServices := []parameters.ParamAuthService{
 		{
@@ -1735,6 +2325,7 @@ func TestParamManifest(t *testing.T) {
 }
 
 func TestParamMcpManifest(t *testing.T) {
+	minLength := 3
 	tcs := []struct {
 		name          string
 		in            parameters.Parameter
@@ -1796,6 +2387,117 @@ func TestParamMcpManifest(t *testing.T) {
 			},
 			wantAuthParam: []string{},
 		},
+		{
+			name: "string with constraints",
+			in: &parameters.StringParameter{
+				CommonParameter: parameters.CommonParameter{
+					Name:             "foo-string",
+					Type:             "string",
+					Desc:             "bar",
+					AllowedValues:    []any{"low", "high"},
+					EnumDescriptions: []string{"first", "second"},
+				},
+				Pattern:   "^[a-z]+$",
+				MinLength: &minLength,
+			},
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Enum:        []any{"low", "high"},
+				OneOf: []parameters.ParameterEnumValue{
+					{Const: "low", Description: "first"},
+					{Const: "high", Description: "second"},
+				},
+				Pattern:   "^[a-z]+$",
+				MinLength: &minLength,
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "string with format",
+			in: &parameters.StringParameter{
+				CommonParameter: parameters.CommonParameter{Name: "foo-string", Type: "string", Desc: "bar"},
+				Format:          "date-time",
+			},
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Format:      "date-time",
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "string with regex allowed values",
+			in:   parameters.NewStringParameterWithAllowedValues("foo-string", "bar", []any{"^a.*"}),
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "array with item limits",
+			in: &parameters.ArrayParameter{
+				CommonParameter: parameters.CommonParameter{Name: "foo-array", Type: "array", Desc: "bar"},
+				Items:           parameters.NewFloatParameterWithAllowedValues("foo-float", "baz", []any{0.5, 1.5}),
+				MinItems:        &minLength,
+			},
+			want: parameters.ParameterMcpManifest{
+				Type:        "array",
+				Description: "bar",
+				Items:       &parameters.ParameterMcpManifest{Type: "number", Description: "baz", Enum: []any{0.5, 1.5}},
+				MinItems:    &minLength,
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "date",
+			in:   parameters.NewDateParameter("foo-date", "bar"),
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Format:      "date",
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "datetime",
+			in:   parameters.NewDateTimeParameter("foo-datetime", "bar"),
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Format:      "date-time",
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "duration",
+			in:   parameters.NewDurationParameter("foo-duration", "bar"),
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Format:      "duration",
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "object",
+			in: parameters.NewObjectParameter("foo-object", "bar", parameters.Parameters{
+				parameters.NewStringParameter("city", "a city"),
+				parameters.NewIntParameterWithDefault("zip", 1000, "a zip code"),
+			}),
+			want: parameters.ParameterMcpManifest{
+				Type:        "object",
+				Description: "bar",
+				Properties: map[string]parameters.ParameterMcpManifest{
+					"city": {Type: "string", Description: "a city"},
+					"zip":  {Type: "integer", Description: "a zip code", Default: 1000},
+				},
+				Required:             []string{"city"},
+				AdditionalProperties: false,
+			},
+			wantAuthParam: []string{},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1972,6 +2674,165 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "unsupported valueType \"not-a-real-type\" for map parameter",
 		},
+		{
+			name: "string with invalid pattern",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"pattern":     "[a-z",
+				},
+			},
+			err: "parameter \"my_string\" has an invalid pattern",
+		},
+		{
+			name: "string with unsupported format",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"format":      "phone",
+				},
+			},
+			err: "parameter \"my_string\" has an unsupported format \"phone\"",
+		},
+		{
+			name: "string with minLength greater than maxLength",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"minLength":   3,
+					"maxLength":   2,
+				},
+			},
+			err: "parameter \"my_string\" has a minLength greater than its maxLength",
+		},
+		{
+			name: "enumDescriptions without allowedValues",
+			in: []map[string]any{
+				{
+					"name":             "my_int",
+					"type":             "integer",
+					"description":      "this param is an int",
+					"enumDescriptions": []string{"one"},
+				},
+			},
+			err: "parameter \"my_int\" has 1 enumDescriptions for 0 allowedValues",
+		},
+		{
+			name: "array with negative minItems",
+			in: []map[string]any{
+				{
+					"name":        "my_array",
+					"type":        "array",
+					"description": "this param is an array of strings",
+					"minItems":    -1,
+					"items": map[string]string{
+						"name":        "my_string",
+						"type":        "string",
+						"description": "string item",
+					},
+				},
+			},
+			err: "parameter \"my_array\" has a negative minItems",
+		},
+		{
+			name: "object parameter missing properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+				},
+			},
+			err: "object parameter \"my_object\" must specify 'properties'",
+		},
+		{
+			name: "object parameter with duplicate properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{"name": "city", "type": "string", "description": "a city"},
+						{"name": "city", "type": "string", "description": "another city"},
+					},
+				},
+			},
+			err: "Duplicate parameter: city",
+		},
+		{
+			name: "object parameter with authenticated property",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":         "email",
+							"type":         "string",
+							"description":  "an email",
+							"authServices": []map[string]string{{"name": "my-auth", "field": "email"}},
+						},
+					},
+				},
+			},
+			err: "nested properties should not have auth services",
+		},
+		{
+			name: "datetime with invalid timezone",
+			in: []map[string]any{
+				{
+					"name":        "my_datetime",
+					"type":        "datetime",
+					"description": "this param is a datetime",
+					"timezone":    "Mars/Olympus_Mons",
+				},
+			},
+			err: "parameter \"my_datetime\" has an invalid timezone \"Mars/Olympus_Mons\"",
+		},
+		{
+			name: "date with invalid default",
+			in: []map[string]any{
+				{
+					"name":        "my_date",
+					"type":        "date",
+					"description": "this param is a date",
+					"default":     "yesterday",
+				},
+			},
+			err: "parameter \"my_date\" has an invalid default",
+		},
+		{
+			name: "duration with invalid default",
+			in: []map[string]any{
+				{
+					"name":        "my_duration",
+					"type":        "duration",
+					"description": "this param is a duration",
+					"default":     "a week",
+				},
+			},
+			err: "parameter \"my_duration\" has an invalid default",
+		},
+		{
+			name: "duration with embeddedBy",
+			in: []map[string]any{
+				{
+					"name":        "my_duration",
+					"type":        "duration",
+					"description": "this param is a duration",
+					"embeddedBy":  "my-model",
+				},
+			},
+			err: "parameter type \"duration\" cannot specify 'embeddedBy'",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2347,3 +3208,162 @@ func TestCheckParamRequired(t *testing.T) {
 		})
 	}
 }
+
+func TestIsExplicitlyRequired(t *testing.T) {
+	tcs := []struct {
+		name  string
+		param parameters.Parameter
+		want  bool
+	}{
+		{
+			name:  "default",
+			param: parameters.NewStringParameter("foo", "bar"),
+			want:  false,
+		},
+		{
+			name:  "required",
+			param: parameters.NewIntParameterWithRequired("foo", "bar", true),
+			want:  true,
+		},
+		{
+			name:  "not required",
+			param: parameters.NewBooleanParameterWithRequired("foo", "bar", false),
+			want:  false,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parameters.IsExplicitlyRequired(tc.param)
+			if got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParamMcpManifestMarshalNullable(t *testing.T) {
+	tcs := []struct {
+		name     string
+		manifest parameters.ParameterMcpManifest
+		want     string
+	}{
+		{
+			name:     "not nullable",
+			manifest: parameters.ParameterMcpManifest{Type: "string", Description: "foo"},
+			want:     `{"type":"string","description":"foo"}`,
+		},
+		{
+			name:     "nullable",
+			manifest: parameters.ParameterMcpManifest{Type: "integer", Description: "foo", Nullable: true},
+			want:     `{"type":["integer","null"],"description":"foo"}`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.manifest)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// compare as maps, since the field order is not significant
+			var gotM, wantM map[string]any
+			if err := json.Unmarshal(got, &gotM); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := json.Unmarshal([]byte(tc.want), &wantM); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(wantM, gotM); diff != "" {
+				t.Fatalf("unexpected manifest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	minV := 0.5
+	maxLength := 6
+	notRequired := false
+	copied := parameters.NewStringParameter("copy", "copied value")
+	copied.ValueFromParam = "name"
+	ps := parameters.Parameters{
+		parameters.NewStringParameterWithAllowedValues("name", "a name", []any{"^a.*"}),
+		parameters.NewFloatParameterWithRange("ratio", "a ratio", &minV, nil),
+		parameters.NewArrayParameterWithRequired("tags", "some tags", false, parameters.NewStringParameter("tag", "a tag")),
+		parameters.NewMapParameter("labels", "some labels", "float"),
+		&parameters.StringParameter{
+			CommonParameter: parameters.CommonParameter{
+				Name:             "status",
+				Type:             "string",
+				Desc:             "a status",
+				AllowedValues:    []any{"open", "closed"},
+				EnumDescriptions: []string{"still open", "now closed"},
+				Required:         &notRequired,
+			},
+			Pattern:   "^[a-z]+$",
+			MaxLength: &maxLength,
+		},
+		parameters.NewObjectParameterWithRequired("address", "an address", false, parameters.Parameters{
+			parameters.NewStringParameter("city", "a city"),
+			&parameters.ArrayParameter{
+				CommonParameter: parameters.CommonParameter{Name: "lines", Type: "array", Desc: "some lines"},
+				Items:           &parameters.StringParameter{CommonParameter: parameters.CommonParameter{Name: "line", Type: "string", Desc: "a line"}, Format: "uri"},
+				MaxItems:        &maxLength,
+			},
+		}),
+		parameters.NewDateTimeParameterWithDefault("since", "now-7d", "a start time"),
+		copied,
+	}
+	want := map[string]any{
+		"type":     "object",
+		"required": []string{"name", "ratio", "labels"},
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string", "description": "a name"},
+			"ratio": map[string]any{"type": "number", "description": "a ratio", "minimum": 0.5},
+			"tags": map[string]any{
+				"type":        "array",
+				"description": "some tags",
+				"items":       map[string]any{"type": "string", "description": "a tag"},
+			},
+			"labels": map[string]any{
+				"type":                 "object",
+				"description":          "some labels",
+				"additionalProperties": map[string]any{"type": "number"},
+			},
+			"status": map[string]any{
+				"type":        "string",
+				"description": "a status",
+				"pattern":     "^[a-z]+$",
+				"maxLength":   6,
+				"enum":        []any{"open", "closed"},
+				"oneOf": []any{
+					map[string]any{"const": "open", "description": "still open"},
+					map[string]any{"const": "closed", "description": "now closed"},
+				},
+			},
+			"since": map[string]any{
+				"type":        "string",
+				"description": "a start time",
+				"format":      "date-time",
+				"default":     "now-7d",
+			},
+			"address": map[string]any{
+				"type":                 "object",
+				"description":          "an address",
+				"additionalProperties": false,
+				"required":             []string{"city", "lines"},
+				"properties": map[string]any{
+					"city": map[string]any{"type": "string", "description": "a city"},
+					"lines": map[string]any{
+						"type":        "array",
+						"description": "some lines",
+						"maxItems":    6,
+						"items":       map[string]any{"type": "string", "description": "a line", "format": "uri"},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, ps.JSONSchema()); diff != "" {
+		t.Fatalf("unexpected schema (-want +got):\n%s", diff)
+	}
+}
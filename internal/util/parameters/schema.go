@@ -0,0 +1,185 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"regexp"
+)
+
+// JSONSchema returns the JSON schema of the object sent by clients to provide
+// the Parameters. Parameters populated from auth services or from other
+// parameters are not sent by clients, and are left out of the schema.
+func (ps Parameters) JSONSchema() map[string]any {
+	properties := make(map[string]any)
+	required := make([]string, 0)
+	for _, p := range ps {
+		if p.GetValueFromParam() != "" || len(p.GetAuthServices()) > 0 {
+			continue
+		}
+		properties[p.GetName()] = ParameterJSONSchema(p)
+		if CheckParamRequired(p.GetRequired(), p.GetDefault()) {
+			required = append(required, p.GetName())
+		}
+	}
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// ParameterJSONSchema returns the JSON schema of the value of a Parameter,
+// including its allowed values, ranges and other constraints.
+func ParameterJSONSchema(p Parameter) map[string]any {
+	schema := map[string]any{"type": jsonSchemaType(p.GetType())}
+	var allowedValues []any
+	var enumDescriptions []string
+	switch p := p.(type) {
+	case *StringParameter:
+		allowedValues = p.GetAllowedValues()
+		enumDescriptions = p.EnumDescriptions
+		schema["description"] = p.Desc
+		if p.Pattern != "" {
+			schema["pattern"] = p.Pattern
+		}
+		if p.MinLength != nil {
+			schema["minLength"] = *p.MinLength
+		}
+		if p.MaxLength != nil {
+			schema["maxLength"] = *p.MaxLength
+		}
+		if p.Format != "" {
+			schema["format"] = p.Format
+		}
+	case *IntParameter:
+		allowedValues = p.GetAllowedValues()
+		enumDescriptions = p.EnumDescriptions
+		schema["description"] = p.Desc
+		if p.MinValue != nil {
+			schema["minimum"] = *p.MinValue
+		}
+		if p.MaxValue != nil {
+			schema["maximum"] = *p.MaxValue
+		}
+	case *FloatParameter:
+		allowedValues = p.GetAllowedValues()
+		enumDescriptions = p.EnumDescriptions
+		schema["description"] = p.Desc
+		if p.MinValue != nil {
+			schema["minimum"] = *p.MinValue
+		}
+		if p.MaxValue != nil {
+			schema["maximum"] = *p.MaxValue
+		}
+	case *BooleanParameter:
+		schema["description"] = p.Desc
+	case *ArrayParameter:
+		schema["description"] = p.Desc
+		if p.Items != nil {
+			schema["items"] = ParameterJSONSchema(p.Items)
+		}
+		if p.MinItems != nil {
+			schema["minItems"] = *p.MinItems
+		}
+		if p.MaxItems != nil {
+			schema["maxItems"] = *p.MaxItems
+		}
+	case *MapParameter:
+		schema["type"] = "object"
+		schema["description"] = p.Desc
+		if p.ValueType != "" {
+			schema["additionalProperties"] = map[string]any{"type": jsonSchemaType(p.ValueType)}
+		} else {
+			schema["additionalProperties"] = true
+		}
+	case *DateTimeParameter:
+		allowedValues = p.GetAllowedValues()
+		schema["description"] = p.Desc
+		schema["format"] = jsonSchemaFormat(p.Type)
+	case *DurationParameter:
+		allowedValues = p.GetAllowedValues()
+		schema["description"] = p.Desc
+		schema["format"] = jsonSchemaFormat(p.Type)
+	case *ObjectParameter:
+		schema = p.Properties.JSONSchema()
+		schema["description"] = p.Desc
+		schema["additionalProperties"] = false
+	default:
+		m, _ := p.McpManifest()
+		schema["description"] = m.Description
+	}
+	if enum, ok := literalValues(allowedValues); ok {
+		schema["enum"] = enum
+		if len(enumDescriptions) == len(enum) {
+			oneOf := make([]any, len(enum))
+			for i, v := range enum {
+				oneOf[i] = map[string]any{"const": v, "description": enumDescriptions[i]}
+			}
+			schema["oneOf"] = oneOf
+		}
+	}
+	if d := p.GetDefault(); d != nil {
+		schema["default"] = d
+	}
+	return schema
+}
+
+// jsonSchemaType converts a parameter type to its JSON schema type.
+func jsonSchemaType(paramType string) string {
+	switch paramType {
+	case TypeFloat:
+		return "number"
+	case TypeMap:
+		return "object"
+	case TypeDate, TypeTime, TypeDateTime, TypeDuration:
+		return "string"
+	default:
+		return paramType
+	}
+}
+
+// jsonSchemaFormat returns the JSON schema format of the temporal parameter
+// types.
+func jsonSchemaFormat(paramType string) string {
+	switch paramType {
+	case TypeDate:
+		return "date"
+	case TypeTime:
+		return "time"
+	case TypeDateTime:
+		return "date-time"
+	case TypeDuration:
+		return "duration"
+	default:
+		return ""
+	}
+}
+
+// literalValues returns the allowed values if none of them is a regular
+// expression, in which case they cannot be listed as an enum.
+func literalValues(values []any) ([]any, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+	for _, v := range values {
+		if s, ok := v.(string); ok && regexp.QuoteMeta(s) != s {
+			return nil, false
+		}
+	}
+	return values, true
+}
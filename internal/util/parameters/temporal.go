@@ -0,0 +1,377 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	// embed the timezone database, so timezones can be loaded in images
+	// without one
+	_ "time/tzdata"
+)
+
+// defaultTimeLayouts are the layouts accepted by the temporal parameter types,
+// in addition to the formats of the parameter.
+var defaultTimeLayouts = map[string][]string{
+	TypeDate:     {time.DateOnly, time.RFC3339},
+	TypeTime:     {time.TimeOnly, "15:04", "15:04:05Z07:00"},
+	TypeDateTime: {time.RFC3339, "2006-01-02T15:04:05", time.DateTime, time.DateOnly},
+}
+
+// relativeTimeRegexp matches relative expressions such as "now" or "now-7d".
+var relativeTimeRegexp = regexp.MustCompile(`(?i)^now(?:\s*([+-])\s*(\S.*))?$`)
+
+// durationUnits are the units of the duration expressions. Days and weeks are
+// always 24 and 168 hours long.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+var (
+	durationRegexp      = regexp.MustCompile(`^(?:\d+(?:\.\d+)?(?:ns|us|µs|ms|s|m|h|d|w))+$`)
+	durationTokenRegexp = regexp.MustCompile(`(\d+(?:\.\d+)?)(ns|us|µs|ms|s|m|h|d|w)`)
+	isoDurationRegexp   = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// parseDuration parses a duration such as "1h30m", "7d" or the ISO 8601
+// "P7DT12H", with an optional sign.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	sign := 1.0
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+	var total float64
+	switch {
+	case durationRegexp.MatchString(s):
+		for _, m := range durationTokenRegexp.FindAllStringSubmatch(s, -1) {
+			n, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				return 0, err
+			}
+			total += n * float64(durationUnits[m[2]])
+		}
+	case isoDurationRegexp.MatchString(s) && s != "P" && !strings.HasSuffix(s, "T"):
+		m := isoDurationRegexp.FindStringSubmatch(s)
+		for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+			if m[i+1] == "" {
+				continue
+			}
+			n, err := strconv.ParseFloat(m[i+1], 64)
+			if err != nil {
+				return 0, err
+			}
+			total += n * float64(unit)
+		}
+	default:
+		return 0, fmt.Errorf("%q is not a valid duration, such as \"1h30m\", \"7d\" or \"P7DT12H\"", s)
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too long", s)
+	}
+	return time.Duration(math.Round(sign * total)), nil
+}
+
+// parseTime parses a relative expression such as "now-7d", or a time in one
+// of the layouts. Times without a timezone are in the location.
+func parseTime(s string, layouts []string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if m := relativeTimeRegexp.FindStringSubmatch(s); m != nil {
+		now := time.Now().In(loc)
+		if m[1] == "" {
+			return now, nil
+		}
+		d, err := parseDuration(m[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if m[1] == "-" {
+			d = -d
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q does not match any of the formats %q, or a relative expression such as \"now-7d\"", s, layouts)
+}
+
+// NewDateParameter is a convenience function for initializing a DateTimeParameter of type "date".
+func NewDateParameter(name string, desc string) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDate,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewTimeParameter is a convenience function for initializing a DateTimeParameter of type "time".
+func NewTimeParameter(name string, desc string) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeTime,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateTimeParameter is a convenience function for initializing a DateTimeParameter of type "datetime".
+func NewDateTimeParameter(name string, desc string) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDateTime,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateTimeParameterWithDefault is a convenience function for initializing a DateTimeParameter of type "datetime" with default value.
+func NewDateTimeParameterWithDefault(name string, defaultV string, desc string) *DateTimeParameter {
+	return &DateTimeParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDateTime,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+var _ Parameter = &DateTimeParameter{}
+
+// DateTimeParameter is a parameter representing the "date", "time" and
+// "datetime" types. Values are parsed as a time.Time in the timezone of the
+// parameter, UTC if unset. Dates are at midnight, and times are on January 1
+// of year 0.
+type DateTimeParameter struct {
+	CommonParameter `yaml:",inline"`
+	// Default can be a relative expression, such as "now-7d", which is
+	// evaluated on each invocation.
+	Default *string `yaml:"default"`
+	// Formats are Go time layouts, such as "02/01/2006", accepted in addition
+	// to RFC 3339.
+	Formats []string `yaml:"formats"`
+	// Timezone is the IANA name of the timezone, such as "Europe/Paris".
+	Timezone string `yaml:"timezone"`
+}
+
+// location returns the timezone of the parameter.
+func (p *DateTimeParameter) location() (*time.Location, error) {
+	if p.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q has an invalid timezone %q: %w", p.Name, p.Timezone, err)
+	}
+	return loc, nil
+}
+
+// validateDefault checks that the default value, if any, can be parsed.
+func (p *DateTimeParameter) validateDefault() error {
+	if p.Default == nil {
+		return nil
+	}
+	if _, err := p.Parse(*p.Default); err != nil {
+		return fmt.Errorf("parameter %q has an invalid default: %w", p.Name, err)
+	}
+	return nil
+}
+
+// Parse parses the value "v" as a time.Time, normalized to the timezone of
+// the parameter.
+func (p *DateTimeParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if !p.IsAllowedValues(s) {
+		return nil, fmt.Errorf("%s is not an allowed value", s)
+	}
+	if p.IsExcludedValues(s) {
+		return nil, fmt.Errorf("%s is an excluded value", s)
+	}
+	loc, err := p.location()
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTime(s, slices.Concat(defaultTimeLayouts[p.Type], p.Formats), loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", p.Type, err)
+	}
+	t = t.In(loc)
+	switch p.Type {
+	case TypeDate:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), nil
+	case TypeTime:
+		return time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), nil
+	default:
+		return t, nil
+	}
+}
+
+func (p *DateTimeParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DateTimeParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DateTimeParameter.
+func (p *DateTimeParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the DateTimeParameter.
+// json schema represents dates and times as strings with a format.
+func (p *DateTimeParameter) McpManifest() (ParameterMcpManifest, []string) {
+	m, authServiceNames := p.CommonParameter.McpManifest()
+	m.Type = jsonSchemaType(p.Type)
+	m.Format = jsonSchemaFormat(p.Type)
+	return m, authServiceNames
+}
+
+// NewDurationParameter is a convenience function for initializing a DurationParameter.
+func NewDurationParameter(name string, desc string) *DurationParameter {
+	return &DurationParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDuration,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDurationParameterWithDefault is a convenience function for initializing a DurationParameter with default value.
+func NewDurationParameterWithDefault(name string, defaultV string, desc string) *DurationParameter {
+	return &DurationParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeDuration,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+var _ Parameter = &DurationParameter{}
+
+// DurationParameter is a parameter representing the "duration" type. Values
+// such as "1h30m", "7d" or "P7DT12H" are parsed as a time.Duration.
+type DurationParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+// validateDefault checks that the default value, if any, can be parsed.
+func (p *DurationParameter) validateDefault() error {
+	if p.Default == nil {
+		return nil
+	}
+	if _, err := p.Parse(*p.Default); err != nil {
+		return fmt.Errorf("parameter %q has an invalid default: %w", p.Name, err)
+	}
+	return nil
+}
+
+// Parse parses the value "v" as a time.Duration.
+func (p *DurationParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if !p.IsAllowedValues(s) {
+		return nil, fmt.Errorf("%s is not an allowed value", s)
+	}
+	if p.IsExcludedValues(s) {
+		return nil, fmt.Errorf("%s is an excluded value", s)
+	}
+	return parseDuration(s)
+}
+
+func (p *DurationParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DurationParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DurationParameter.
+func (p *DurationParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+		Default:      p.GetDefault(),
+	}
+}
+
+// McpManifest returns the MCP manifest for the DurationParameter.
+func (p *DurationParameter) McpManifest() (ParameterMcpManifest, []string) {
+	m, authServiceNames := p.CommonParameter.McpManifest()
+	m.Type = jsonSchemaType(p.Type)
+	m.Format = jsonSchemaFormat(p.Type)
+	return m, authServiceNames
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ResultLimits limit the size of the result of a query. A value of 0 means
+// that there is no limit.
+type ResultLimits struct {
+	// MaxRows is the maximum number of rows returned.
+	MaxRows int `yaml:"maxRows,omitempty" validate:"gte=0"`
+	// MaxResultBytes is the maximum size of the returned rows, measured as
+	// their JSON encoding.
+	MaxResultBytes int `yaml:"maxResultBytes,omitempty" validate:"gte=0"`
+}
+
+// Merge returns the stricter of both limits.
+func (l ResultLimits) Merge(other ResultLimits) ResultLimits {
+	return ResultLimits{
+		MaxRows:        minLimit(l.MaxRows, other.MaxRows),
+		MaxResultBytes: minLimit(l.MaxResultBytes, other.MaxResultBytes),
+	}
+}
+
+func minLimit(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+type contextKey string
+
+// resultLimitsKey is the key used to store the result limits within context
+const resultLimitsKey contextKey = "resultLimits"
+
+// WithResultLimits adds result limits into the context. If the context already
+// has limits, the stricter of both apply.
+func WithResultLimits(ctx context.Context, limits ResultLimits) context.Context {
+	return context.WithValue(ctx, resultLimitsKey, ResultLimitsFromContext(ctx).Merge(limits))
+}
+
+// ResultLimitsFromContext retrieves the result limits, which are empty if none
+// were set.
+func ResultLimitsFromContext(ctx context.Context) ResultLimits {
+	if limits, ok := ctx.Value(resultLimitsKey).(ResultLimits); ok {
+		return limits
+	}
+	return ResultLimits{}
+}
+
+// TruncatedResult is returned instead of the rows when a limit was reached.
+type TruncatedResult struct {
+	Rows      []any  `json:"rows"`
+	RowCount  int    `json:"rowCount"`
+	Truncated bool   `json:"truncated"`
+	Message   string `json:"message"`
+}
+
+// Rows collects the rows of a query result until a limit is reached.
+type Rows struct {
+	limits ResultLimits
+	rows   []any
+	bytes  int
+	// reason is the name of the limit that was reached, if any
+	reason string
+}
+
+// NewRows returns a collector for the rows of a query. The limits of the
+// source are combined with the limits of the tool from the context.
+func NewRows(ctx context.Context, sourceLimits ResultLimits) *Rows {
+	return &Rows{limits: ResultLimitsFromContext(ctx).Merge(sourceLimits)}
+}
+
+// Add adds a row to the result. It returns false once a limit is reached, in
+// which case the row is dropped and no more rows should be read.
+func (r *Rows) Add(row any) bool {
+	if r.limits.MaxRows > 0 && len(r.rows) >= r.limits.MaxRows {
+		r.reason = "maxRows"
+		return false
+	}
+	if r.limits.MaxResultBytes > 0 {
+		// rows that cannot be encoded fail when the response is sent, so
+		// they are not counted here
+		b, _ := json.Marshal(row)
+		if r.bytes+len(b) > r.limits.MaxResultBytes {
+			r.reason = "maxResultBytes"
+			return false
+		}
+		r.bytes += len(b)
+	}
+	r.rows = append(r.rows, row)
+	return true
+}
+
+// Len returns the number of collected rows.
+func (r *Rows) Len() int {
+	return len(r.rows)
+}
+
+// Result returns the collected rows, or a TruncatedResult if a limit was
+// reached.
+func (r *Rows) Result() any {
+	if r.reason == "" {
+		return r.rows
+	}
+	return TruncatedResult{
+		Rows:      r.rows,
+		RowCount:  len(r.rows),
+		Truncated: true,
+		Message:   fmt.Sprintf("The result was truncated to %d rows because it exceeded %s. Refine the query, for example by adding filters, selecting fewer columns or using a LIMIT clause, to retrieve the remaining rows.", len(r.rows), r.reason),
+	}
+}
+
+// ResultRows returns the rows of a result returned by Rows.Result, whether it
+// was truncated or not.
+func ResultRows(result any) ([]any, bool) {
+	switch r := result.(type) {
+	case []any:
+		return r, true
+	case TruncatedResult:
+		return r.Rows, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+)
+
+func TestResultLimitsMerge(t *testing.T) {
+	tcs := []struct {
+		desc string
+		a, b sqlutil.ResultLimits
+		want sqlutil.ResultLimits
+	}{
+		{desc: "no limits"},
+		{
+			desc: "one side set",
+			a:    sqlutil.ResultLimits{MaxRows: 10},
+			b:    sqlutil.ResultLimits{MaxResultBytes: 100},
+			want: sqlutil.ResultLimits{MaxRows: 10, MaxResultBytes: 100},
+		},
+		{
+			desc: "stricter limit wins",
+			a:    sqlutil.ResultLimits{MaxRows: 10, MaxResultBytes: 50},
+			b:    sqlutil.ResultLimits{MaxRows: 5, MaxResultBytes: 100},
+			want: sqlutil.ResultLimits{MaxRows: 5, MaxResultBytes: 50},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.a.Merge(tc.b); got != tc.want {
+				t.Fatalf("unexpected limits: got %+v, want %+v", got, tc.want)
+			}
+			if got := tc.b.Merge(tc.a); got != tc.want {
+				t.Fatalf("merge is not symmetric: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	row := map[string]any{"id": 1} // `{"id":1}` is 8 bytes
+	tcs := []struct {
+		desc         string
+		sourceLimits sqlutil.ResultLimits
+		toolLimits   sqlutil.ResultLimits
+		rows         int
+		want         any
+	}{
+		{
+			desc: "no rows",
+			want: []any(nil),
+		},
+		{
+			desc: "no limits",
+			rows: 3,
+			want: []any{row, row, row},
+		},
+		{
+			desc:         "within limits",
+			sourceLimits: sqlutil.ResultLimits{MaxRows: 3, MaxResultBytes: 24},
+			rows:         3,
+			want:         []any{row, row, row},
+		},
+		{
+			desc:         "max rows of source",
+			sourceLimits: sqlutil.ResultLimits{MaxRows: 2},
+			rows:         3,
+			want: sqlutil.TruncatedResult{
+				Rows:      []any{row, row},
+				RowCount:  2,
+				Truncated: true,
+				Message:   "The result was truncated to 2 rows because it exceeded maxRows. Refine the query, for example by adding filters, selecting fewer columns or using a LIMIT clause, to retrieve the remaining rows.",
+			},
+		},
+		{
+			desc:         "max rows of tool is stricter",
+			sourceLimits: sqlutil.ResultLimits{MaxRows: 2},
+			toolLimits:   sqlutil.ResultLimits{MaxRows: 1},
+			rows:         3,
+			want: sqlutil.TruncatedResult{
+				Rows:      []any{row},
+				RowCount:  1,
+				Truncated: true,
+				Message:   "The result was truncated to 1 rows because it exceeded maxRows. Refine the query, for example by adding filters, selecting fewer columns or using a LIMIT clause, to retrieve the remaining rows.",
+			},
+		},
+		{
+			desc:       "max result bytes",
+			toolLimits: sqlutil.ResultLimits{MaxResultBytes: 20},
+			rows:       3,
+			want: sqlutil.TruncatedResult{
+				Rows:      []any{row, row},
+				RowCount:  2,
+				Truncated: true,
+				Message:   "The result was truncated to 2 rows because it exceeded maxResultBytes. Refine the query, for example by adding filters, selecting fewer columns or using a LIMIT clause, to retrieve the remaining rows.",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := sqlutil.WithResultLimits(context.Background(), tc.toolLimits)
+			out := sqlutil.NewRows(ctx, tc.sourceLimits)
+			for i := 0; i < tc.rows; i++ {
+				if !out.Add(row) {
+					break
+				}
+			}
+			got := out.Result()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected result (-want +got):\n%s", diff)
+			}
+			rows, ok := sqlutil.ResultRows(got)
+			if !ok || len(rows) != out.Len() {
+				t.Fatalf("unexpected rows: got %v", rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlutil contains helpers shared by the SQL based tools.
+package sqlutil
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ErrNotReadOnly is returned when a statement is rejected in read-only mode.
+var ErrNotReadOnly = errors.New("statement is not allowed in read-only mode")
+
+// readOnlyKeywords are the keywords a statement may start with in read-only
+// mode.
+var readOnlyKeywords = []string{"SELECT", "WITH", "SHOW", "EXPLAIN", "DESCRIBE", "DESC", "VALUES", "TABLE"}
+
+// writeKeywords are the keywords that are not allowed anywhere in a
+// statement in read-only mode, for example in data-modifying CTEs,
+// `EXPLAIN ANALYZE` or `SELECT ... INTO`.
+var writeKeywords = []string{
+	"INSERT", "UPDATE", "DELETE", "MERGE",
+	"CREATE", "ALTER", "DROP", "TRUNCATE", "RENAME",
+	"GRANT", "REVOKE", "CALL", "EXEC", "EXECUTE", "COPY", "LOCK", "INTO",
+	"ATTACH", "DETACH", "VACUUM",
+}
+
+// tsqlStatementKeywords are the keywords that start a T-SQL statement. T-SQL
+// does not require statements to be separated by a semicolon, so these are
+// not allowed anywhere in a statement, for example in `SELECT 1 SHUTDOWN`.
+// Columns with the same name must be quoted.
+var tsqlStatementKeywords = []string{
+	"BACKUP", "BEGIN", "BREAK", "BULK", "CHECKPOINT", "CLOSE", "COMMIT",
+	"CONTINUE", "DBCC", "DEALLOCATE", "DECLARE", "DENY", "DISABLE", "ENABLE",
+	"GET", "GOTO", "IF", "KILL", "MOVE", "OPEN", "PRINT", "RAISERROR",
+	"READTEXT", "RECEIVE", "RECONFIGURE", "RESTORE", "RETURN", "REVERT",
+	"ROLLBACK", "SAVE", "SEND", "SET", "SETUSER", "SHUTDOWN", "THROW",
+	"UPDATETEXT", "USE", "WAITFOR", "WHILE", "WRITETEXT",
+}
+
+// Dialect is the SQL dialect statements are classified for.
+type Dialect int
+
+const (
+	// DialectStandard is used for engines that require statements to be
+	// separated by a semicolon.
+	DialectStandard Dialect = iota
+	// DialectTSQL is used for SQL Server, which does not require statements to
+	// be separated and quotes identifiers with brackets.
+	DialectTSQL
+)
+
+// CheckReadOnly classifies the statements of sql and returns an error wrapping
+// ErrNotReadOnly if any of them may write. It is used for engines that do not
+// offer a read-only mode, or whose read-only mode can be escaped, and errs on
+// the side of rejecting statements it cannot classify.
+func CheckReadOnly(dialect Dialect, sql string) error {
+	// Engines disagree on whether a backslash escapes a quote within a string
+	// literal. Check both interpretations so that a literal cannot be used to
+	// hide a statement from the classifier.
+	for _, backslashEscapes := range []bool{false, true} {
+		for _, stmt := range splitStatements(dialect, sql, backslashEscapes) {
+			if err := checkStatement(dialect, stmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkStatement(dialect Dialect, words []string) error {
+	if len(words) == 0 {
+		return nil
+	}
+	if !slices.Contains(readOnlyKeywords, words[0]) {
+		return fmt.Errorf("%w: %q statements are not allowed", ErrNotReadOnly, words[0])
+	}
+	if dialect == DialectStandard && describesSchema(words) {
+		// names such as `lock` or keywords such as `SHOW CREATE TABLE` are not
+		// writes in these statements
+		return nil
+	}
+	for _, w := range words[1:] {
+		if slices.Contains(writeKeywords, w) || (dialect == DialectTSQL && slices.Contains(tsqlStatementKeywords, w)) {
+			return fmt.Errorf("%w: %q statements are not allowed", ErrNotReadOnly, w)
+		}
+	}
+	return nil
+}
+
+// describesSchema returns true for statements that only describe the schema:
+// SHOW statements, and `DESCRIBE table [column]`. DESCRIBE followed by a
+// statement, such as `DESCRIBE ANALYZE DELETE ...`, may execute it.
+func describesSchema(words []string) bool {
+	switch words[0] {
+	case "SHOW":
+		return true
+	case "DESCRIBE", "DESC":
+		return len(words) <= 3
+	default:
+		return false
+	}
+}
+
+// splitStatements returns the upper-cased words of each statement in sql.
+// String literals, quoted identifiers and comments are skipped, except for
+// MySQL executable comments (`/*! ... */`) whose content is executed.
+func splitStatements(dialect Dialect, sql string, backslashEscapes bool) [][]string {
+	var stmts [][]string
+	var words []string
+	r := []rune(sql)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case c == ';':
+			stmts = append(stmts, words)
+			words = nil
+			i++
+		case c == '-' && i+1 < len(r) && r[i+1] == '-':
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(r) && r[i+1] == '*':
+			if i+2 < len(r) && r[i+2] == '!' {
+				// executable comment, scan its content
+				i += 3
+				continue
+			}
+			i += 2
+			for i < len(r) && (r[i] != '*' || i+1 >= len(r) || r[i+1] != '/') {
+				i++
+			}
+			i += 2
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(r, i, c, backslashEscapes && c != '`')
+		case c == '[' && dialect == DialectTSQL:
+			i = skipQuoted(r, i, ']', false)
+		case isWordStart(c):
+			start := i
+			for i < len(r) && isWordPart(r[i]) {
+				i++
+			}
+			// qualified names such as `t.update` are not keywords
+			if start > 0 && r[start-1] == '.' {
+				continue
+			}
+			words = append(words, strings.ToUpper(string(r[start:i])))
+		default:
+			i++
+		}
+	}
+	return append(stmts, words)
+}
+
+// skipQuoted returns the index after the quoted string starting at i, which
+// ends with the quote character. A doubled quote character is treated as an
+// escaped quote.
+func skipQuoted(r []rune, i int, quote rune, backslashEscapes bool) int {
+	i++
+	for i < len(r) {
+		switch {
+		case backslashEscapes && r[i] == '\\':
+			i += 2
+		case r[i] == quote && i+1 < len(r) && r[i+1] == quote:
+			i += 2
+		case r[i] == quote:
+			return i + 1
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+func isWordStart(c rune) bool {
+	return c == '_' || unicode.IsLetter(c)
+}
+
+func isWordPart(c rune) bool {
+	return c == '_' || c == '$' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	tcs := []struct {
+		desc string
+		sql  string
+		err  string
+	}{
+		{desc: "select", sql: "SELECT * FROM flights WHERE id = 1"},
+		{desc: "lower case select", sql: "select id from flights;"},
+		{desc: "cte", sql: "WITH f AS (SELECT * FROM flights) SELECT count(*) FROM f"},
+		{desc: "show", sql: "SHOW TABLES"},
+		{desc: "explain", sql: "EXPLAIN SELECT 1"},
+		{desc: "leading comments", sql: "-- list flights\n/* all of them */ SELECT * FROM flights"},
+		{desc: "parenthesized select", sql: "(SELECT 1) UNION (SELECT 2)"},
+		{desc: "keyword in string literal", sql: "SELECT * FROM logs WHERE action = 'DELETE'"},
+		{desc: "keyword in quoted identifier", sql: `SELECT "update", ` + "`delete`" + ` FROM t`},
+		{desc: "qualified column name", sql: "SELECT t.update FROM t"},
+		{desc: "multiple selects", sql: "SELECT 1; SELECT 2;"},
+		{desc: "replace function", sql: "SELECT REPLACE(name, 'a', 'b') FROM t"},
+		{desc: "show create table", sql: "SHOW CREATE TABLE flights"},
+		{desc: "show create view", sql: "SHOW CREATE VIEW v"},
+		{desc: "describe table named lock", sql: "DESCRIBE lock"},
+		{desc: "describe column named into", sql: "DESC flights into"},
+		{desc: "describe analyze", sql: "DESCRIBE ANALYZE DELETE FROM flights", err: `"DELETE" statements are not allowed`},
+		{desc: "replace", sql: "REPLACE INTO flights VALUES (1)", err: `"REPLACE" statements are not allowed`},
+		{desc: "insert", sql: "INSERT INTO flights VALUES (1)", err: `"INSERT" statements are not allowed`},
+		{desc: "lower case delete", sql: "delete from flights", err: `"DELETE" statements are not allowed`},
+		{desc: "ddl", sql: "DROP TABLE flights", err: `"DROP" statements are not allowed`},
+		{desc: "set", sql: "SET autocommit = 1", err: `"SET" statements are not allowed`},
+		{desc: "write after select", sql: "SELECT 1; UPDATE flights SET id = 2", err: `"UPDATE" statements are not allowed`},
+		{desc: "data modifying cte", sql: "WITH d AS (DELETE FROM flights RETURNING *) SELECT * FROM d", err: `"DELETE" statements are not allowed`},
+		{desc: "explain analyze", sql: "EXPLAIN ANALYZE DELETE FROM flights", err: `"DELETE" statements are not allowed`},
+		{desc: "select into", sql: "SELECT * INTO backup FROM flights", err: `"INTO" statements are not allowed`},
+		{desc: "select for update", sql: "SELECT * FROM flights FOR UPDATE", err: `"UPDATE" statements are not allowed`},
+		{desc: "executable comment", sql: "SELECT 1 /*! ; DELETE FROM flights */", err: `"DELETE" statements are not allowed`},
+		{desc: "write hidden by backslash escape", sql: `SELECT 'a\'; DELETE FROM flights; -- '`, err: `"DELETE" statements are not allowed`},
+		{desc: "write hidden by standard string", sql: `SELECT 'a\''; DELETE FROM flights; -- '`, err: `"DELETE" statements are not allowed`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, tc.sql)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expect statement to be rejected")
+			}
+			if !errors.Is(err, sqlutil.ErrNotReadOnly) {
+				t.Fatalf("expect error to wrap ErrNotReadOnly, got %s", err)
+			}
+			want := "statement is not allowed in read-only mode: " + tc.err
+			if err.Error() != want {
+				t.Fatalf("unexpected error: got %q, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestCheckReadOnlyTSQL(t *testing.T) {
+	tcs := []struct {
+		desc string
+		sql  string
+		err  string
+	}{
+		{desc: "select", sql: "SELECT TOP 10 * FROM flights WHERE id = 1"},
+		{desc: "case expression", sql: "SELECT CASE WHEN id > 1 THEN 'a' ELSE 'b' END FROM flights"},
+		{desc: "bracket quoted keyword", sql: "SELECT [open], [close] FROM prices"},
+		{desc: "offset fetch", sql: "SELECT id FROM flights ORDER BY id OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY"},
+		{desc: "shutdown without separator", sql: "SELECT 1 SHUTDOWN", err: `"SHUTDOWN" statements are not allowed`},
+		{desc: "kill without separator", sql: "SELECT 1 KILL 53", err: `"KILL" statements are not allowed`},
+		{desc: "backup without separator", sql: "SELECT 1 BACKUP DATABASE flights TO DISK = 'x.bak'", err: `"BACKUP" statements are not allowed`},
+		{desc: "dbcc without separator", sql: "SELECT 1 DBCC FREEPROCCACHE", err: `"DBCC" statements are not allowed`},
+		{desc: "deny without separator", sql: "SELECT 1 DENY SELECT ON flights TO public", err: `"DENY" statements are not allowed`},
+		{desc: "set without separator", sql: "SELECT 1 SET IMPLICIT_TRANSACTIONS ON", err: `"SET" statements are not allowed`},
+		{desc: "unquoted keyword column", sql: "SELECT open FROM prices", err: `"OPEN" statements are not allowed`},
+		{desc: "write hidden by bracket quote", sql: "SELECT 1 AS [a'] SHUTDOWN --']", err: `"SHUTDOWN" statements are not allowed`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := sqlutil.CheckReadOnly(sqlutil.DialectTSQL, tc.sql)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			want := "statement is not allowed in read-only mode: " + tc.err
+			if err == nil || err.Error() != want {
+				t.Fatalf("unexpected error: got %v, want %q", err, want)
+			}
+		})
+	}
+
+	// T-SQL statements are allowed as identifiers in other dialects
+	if err := sqlutil.CheckReadOnly(sqlutil.DialectStandard, "SELECT open, close FROM prices"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatementTimeout returns the time left until the deadline of the context,
+// rounded up to the millisecond, so that sources can push the deadline down to
+// the database. It returns false if the context has no deadline.
+func StatementTimeout(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	timeout := time.Until(deadline)
+	// a timeout of 0 disables the timeout on most engines
+	if timeout < time.Millisecond {
+		return time.Millisecond, true
+	}
+	if rem := timeout % time.Millisecond; rem != 0 {
+		timeout += time.Millisecond - rem
+	}
+	return timeout, true
+}
+
+// WithMaxExecutionTime adds a MySQL `MAX_EXECUTION_TIME` optimizer hint to a
+// SELECT statement if the context has a deadline. Other statements, and
+// statements that already have optimizer hints, are returned unchanged.
+func WithMaxExecutionTime(ctx context.Context, statement string) string {
+	timeout, ok := StatementTimeout(ctx)
+	if !ok {
+		return statement
+	}
+	trimmed := strings.TrimLeft(statement, " \t\r\n")
+	if len(trimmed) < len("SELECT") || !strings.EqualFold(trimmed[:len("SELECT")], "SELECT") {
+		return statement
+	}
+	rest := trimmed[len("SELECT"):]
+	if rest != "" && isWordPart(rune(rest[0])) {
+		return statement
+	}
+	if strings.HasPrefix(strings.TrimLeft(rest, " \t\r\n"), "/*+") {
+		return statement
+	}
+	prefix := statement[:len(statement)-len(trimmed)]
+	return fmt.Sprintf("%s%s /*+ MAX_EXECUTION_TIME(%d) */%s", prefix, trimmed[:len("SELECT")], timeout.Milliseconds(), rest)
+}
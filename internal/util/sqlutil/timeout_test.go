@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/util/sqlutil"
+)
+
+func TestStatementTimeout(t *testing.T) {
+	if _, ok := sqlutil.StatementTimeout(context.Background()); ok {
+		t.Fatalf("expect no timeout without a deadline")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	timeout, ok := sqlutil.StatementTimeout(ctx)
+	if !ok || timeout <= 59*time.Second || timeout > time.Minute {
+		t.Fatalf("unexpected timeout: %s", timeout)
+	}
+
+	deadline := time.Now().Add(time.Minute + 700*time.Microsecond)
+	ctx, cancel = context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	timeout, _ = sqlutil.StatementTimeout(ctx)
+	if timeout%time.Millisecond != 0 || timeout < time.Until(deadline) {
+		t.Fatalf("expect the timeout to be rounded up to the millisecond, got %s", timeout)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if timeout, _ := sqlutil.StatementTimeout(ctx); timeout != time.Millisecond {
+		t.Fatalf("expect an expired deadline to be rounded up to 1ms, got %s", timeout)
+	}
+}
+
+func TestWithMaxExecutionTime(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	// the remaining time depends on when the statement is rewritten
+	hint := regexp.MustCompile(`MAX_EXECUTION_TIME\(\d+\)`)
+
+	tcs := []struct {
+		desc string
+		sql  string
+		want string
+	}{
+		{desc: "select", sql: "SELECT * FROM flights", want: "SELECT /*+ MAX_EXECUTION_TIME(n) */ * FROM flights"},
+		{desc: "lower case select with whitespace", sql: "\n  select 1", want: "\n  select /*+ MAX_EXECUTION_TIME(n) */ 1"},
+		{desc: "existing hint", sql: "SELECT /*+ BKA(t1) */ * FROM t1", want: "SELECT /*+ BKA(t1) */ * FROM t1"},
+		{desc: "not a select", sql: "UPDATE flights SET id = 1", want: "UPDATE flights SET id = 1"},
+		{desc: "identifier starting with select", sql: "SELECTED", want: "SELECTED"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := hint.ReplaceAllString(sqlutil.WithMaxExecutionTime(ctx, tc.sql), "MAX_EXECUTION_TIME(n)")
+			if got != tc.want {
+				t.Fatalf("unexpected statement: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if got := sqlutil.WithMaxExecutionTime(context.Background(), "SELECT 1"); got != "SELECT 1" {
+		t.Fatalf("expect statement without deadline to be unchanged, got %q", got)
+	}
+}
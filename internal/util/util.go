@@ -190,3 +190,24 @@ func InstrumentationFromContext(ctx context.Context) (*telemetry.Instrumentation
 }
 
 var ErrUnauthorized = errors.New("unauthorized")
+
+var ErrForbidden = errors.New("forbidden")
+
+// ProgressReporter sends a progress update of a long-running operation to the
+// client. A total of 0 indicates that the total is unknown.
+type ProgressReporter func(ctx context.Context, progress, total float64, message string)
+
+const progressReporterKey contextKey = "progressReporter"
+
+// WithProgressReporter adds a progress reporter into the context as a value
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey, reporter)
+}
+
+// ReportProgress sends a progress update using the reporter within the
+// context. It is a no-op if the client did not request progress updates.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	if reporter, ok := ctx.Value(progressReporterKey).(ProgressReporter); ok {
+		reporter(ctx, progress, total, message)
+	}
+}